@@ -0,0 +1,437 @@
+package collect
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/events"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/gocolly/colly/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// sessionCtx is the request context key of the login generation the request is sent with
+	sessionCtx = "AuthSession"
+	// reloginCtx is the request context key of the page requested again after the login
+	reloginCtx = "AuthRelogin"
+)
+
+type (
+	// Session logs in by the form of config.AuthLogin and adds the auth headers of the hosts.
+	// The cookies of the login are set to the jar shared with the collector.
+	Session struct {
+		auth   *config.Auth
+		jar    http.CookieJar
+		client *http.Client
+		lock   *sync.Mutex
+		// logins is the generation of the session, incremented by each login
+		logins atomic.Int32
+	}
+
+	// FileCookie is the cookie of the Netscape cookies.txt with the URL it is set for
+	FileCookie struct {
+		URL    *url.URL
+		Cookie *http.Cookie
+	}
+)
+
+// NewSession of the auth config, the login requests are sent by the transport with the jar cookies
+func NewSession(auth *config.Auth, jar http.CookieJar, transport http.RoundTripper) *Session {
+
+	return &Session{
+		auth: auth,
+		jar:  jar,
+		client: &http.Client{
+			Transport: transport,
+			Jar:       jar,
+			Timeout:   30 * time.Second,
+		},
+		lock: &sync.Mutex{},
+	}
+}
+
+// withAuth imports the cookies file, adds the auth headers and re-login on the logged-out pages
+func (crawler *Crawler) withAuth() error {
+
+	auth := crawler.args.Auth
+	if auth == nil {
+		return nil
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+	if crawler.proxies != nil {
+		transport = crawler.proxies.Transport()
+	}
+
+	// login is recorded and replayed with the pages
+	crawler.session = NewSession(auth, crawler.deps.Cookies, crawler.cached(transport))
+
+	if len(auth.CookiesFile) > 0 {
+		if err := crawler.session.Import(auth.CookiesFile); err != nil {
+			return err
+		}
+	}
+
+	crawler.collect.OnRequest(crawler.session.request)
+	crawler.collect.OnResponse(crawler.loggedOut)
+
+	return nil
+}
+
+// login before the crawl, skipped if the session of the previous run is restored
+// and might be checked by the logged-out marker
+func (crawler *Crawler) login() error {
+
+	if crawler.session == nil || crawler.args.Auth.Login == nil {
+		return nil
+	}
+
+	if len(crawler.args.Auth.LoggedOut) > 0 && crawler.session.Restored(crawler.args.StartURLs[0]) {
+		slog.Info("auth: session restored")
+		return nil
+	}
+
+	return crawler.session.Login()
+}
+
+// isRelogin returns true for the page requested again after the login
+func isRelogin(r *colly.Request) bool {
+	return r.Ctx != nil && r.Ctx.Get(reloginCtx) == "true"
+}
+
+// loggedOut marks the page matched by the logged-out marker, logs in again and requests the page once more
+func (crawler *Crawler) loggedOut(r *colly.Response) {
+
+	if !crawler.args.Auth.IsLoggedOut(r.Body) {
+		return
+	}
+
+	// skipped by the extraction and links discovery
+	r.Ctx.Put(config.LoggedOutCtx, "true")
+
+	slog.Warn("auth: logged out", slog.String("url", r.Request.URL.String()))
+
+	// page is requested again once
+	if crawler.args.Auth.Login == nil || isRelogin(r.Request) {
+		return
+	}
+
+	generation, _ := r.Ctx.GetAny(sessionCtx).(int32)
+	if err := crawler.session.Relogin(generation); err != nil {
+		slog.Error("auth: login failed", slog.String("error", err.Error()))
+		return
+	}
+
+	// the page is requested again by the queue, the logged-out response is skipped
+	if err := crawler.queue.AddRequest(relogin(r.Request)); err != nil {
+		slog.Warn("auth: retry failed",
+			slog.String("url", r.Request.URL.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// relogin is the copy of the logged-out page request with the fresh context,
+// the queue keys are kept, e.g. the frontier class and the pagination page
+func relogin(req *colly.Request) *colly.Request {
+
+	ctx := colly.NewContext()
+	for _, key := range []string{frontier.ClassCtx, events.PaginationPageCtx, events.PaginationBaseCtx} {
+		if value := req.Ctx.Get(key); len(value) > 0 {
+			ctx.Put(key, value)
+		}
+	}
+	ctx.Put(reloginCtx, "true")
+
+	req = events.CopyRequest(req, ctx)
+	// the cookies of the new session are set by the jar
+	if req.Headers != nil {
+		req.Headers.Del("Cookie")
+	}
+
+	return req
+}
+
+// Header of the host, nil if the session is not set
+func (s *Session) Header(host string) http.Header {
+
+	if s == nil {
+		return nil
+	}
+
+	return s.auth.Header(host)
+}
+
+// request sets the auth headers of the host and the login generation
+func (s *Session) request(r *colly.Request) {
+
+	header := s.auth.Header(r.URL.Hostname())
+	for name := range header {
+		r.Headers.Set(name, header.Get(name))
+	}
+
+	r.Ctx.Put(sessionCtx, s.logins.Load())
+}
+
+// Restored returns true if the jar has the cookies of the login site and the probe page is not logged out,
+// e.g. the cookies of the previous run or the imported ones. Any cookie, e.g. the tracking one, is not the session.
+func (s *Session) Restored(probe string) bool {
+
+	u, err := url.Parse(s.auth.Login.URL)
+	if err != nil || len(s.jar.Cookies(u)) == 0 {
+		return false
+	}
+
+	req, err := http.NewRequest(http.MethodGet, probe, nil)
+	if err != nil {
+		return false
+	}
+
+	header := s.auth.Header(req.URL.Hostname())
+	for name := range header {
+		req.Header.Set(name, header.Get(name))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false
+	}
+
+	return resp.StatusCode < http.StatusBadRequest && !s.auth.IsLoggedOut(body)
+}
+
+// Relogin once per session generation, the concurrent logged-out pages wait for the same login
+func (s *Session) Relogin(generation int32) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// logged in by another request since the page was sent
+	if s.logins.Load() != generation {
+		return nil
+	}
+
+	return s.login()
+}
+
+// Login by the form of the login page
+func (s *Session) Login() error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.login()
+}
+
+func (s *Session) login() error {
+
+	login := s.auth.Login
+
+	// next generation even if failed, so the failed login is not repeated by the same pages
+	defer s.logins.Add(1)
+
+	resp, err := s.client.Get(login.URL)
+	if err != nil {
+		return fmt.Errorf("auth login page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("auth login page status: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("auth login page: %w", err)
+	}
+
+	action, values, err := s.form(doc, resp.Request.URL)
+	if err != nil {
+		return err
+	}
+
+	submit, err := s.client.PostForm(action, values)
+	if err != nil {
+		return fmt.Errorf("auth login submit: %w", err)
+	}
+	defer submit.Body.Close()
+
+	body, err := io.ReadAll(submit.Body)
+	if err != nil {
+		return fmt.Errorf("auth login submit: %w", err)
+	}
+
+	if submit.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("auth login submit status: %d", submit.StatusCode)
+	}
+
+	if s.auth.IsLoggedOut(body) {
+		return errors.New("auth login rejected: logged out marker found")
+	}
+
+	slog.Info("auth: logged in", slog.String("url", login.URL))
+
+	return nil
+}
+
+// form of the username field with the credentials set, returns the action url and the form values
+func (s *Session) form(doc *goquery.Document, base *url.URL) (string, url.Values, error) {
+
+	login := s.auth.Login
+
+	username := doc.Find(login.UsernameSelector).First()
+	if username.Length() == 0 {
+		return "", nil, fmt.Errorf("auth login username field not found: %s", login.UsernameSelector)
+	}
+
+	password := doc.Find(login.PasswordSelector).First()
+	if password.Length() == 0 {
+		return "", nil, fmt.Errorf("auth login password field not found: %s", login.PasswordSelector)
+	}
+
+	form := username.Closest("form")
+	if form.Length() == 0 {
+		return "", nil, errors.New("auth login form not found")
+	}
+
+	// hidden fields, e.g. csrf token, are submitted as is
+	values := url.Values{}
+	form.Find("input[name]").Each(func(_ int, input *goquery.Selection) {
+
+		switch strings.ToLower(input.AttrOr("type", "text")) {
+		case "submit", "button", "image", "file", "reset":
+			return
+		case "checkbox", "radio":
+			if _, checked := input.Attr("checked"); !checked {
+				return
+			}
+		}
+
+		name, _ := input.Attr("name")
+		values.Add(name, input.AttrOr("value", ""))
+	})
+
+	user, pass := login.Credentials()
+	values.Set(username.AttrOr("name", "username"), user)
+	values.Set(password.AttrOr("name", "password"), pass)
+
+	action, err := base.Parse(form.AttrOr("action", ""))
+	if err != nil {
+		return "", nil, fmt.Errorf("auth login form action: %w", err)
+	}
+
+	return action.String(), values, nil
+}
+
+// Import the Netscape cookies.txt file to the jar
+func (s *Session) Import(filename string) error {
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("auth cookies file: %w", err)
+	}
+	defer f.Close()
+
+	cookies, err := ParseCookiesFile(f)
+	if err != nil {
+		return fmt.Errorf("auth cookies file: %w", err)
+	}
+
+	for _, c := range cookies {
+		s.jar.SetCookies(c.URL, []*http.Cookie{c.Cookie})
+	}
+
+	slog.Info("auth: cookies imported", slog.Int("cookies", len(cookies)))
+
+	return nil
+}
+
+// ParseCookiesFile of the Netscape format, e.g. exported by the browser extension or curl.
+// Each line is: domain, include subdomains, path, secure, expiration, name and value separated by tabs.
+// Expired cookies are skipped.
+func ParseCookiesFile(r io.Reader) ([]*FileCookie, error) {
+
+	cookies := make([]*FileCookie, 0)
+	now := time.Now()
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+
+		line := strings.TrimSpace(scanner.Text())
+
+		// the http-only cookies are commented by the prefix
+		httpOnly := strings.HasPrefix(line, "#HttpOnly_")
+		line = strings.TrimPrefix(line, "#HttpOnly_")
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 fields, got %d", n, len(fields))
+		}
+
+		expires, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: expiration is invalid: %w", n, err)
+		}
+
+		cookie := &http.Cookie{
+			Name:     fields[5],
+			Value:    fields[6],
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			HttpOnly: httpOnly,
+		}
+
+		// zero is the session cookie
+		if expires > 0 {
+			cookie.Expires = time.Unix(expires, 0)
+			if cookie.Expires.Before(now) {
+				continue
+			}
+		}
+
+		host := strings.TrimPrefix(fields[0], ".")
+
+		// host-only cookie has no domain attribute
+		if strings.EqualFold(fields[1], "TRUE") {
+			cookie.Domain = host
+		}
+
+		scheme := "http"
+		if cookie.Secure {
+			scheme = "https"
+		}
+
+		cookies = append(cookies, &FileCookie{
+			URL:    &url.URL{Scheme: scheme, Host: host, Path: cookie.Path},
+			Cookie: cookie,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cookies, nil
+}
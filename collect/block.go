@@ -0,0 +1,312 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/proxy"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// blockTypes maps the config resource types to the CDP ones
+var blockTypes = map[string]network.ResourceType{
+	"image":      network.ResourceTypeImage,
+	"media":      network.ResourceTypeMedia,
+	"font":       network.ResourceTypeFont,
+	"stylesheet": network.ResourceTypeStylesheet,
+	"script":     network.ResourceTypeScript,
+}
+
+// estimatedSizes are the rough typical transfer sizes of the resource types, not a measurement:
+// the blocked request has no response, so the saved bytes are estimated by them
+var estimatedSizes = map[network.ResourceType]int64{
+	network.ResourceTypeImage:      30 << 10,
+	network.ResourceTypeMedia:      500 << 10,
+	network.ResourceTypeFont:       30 << 10,
+	network.ResourceTypeStylesheet: 15 << 10,
+	network.ResourceTypeScript:     20 << 10,
+}
+
+// estimatedSizeOther of the resource types not in the table, e.g. the tracker beacons blocked by URLs
+const estimatedSizeOther int64 = 1 << 10
+
+// blocker fails the tab requests matching the config.BrowserBlock by the CDP Fetch domain
+// before they are sent and counts blocked requests and estimated saved bytes of the page.
+// The Fetch domain is one per tab, so the blocker answers the proxy auth challenge
+// and adds the auth headers of the host as well.
+type blocker struct {
+	patterns []*fetch.RequestPattern
+	urls     []*regexp.Regexp
+	types    map[network.ResourceType]bool
+	auth     *url.Userinfo
+	headers  func(host string) http.Header
+	requests atomic.Int64
+	// estimated saved bytes by the resource types
+	estimated atomic.Int64
+}
+
+// newBlocker of the config, the proxy credentials and the auth headers of the hosts,
+// nil if nothing is blocked, no credentials and no headers
+func newBlocker(block *config.BrowserBlock, auth *url.Userinfo, headers func(host string) http.Header) *blocker {
+
+	if (block == nil || !block.Enabled()) && auth == nil && headers == nil {
+		return nil
+	}
+
+	b := &blocker{
+		types:   make(map[network.ResourceType]bool),
+		auth:    auth,
+		headers: headers,
+	}
+
+	if block == nil {
+		block = &config.BrowserBlock{}
+	}
+
+	// auth challenges are issued for the paused requests only,
+	// headers are added to the paused requests as well
+	all := auth != nil || headers != nil
+	if all {
+		b.patterns = append(b.patterns, &fetch.RequestPattern{
+			URLPattern:   "*",
+			RequestStage: fetch.RequestStageRequest,
+		})
+	}
+
+	// urls are blocked before the request is sent
+	for _, glob := range block.URLs {
+		b.urls = append(b.urls, globRegexp(glob))
+		if !all {
+			b.patterns = append(b.patterns, &fetch.RequestPattern{
+				URLPattern:   glob,
+				RequestStage: fetch.RequestStageRequest,
+			})
+		}
+	}
+
+	// resource types are blocked before the request is sent as well, nothing goes through the proxy
+	for _, resourceType := range block.ResourceTypes {
+		b.types[blockTypes[resourceType]] = true
+		if !all {
+			b.patterns = append(b.patterns, &fetch.RequestPattern{
+				URLPattern:   "*",
+				ResourceType: blockTypes[resourceType],
+				RequestStage: fetch.RequestStageRequest,
+			})
+		}
+	}
+
+	return b
+}
+
+// proxyAuth credentials of the proxy, nil if not set
+func proxyAuth(p *proxy.Proxy) *url.Userinfo {
+
+	if p == nil || p.URL == nil {
+		return nil
+	}
+
+	return p.URL.User
+}
+
+// listen the paused requests of the tab, must be called once per tab before the Fetch domain enabled
+func (b *blocker) listen(ctx context.Context) {
+
+	if b == nil {
+		return
+	}
+
+	chromedp.ListenTarget(ctx, func(ev any) {
+
+		// listener must not block the event loop of the tab
+		switch e := ev.(type) {
+		case *fetch.EventRequestPaused:
+			go b.paused(ctx, e)
+		case *fetch.EventAuthRequired:
+			go b.authRequired(ctx, e)
+		}
+	})
+}
+
+// enable the Fetch domain with the block patterns
+func (b *blocker) enable() chromedp.Action {
+
+	if b == nil {
+		return chromedp.ActionFunc(func(context.Context) error { return nil })
+	}
+
+	return fetch.Enable().WithPatterns(b.patterns).WithHandleAuthRequests(b.auth != nil)
+}
+
+// paused request is failed if blocked
+func (b *blocker) paused(ctx context.Context, e *fetch.EventRequestPaused) {
+
+	ctx, ok := executor(ctx)
+	if !ok {
+		return
+	}
+
+	var err error
+	if b.blocked(e) {
+		err = b.fail(ctx, e)
+	} else {
+		err = b.continueRequest(ctx, e)
+	}
+
+	if err != nil && ctx.Err() == nil {
+		slog.Debug("browser block failed",
+			slog.String("url", e.Request.URL),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// continueRequest with the auth headers of the request host
+func (b *blocker) continueRequest(ctx context.Context, e *fetch.EventRequestPaused) error {
+
+	next := fetch.ContinueRequest(e.RequestID)
+
+	u, err := url.Parse(e.Request.URL)
+	if b.headers == nil || err != nil {
+		return next.Do(ctx)
+	}
+
+	header := b.headers(u.Hostname())
+	if len(header) == 0 {
+		return next.Do(ctx)
+	}
+
+	// the headers of the request are replaced
+	entries := make([]*fetch.HeaderEntry, 0, len(e.Request.Headers)+len(header))
+	for name, value := range e.Request.Headers {
+		if header.Get(name) != "" {
+			continue
+		}
+		entries = append(entries, &fetch.HeaderEntry{Name: name, Value: fmt.Sprint(value)})
+	}
+	for name := range header {
+		entries = append(entries, &fetch.HeaderEntry{Name: name, Value: header.Get(name)})
+	}
+
+	return next.WithHeaders(entries).Do(ctx)
+}
+
+// blocked returns true if the resource type or the url of the request is blocked,
+// the page document is never blocked
+func (b *blocker) blocked(e *fetch.EventRequestPaused) bool {
+
+	if e.ResourceType == network.ResourceTypeDocument {
+		return false
+	}
+
+	return b.types[e.ResourceType] || b.blockedURL(e.Request.URL)
+}
+
+// fail the blocked request, the saved bytes are estimated by the resource type
+func (b *blocker) fail(ctx context.Context, e *fetch.EventRequestPaused) error {
+
+	b.requests.Add(1)
+	b.estimated.Add(estimatedSize(e.ResourceType))
+
+	return fetch.FailRequest(e.RequestID, network.ErrorReasonBlockedByClient).Do(ctx)
+}
+
+// authRequired answers the proxy challenge with the proxy credentials, other challenges are default
+func (b *blocker) authRequired(ctx context.Context, e *fetch.EventAuthRequired) {
+
+	ctx, ok := executor(ctx)
+	if !ok {
+		return
+	}
+
+	answer := &fetch.AuthChallengeResponse{Response: fetch.AuthChallengeResponseResponseDefault}
+
+	if e.AuthChallenge.Source == fetch.AuthChallengeSourceProxy && b.auth != nil {
+		password, _ := b.auth.Password()
+		answer = &fetch.AuthChallengeResponse{
+			Response: fetch.AuthChallengeResponseResponseProvideCredentials,
+			Username: b.auth.Username(),
+			Password: password,
+		}
+	}
+
+	if err := fetch.ContinueWithAuth(e.RequestID, answer).Do(ctx); err != nil && ctx.Err() == nil {
+		slog.Debug("browser auth failed",
+			slog.String("url", e.Request.URL),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// blockedURL returns true if the url matches any blocked glob
+func (b *blocker) blockedURL(uri string) bool {
+
+	for _, re := range b.urls {
+		if re.MatchString(uri) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reset the counters before the navigation of the reused tab
+func (b *blocker) reset() {
+
+	if b == nil {
+		return
+	}
+
+	b.requests.Store(0)
+	b.estimated.Store(0)
+}
+
+// counters of the blocked requests and estimated saved bytes since the reset
+func (b *blocker) counters() (int, int64) {
+
+	if b == nil {
+		return 0, 0
+	}
+
+	return int(b.requests.Load()), b.estimated.Load()
+}
+
+// estimatedSize of the blocked request by the resource type
+func estimatedSize(kind network.ResourceType) int64 {
+
+	if size, ok := estimatedSizes[kind]; ok {
+		return size
+	}
+
+	return estimatedSizeOther
+}
+
+// executor of the tab target for the CDP commands
+func executor(ctx context.Context) (context.Context, bool) {
+
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Target == nil {
+		return ctx, false
+	}
+
+	return cdp.WithExecutor(ctx, c.Target), true
+}
+
+// globRegexp of the CDP url pattern: "*" is zero or more characters, "?" exactly one
+func globRegexp(glob string) *regexp.Regexp {
+
+	pattern := regexp.QuoteMeta(glob)
+	pattern = strings.ReplaceAll(pattern, `\*`, `.*`)
+	pattern = strings.ReplaceAll(pattern, `\?`, `.`)
+
+	return regexp.MustCompile("^" + pattern + "$")
+}
@@ -0,0 +1,164 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/events"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// browserActions converts the Config.BrowserActions to chromedp actions,
+// each one is limited by the own timeout
+func (crawler *Crawler) browserActions(net *networkMonitor) chromedp.Tasks {
+
+	tasks := make(chromedp.Tasks, 0, len(crawler.args.BrowserActions))
+
+	for i, action := range crawler.args.BrowserActions {
+		tasks = append(tasks, browserAction(i, action, net))
+	}
+
+	return tasks
+}
+
+// browserAction of the step, the failure is the events.BrowserActionError unless the action is optional
+func browserAction(step int, action *config.BrowserAction, net *networkMonitor) chromedp.Action {
+
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+
+		if action.Action != config.ActionSleep {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, action.Timeout.Duration())
+			defer cancel()
+		}
+
+		err := browserStep(action, net).Do(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if action.Optional {
+			slog.Debug("browser optional action skipped",
+				slog.String("action", action.String()),
+				slog.String("error", err.Error()),
+			)
+			return nil
+		}
+
+		return &events.BrowserActionError{
+			Step:     step,
+			Action:   action.String(),
+			Selector: action.Selector,
+			Err:      err,
+		}
+	})
+}
+
+func browserStep(action *config.BrowserAction, net *networkMonitor) chromedp.Action {
+
+	switch action.Action {
+	case config.ActionWait:
+		return chromedp.WaitVisible(action.Selector, chromedp.ByQuery)
+	case config.ActionNetworkIdle:
+		return chromedp.ActionFunc(net.Idle)
+	case config.ActionScroll:
+		tasks := make(chromedp.Tasks, 0, action.Times*2)
+		for i := 0; i < action.Times; i++ {
+			tasks = append(tasks,
+				chromedp.Evaluate(`window.scrollTo(0, document.body.scrollHeight)`, nil),
+				chromedp.Sleep(action.Duration.Duration()),
+			)
+		}
+		return tasks
+	case config.ActionClick:
+		return chromedp.Click(action.Selector, chromedp.ByQuery, chromedp.NodeVisible)
+	case config.ActionType:
+		return chromedp.SendKeys(action.Selector, action.Value, chromedp.ByQuery, chromedp.NodeVisible)
+	case config.ActionEval:
+		return chromedp.Evaluate(action.Value, nil, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+			return p.WithAwaitPromise(true)
+		})
+	case config.ActionSleep:
+		return chromedp.Sleep(action.Duration.Duration())
+	}
+
+	// validated by the config
+	return chromedp.ActionFunc(func(context.Context) error {
+		return fmt.Errorf("unknown browser action %s", action.Action)
+	})
+}
+
+// networkMonitor counts requests of the tab in flight
+type networkMonitor struct {
+	inflight map[network.RequestID]bool
+	active   time.Time
+	mute     *sync.Mutex
+}
+
+// listenNetwork of the tab, must be called once per tab before the navigation
+func listenNetwork(ctx context.Context) *networkMonitor {
+
+	m := &networkMonitor{
+		inflight: make(map[network.RequestID]bool),
+		active:   time.Now(),
+		mute:     &sync.Mutex{},
+	}
+
+	chromedp.ListenTarget(ctx, func(ev any) {
+
+		m.mute.Lock()
+		defer m.mute.Unlock()
+
+		switch e := ev.(type) {
+		case *network.EventRequestWillBeSent:
+			m.inflight[e.RequestID] = true
+		case *network.EventLoadingFinished:
+			delete(m.inflight, e.RequestID)
+		case *network.EventLoadingFailed:
+			delete(m.inflight, e.RequestID)
+		default:
+			return
+		}
+
+		m.active = time.Now()
+	})
+
+	return m
+}
+
+// reset the monitor before the navigation of the reused tab
+func (m *networkMonitor) reset() {
+	m.mute.Lock()
+	defer m.mute.Unlock()
+	clear(m.inflight)
+	m.active = time.Now()
+}
+
+// Idle waits for no requests in flight for the config.NetworkIdleTime
+func (m *networkMonitor) Idle(ctx context.Context) error {
+
+	ticker := time.NewTicker(config.NetworkIdleTime / 5)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if m.idle() {
+				return nil
+			}
+		}
+	}
+}
+
+func (m *networkMonitor) idle() bool {
+	m.mute.Lock()
+	defer m.mute.Unlock()
+	return len(m.inflight) == 0 && time.Since(m.active) >= config.NetworkIdleTime
+}
@@ -0,0 +1,194 @@
+package collect
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/editorpost/spider/collect/config"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// CacheMissHeader marks the response not found in the archive on replay
+const CacheMissHeader = "X-Spider-Cache"
+
+type (
+	// CacheTransport records the responses of the next transport to the archive
+	// or replays them from the archive without the network by the config.Cache mode
+	CacheTransport struct {
+		mode    string
+		archive config.CacheStorage
+		next    http.RoundTripper
+	}
+
+	// CacheEntry is the recorded response of the request
+	CacheEntry struct {
+		URL    string      `json:"URL"`
+		Method string      `json:"Method"`
+		Status int         `json:"Status"`
+		Header http.Header `json:"Header"`
+		Body   []byte      `json:"Body"`
+	}
+
+	// DirCache is the archive of the local directory
+	DirCache struct {
+		dir string
+	}
+)
+
+// NewCacheTransport of the cache mode, the next transport is not used on replay
+func NewCacheTransport(cache *config.Cache, archive config.CacheStorage, next http.RoundTripper) *CacheTransport {
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &CacheTransport{
+		mode:    cache.Mode,
+		archive: archive,
+		next:    next,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if t.mode == config.CacheReplay {
+		return t.replay(req)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || t.mode != config.CacheRecord {
+		return resp, err
+	}
+
+	return t.record(req, resp)
+}
+
+// record the response to the archive, the body is read and set back to the response
+func (t *CacheTransport) record(req *http.Request, resp *http.Response) (*http.Response, error) {
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+
+	data, err := json.Marshal(&CacheEntry{
+		URL:    req.URL.String(),
+		Method: req.Method,
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the crawl goes on, the page is missed on replay
+	if err = t.archive.Save(data, CacheKey(req)); err != nil {
+		slog.Warn("cache: record failed",
+			slog.String("url", req.URL.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return resp, nil
+}
+
+// replay the response from the archive, the request not recorded is not found
+func (t *CacheTransport) replay(req *http.Request) (*http.Response, error) {
+
+	entry := &CacheEntry{}
+
+	data, err := t.archive.Load(CacheKey(req))
+	if err == nil {
+		err = json.Unmarshal(data, entry)
+	}
+
+	// local storage returns empty object of the missing file
+	if err != nil || entry.Status == 0 {
+		slog.Warn("cache: replay miss", slog.String("url", req.URL.String()))
+		entry = &CacheEntry{
+			Status: http.StatusNotFound,
+			Header: http.Header{CacheMissHeader: []string{"miss"}},
+		}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Status, http.StatusText(entry.Status)),
+		StatusCode:    entry.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}, nil
+}
+
+// CacheKey is the archive filename of the request method and url
+func CacheKey(req *http.Request) string {
+	sum := sha1.Sum([]byte(req.Method + " " + req.URL.String()))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// NewDirCache of the local directory, created on the first record
+func NewDirCache(dir string) *DirCache {
+	return &DirCache{dir: dir}
+}
+
+// Save the data to the file of the directory
+func (c *DirCache) Save(data []byte, filename string) error {
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(c.dir, filename), data, 0644)
+}
+
+// Load the data of the file, os.ErrNotExist if not recorded
+func (c *DirCache) Load(filename string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(c.dir, filename))
+}
+
+// withCache sets the archive of the cache mode and wraps the sitemaps and robots.txt transport
+func (crawler *Crawler) withCache() error {
+
+	cache := crawler.args.Cache
+	if !cache.Enabled() {
+		return nil
+	}
+
+	if crawler.deps.Cache == nil {
+		if len(cache.Dir) == 0 {
+			return errors.New("cache archive is required: set Cache.Dir or the storage")
+		}
+		crawler.deps.Cache = NewDirCache(cache.Dir)
+	}
+
+	crawler.deps.RoundTripper = crawler.cached(crawler.deps.RoundTripper)
+
+	return nil
+}
+
+// cached transport of the cache mode, returns the transport as is if the cache is off
+func (crawler *Crawler) cached(next http.RoundTripper) http.RoundTripper {
+
+	if !crawler.args.Cache.Enabled() {
+		return next
+	}
+
+	return NewCacheTransport(&crawler.args.Cache, crawler.deps.Cache, next)
+}
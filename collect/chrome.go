@@ -2,68 +2,240 @@ package collect
 
 import (
 	"context"
-	"github.com/PuerkitoBio/goquery"
+	"errors"
+	"fmt"
 	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+	"io"
 	"log/slog"
+	"net/http"
 	"strings"
 )
 
-// Browse the Endpoint this chromedp.Navigate, wait dom loaded and return the rendered HTML
-func (crawler *Crawler) Browse(reqURL string) (*goquery.Selection, error) {
+// browserHeaders are the request headers not forwarded to the tab as is:
+// the user agent and the language are emulated, the cookies are set to the tab,
+// the transport headers are set by the browser
+var browserHeaders = map[string]bool{
+	"User-Agent":      true,
+	"Accept-Language": true,
+	"Cookie":          true,
+	"Accept-Encoding": true,
+	"Connection":      true,
+	"Host":            true,
+	"Content-Length":  true,
+}
 
-	resp, err := crawler.browseChrome(reqURL)
-	if err != nil {
-		slog.Error("browser failed",
-			slog.String("error", err.Error()),
-			slog.String("url", reqURL),
-		)
-		return nil, err
-	}
+// Browse the Endpoint this chromedp.Navigate, wait dom loaded, run the browser actions
+// and return the main document response and the rendered HTML.
+// The headers and the cookies of the request are sent by the navigation,
+// e.g. the conditional validators, the session cookies of the jar and the OnRequest headers.
+// The tab of the pool is recycled if the page failed, e.g. the request ctx is done by request timeout.
+func (crawler *Crawler) browseChrome(req *http.Request) (_ *network.Response, _ string, err error) {
 
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(resp))
+	reqCtx, reqURL := req.Context(), req.URL.String()
+
+	tab, err := crawler.tabs.Acquire(reqCtx)
 	if err != nil {
-		slog.Error("browser failed",
-			slog.String("error", err.Error()),
-			slog.String("url", reqURL),
-		)
-		return nil, err
+		return nil, "", err
 	}
+	defer func() {
+		crawler.tabs.Release(tab, err)
+	}()
 
-	return doc.Find(crawler.args.ExtractSelector), nil
-}
+	ctx, cancel := tab.Context(reqCtx)
+	defer cancel()
 
-// Browse the Endpoint this chromedp.Navigate, wait dom loaded and return the rendered HTML
-func (crawler *Crawler) browseChrome(reqURL string) (string, error) {
+	// requests in flight for the network-idle action and blocked ones of the page
+	tab.net.reset()
+	tab.block.reset()
 
-	// Initialize a new browser context
-	ctx, cancel := chromedp.NewContext(crawler.chromeCtx)
-	defer cancel()
+	// Navigate to the Endpoint
+	header := crawler.args.RequestHeader(tab.ProxyURL())
+
+	acceptLanguage := req.Header.Get("Accept-Language")
+	if len(acceptLanguage) == 0 {
+		acceptLanguage = header.Get("Accept-Language")
+	}
+	if len(acceptLanguage) == 0 {
+		acceptLanguage = "en-US,en;q=0.9"
+	}
 
-	chromedp.UserAgent(crawler.args.UserAgent)
+	// the config headers are sent by the requests of the page,
+	// the request headers by the navigation only
+	extra := network.Headers{}
+	for name := range crawler.args.Headers {
+		extra[name] = header.Get(name)
+	}
 
-	// Navigate to the Endpoint and fetch the rendered HTML
-	var htmlContent string
-	err := chromedp.Run(ctx,
+	navigation := network.Headers{}
+	for name, value := range extra {
+		navigation[name] = value
+	}
+	for name, values := range req.Header {
+		if !browserHeaders[http.CanonicalHeaderKey(name)] {
+			navigation[name] = strings.Join(values, ", ")
+		}
+	}
+
+	resp, err := chromedp.RunResponse(ctx,
 		&emulation.SetUserAgentOverrideParams{
-			// todo: why UserAgent set twice?
-			UserAgent:      crawler.args.UserAgent,
-			AcceptLanguage: "en-US,en;q=0.9",
+			UserAgent:      header.Get("User-Agent"),
+			AcceptLanguage: acceptLanguage,
 		},
+		network.SetExtraHTTPHeaders(navigation),
+		// the cookies of the jar: the session of the login and imported cookies
+		browserCookies(req),
 		chromedp.Navigate(reqURL),
+	)
+	if err = tab.Err(err); err != nil {
+		// cancelled by the crawler, not failed by the proxy
+		if !errors.Is(err, context.Canceled) {
+			_ = crawler.proxyFeedback(tab, false)
+		}
+		return nil, "", err
+	}
+
+	if err = crawler.proxyFeedback(tab, resp == nil || resp.Status != http.StatusProxyAuthRequired); err != nil {
+		return nil, "", err
+	}
+
+	// run the actions and fetch the rendered HTML
+	var htmlContent string
+	err = chromedp.Run(ctx,
+		// the requests of the actions don't send the validators of the page
+		network.SetExtraHTTPHeaders(extra),
 		chromedp.WaitReady(`body`),
+		crawler.browserActions(tab.net),
 		chromedp.OuterHTML("html", &htmlContent),
 	)
+	if err = tab.Err(err); err != nil {
+		return nil, "", err
+	}
+
+	// the page is captured as rendered for the extraction
+	crawler.snapshot(ctx, reqURL)
+
+	crawler.blocked(reqURL, tab)
+
+	return resp, htmlContent, nil
+}
+
+// blocked reports requests blocked and estimated bytes saved by the tab for the page
+func (crawler *Crawler) blocked(reqURL string, tab *Tab) {
+
+	if !crawler.args.BrowserBlock.Enabled() {
+		return
+	}
+
+	requests, estimated := tab.block.counters()
+
+	crawler.deps.Monitor.OnBrowserBlocked(reqURL, requests, estimated)
+	slog.Debug("browser blocked",
+		slog.String("url", reqURL),
+		slog.Int("requests", requests),
+		slog.Int64("estimated_bytes", estimated),
+	)
+}
+
+// proxyFeedback of the navigation to the proxy pool as the transport does on the CONNECT response.
+// Returns the error if the proxy failed, so the tab is recycled with the next proxy.
+func (crawler *Crawler) proxyFeedback(tab *Tab, success bool) error {
+
+	if err := crawler.tabs.Feedback(tab, success); err != nil {
+		return fmt.Errorf("proxy %s: %w", tab.proxy, err)
+	}
+
+	return nil
+}
+
+// ChromeTransport fetches pages by the browser, the rendered HTML is the response body.
+// So links are discovered and data is extracted from the same navigation.
+type ChromeTransport struct {
+	crawler *Crawler
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ChromeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	if req.Method != http.MethodGet {
+		return nil, fmt.Errorf("browser method is not supported: %s", req.Method)
+	}
+
+	resp, htmlContent, err := t.crawler.browseChrome(req)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("browser: %w", err)
 	}
 
-	return htmlContent, nil
+	// same-document navigation has no response, the page is rendered anyway
+	status, statusText := http.StatusOK, http.StatusText(http.StatusOK)
+	header := http.Header{}
+
+	if resp != nil {
+		status, statusText = int(resp.Status), resp.StatusText
+		header = ResponseHeader(resp.Headers)
+	}
+
+	// the rendered document is decoded by the browser
+	header.Set("Content-Type", "text/html; charset=utf-8")
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, statusText),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(htmlContent)),
+		ContentLength: int64(len(htmlContent)),
+		Request:       req,
+	}, nil
+}
+
+// browserCookies of the request set to the tab before the navigation
+func browserCookies(req *http.Request) chromedp.Action {
+
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+
+		for _, cookie := range req.Cookies() {
+			if err := network.SetCookie(cookie.Name, cookie.Value).WithURL(req.URL.String()).Do(ctx); err != nil {
+				return fmt.Errorf("browser cookie %s: %w", cookie.Name, err)
+			}
+		}
+
+		return nil
+	})
 }
 
-func (crawler *Crawler) setupChrome() context.CancelFunc {
+// ResponseHeader of the browser response, the values of the repeated header are joined by line breaks
+func ResponseHeader(headers network.Headers) http.Header {
 
-	slog.Info("chrome collector")
+	header := http.Header{}
+
+	for name, value := range headers {
+		switch v := value.(type) {
+		case string:
+			for _, line := range strings.Split(v, "\n") {
+				header.Add(name, line)
+			}
+		case []any:
+			for _, item := range v {
+				header.Add(name, fmt.Sprint(item))
+			}
+		default:
+			header.Add(name, fmt.Sprint(v))
+		}
+	}
+
+	return header
+}
+
+// setupChrome of the run, the browser is closed once the ctx is done
+func (crawler *Crawler) setupChrome(ctx context.Context) (context.CancelFunc, error) {
+
+	slog.Info("chrome collector", slog.Int("tabs", crawler.args.Tabs()), slog.Bool("proxy", crawler.proxies != nil))
 
 	opts := []chromedp.ExecAllocatorOption{
 		chromedp.NoFirstRun,
@@ -73,14 +245,30 @@ func (crawler *Crawler) setupChrome() context.CancelFunc {
 	}
 
 	// create context
-	ctx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
-	crawler.chromeCtx = ctx
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, opts...)
 
-	// create context
-	_, cancel := chromedp.NewContext(crawler.chromeCtx) // create new tab
+	// create browser, the tabs are opened in it
+	browserCtx, cancel := chromedp.NewContext(allocCtx)
+	crawler.chromeCtx = browserCtx
 
-	return func() {
-		cancelAlloc()
+	// start the browser, so the tabs share it and might be opened in the own browser contexts
+	if err := chromedp.Run(browserCtx); err != nil {
 		cancel()
+		cancelAlloc()
+		return nil, fmt.Errorf("browser start: %w", err)
+	}
+
+	// reusable tabs for the concurrent requests
+	crawler.tabs = NewTabPool(crawler.chromeCtx, crawler.args.Tabs(), &crawler.args.BrowserBlock, crawler.proxies, crawler.deps.Monitor)
+
+	// auth headers are added to the requests of the hosts
+	if crawler.session != nil && len(crawler.args.Auth.Hosts) > 0 {
+		crawler.tabs.WithHeaders(crawler.session.Header)
 	}
+
+	return func() {
+		crawler.tabs.Close()
+		cancel()
+		cancelAlloc()
+	}, nil
 }
@@ -3,8 +3,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"github.com/editorpost/spider/collect/frontier"
 	"log/slog"
 	"net/url"
+	"slices"
 	"strings"
 )
 
@@ -14,23 +16,62 @@ const (
 
 type Config struct {
 
-	// StartURL is the url to start the scraping
+	// StartURL is the url to start the scraping,
+	// kept for backward compatibility, the first of StartURLs after Normalize.
 	StartURL string `json:"StartURL"`
 
+	// StartURLs is the list of urls to start the scraping, might be on different domains
+	StartURLs []string `json:"StartURLs"`
+
+	// AllowedDomains is the crawl scope, e.g. "example.com" or "*.example.com" for any subdomain.
+	// Hosts of StartURLs are always allowed.
+	// def: hosts of StartURLs
+	AllowedDomains []string `json:"AllowedDomains"`
+
 	// AllowedURLs is comma separated regex to match the urls
 	// use it to reduce the number of urls to visit
 	AllowedURLs []string `json:"AllowedURLs"`
 
+	// DisallowedURLs is the list of url patterns to skip, e.g. "{any}/tag/{any}",
+	// same placeholder syntax as AllowedURLs, checked before AllowedURLs.
+	DisallowedURLs []string `json:"DisallowedURLs"`
+
 	// ExtractURLs is the regex to match the entity urls
 	// use it to extract the entity urls
 	ExtractURLs []string `json:"ExtractURLs"`
 
+	// Canonical is the url canonicalization rules, applied to links before queueing
+	// and to the page url before extraction (<link rel="canonical"> preferred).
+	// def: all rules enabled
+	Canonical Canonical `json:"Canonical"`
+
+	// Traps detects the crawler traps of the discovered urls, e.g. infinite calendars,
+	// and quarantines the offending patterns with the suggested DisallowedURLs rule.
+	// def: enabled
+	Traps Traps `json:"Traps"`
+
+	// Sitemaps is the list of sitemap.xml or sitemap index urls (gzip supported).
+	// Sitemap urls matching AllowedURLs and, if set, ExtractURLs (and not DisallowedURLs) are queued before StartURL.
+	Sitemaps []string `json:"Sitemaps"`
+
+	// SitemapDiscover is the flag to find sitemaps in robots.txt `Sitemap:` lines
+	// of the StartURL host, discovered sitemaps are added to Sitemaps.
+	// def: false
+	SitemapDiscover bool `json:"SitemapDiscover"`
+
 	// VisitOnce is the flag to visit the url only once
 	// If true, then visited urls stored in S3 between runs
 	// Else default colly memory collector used to store the visited urls by default
 	// def: false
 	VisitOnce bool `json:"VisitOnce"`
 
+	// Revalidate is the flag to re-crawl pages of previous runs conditionally (requires storage):
+	// If-None-Match/If-Modified-Since are sent for ExtractURLs pages,
+	// not modified and unchanged pages (by body hash) are not extracted again,
+	// pages visited before are requested again even if VisitOnce is set.
+	// def: false
+	Revalidate bool `json:"Revalidate"`
+
 	// ExtractSelector is the css selector to match the elements
 	// use selector for extracting entities and filtering pages
 	// def: html
@@ -40,9 +81,57 @@ type Config struct {
 	// Crawler gracefully stops after reaching the limit
 	ExtractLimit int `json:"ExtractLimit"`
 
+	// MaxRequests is the number of requests sent by the run, retries included.
+	// Crawler gracefully stops after reaching it.
+	// def: unlimited
+	MaxRequests int `json:"MaxRequests"`
+
+	// MaxDuration of the run, crawler gracefully stops after it.
+	// def: unlimited
+	MaxDuration Duration `json:"MaxDuration"`
+
+	// MaxPagesPerHost is the number of pages requested per host, the next pages of the host are skipped.
+	// def: unlimited
+	MaxPagesPerHost int `json:"MaxPagesPerHost"`
+
+	// MaxBytes is the size of the response bodies downloaded by the run.
+	// Crawler gracefully stops after reaching it.
+	// def: unlimited
+	MaxBytes int64 `json:"MaxBytes"`
+
+	// RespectRobots is the flag to obey robots.txt Disallow/Allow rules and Crawl-delay
+	// for the UserAgent, skip extraction of noindex pages and links of nofollow pages.
+	// def: false
+	RespectRobots bool `json:"RespectRobots"`
+
+	// Scheduling is the crawl queue ordering policy: "bfs", "dfs" or "entity-first".
+	// The entity-first fetches ExtractURLs pages, then pagination, then other pages.
+	// def: entity-first
+	Scheduling frontier.Policy `json:"Scheduling"`
+
+	// Pagination is the listing pagination followed by the next page selector or url template,
+	// pagination pages are visited regardless of AllowedURLs and keep the listing depth.
+	Pagination Pagination `json:"Pagination"`
+
+	// Limits is the list of per-host politeness rules: parallelism, delay and rate.
+	// The first rule matching the host is applied, hosts without rule are not limited.
+	// The sum of rules parallelism is used as the number of queue threads.
+	Limits []*LimitRule `json:"Limits"`
+
 	// UseBrowser is a flag to use browser for rendering the page
 	UseBrowser bool `json:"UseBrowser"`
 
+	// BrowserActions are the steps run in the browser after the page loaded, e.g. scroll or click "Show more".
+	// Failed step fails the page request unless the step is optional.
+	BrowserActions []*BrowserAction `json:"BrowserActions"`
+
+	// BrowserBlock is the list of resource types and url globs blocked during the page rendering
+	BrowserBlock BrowserBlock `json:"BrowserBlock"`
+
+	// BrowserTabs is the number of reusable browser tabs, pages are browsed concurrently up to the queue threads.
+	// def: number of queue threads
+	BrowserTabs int `json:"BrowserTabs"`
+
 	// Depth if is 1, so only the links on the scraped page
 	// is visited, and no further links are followed
 	Depth int `json:"Depth"`
@@ -50,12 +139,43 @@ type Config struct {
 	// UserAgent is the user agent string used by the collector
 	UserAgent string `json:"UserAgent"`
 
+	// UserAgents is the list of user agents rotated by the UserAgentRotation
+	UserAgents []string `json:"UserAgents"`
+
+	// UserAgentRotation is the strategy of the user agent: fixed, random or sticky-proxy.
+	// def: fixed UserAgent
+	UserAgentRotation string `json:"UserAgentRotation"`
+
+	// Retry is the policy of the failed requests: retried statuses and errors, backoff and budget per host
+	Retry RetryPolicy `json:"Retry"`
+
+	// Headers are sent with every request of the collector, the browser, the proxy check and the media download,
+	// e.g. Accept-Language or Referer
+	Headers map[string]string `json:"Headers"`
+
 	// ProxyEnabled is the flag to enable proxy or send requests directly
 	ProxyEnabled bool `json:"ProxyEnabled"`
 
 	// ProxySources is the list of proxy sources, expected to return list of proxies URLs.
 	// If empty, the default proxy sources is used.
 	ProxySources []string `json:"ProxySources"`
+
+	// Auth is the session of subscriber-only sources: form login, cookies.txt import
+	// and static headers per host. Credentials are taken from the Deploy secrets.
+	Auth *Auth `json:"Auth"`
+
+	// Cache records the responses to the archive or replays the crawl from it without the network.
+	// def: off
+	Cache Cache `json:"Cache"`
+
+	// WARC writes the raw http exchange of every fetched response to the WARC files (requires storage),
+	// the page rendered by the browser as the resource record.
+	// The payload references the file and the offset of the page record.
+	WARC WARC `json:"WARC"`
+
+	// Graph records the links found on the pages, exported to the collect storage
+	// and summarized by the url patterns in the run result.
+	Graph Graph `json:"Graph"`
 }
 
 // The Config JSON representation:
@@ -63,15 +183,38 @@ type Config struct {
 // 	"ID": "ready-check",
 //  "Name": "Ready Check",
 // 	"StartURL": "https://example.com",
+// 	"StartURLs": ["https://news.example.com", "https://cdn-articles.example.net"],
+// 	"AllowedDomains": ["*.example.com", "cdn-articles.example.net"],
 // 	"AllowedURL": "https://example.com/{any}",
+// 	"DisallowedURLs": ["{any}/tag/{any}", "{any}/search\\?{any}"],
 // 	"ExtractURL": "https://example.com/articles/{any}",
+// 	"Traps": {"MaxTemplateURLs": 1000, "MaxSegmentRepeats": 3, "MaxQueryCombinations": 100},
+// 	"Sitemaps": ["https://example.com/sitemap.xml"],
+// 	"SitemapDiscover": true,
+// 	"Scheduling": "entity-first",
+// 	"Pagination": {"Selector": "a.next", "MaxPages": 50},
+// 	"Revalidate": true,
 // 	"ExtractSelector": "article",
 // 	"ExtractLimit": 1,
+// 	"MaxRequests": 10000,
+// 	"MaxDuration": "2h",
+// 	"MaxPagesPerHost": 1000,
+// 	"MaxBytes": 1073741824,
 // 	"UseBrowser": true,
+// 	"BrowserActions": [{"Action": "click", "Selector": ".show-more"}, {"Action": "network-idle"}],
+// 	"BrowserBlock": {"ResourceTypes": ["image", "media", "font"], "URLs": ["*google-analytics.com*"]},
 // 	"Depth": 1,
 // 	"UserAgent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/58.0.3029.110 Safari/537.3",
+// 	"UserAgents": [],
+// 	"UserAgentRotation": "fixed",
+// 	"Headers": {"Accept-Language": "en-US,en;q=0.9"},
+// 	"Retry": {"Statuses": [429, 503], "Attempts": 3, "BaseDelay": "1s", "MaxDelay": "1m", "HostBudget": 100},
 // 	"ProxyEnabled": true,
-// 	"ProxySources": []
+// 	"ProxySources": [],
+// 	"Auth": {"Login": {"URL": "https://example.com/login", "UsernameSelector": "#email", "PasswordSelector": "#password", "UsernameSecret": "USER", "PasswordSecret": "PASS"}, "LoggedOut": "Sign in"},
+// 	"Cache": {"Mode": "record", "Dir": "./archive"},
+// 	"WARC": {"Enabled": true, "MaxSize": 100, "FlushInterval": "1m"},
+// 	"Graph": {"Enabled": true, "Formats": ["jsonl", "graphml", "dot"], "MaxEdges": 1000000}
 // }
 
 func (args *Config) Normalize() error {
@@ -84,6 +227,54 @@ func (args *Config) Normalize() error {
 		return err
 	}
 
+	if err := args.NormalizeHeaders(); err != nil {
+		return err
+	}
+
+	if err := args.NormalizeBudget(); err != nil {
+		return err
+	}
+
+	if err := args.Traps.Normalize(); err != nil {
+		return err
+	}
+
+	if err := args.NormalizeLimits(); err != nil {
+		return err
+	}
+
+	if err := args.NormalizeScheduling(); err != nil {
+		return err
+	}
+
+	if err := args.NormalizeBrowserActions(); err != nil {
+		return err
+	}
+
+	if err := args.BrowserBlock.Normalize(); err != nil {
+		return err
+	}
+
+	if err := args.Retry.Normalize(); err != nil {
+		return err
+	}
+
+	if args.Auth != nil {
+		if err := args.Auth.Normalize(); err != nil {
+			return err
+		}
+	}
+
+	if err := args.Cache.Normalize(); err != nil {
+		return err
+	}
+
+	args.WARC.Normalize()
+
+	if err := args.Graph.Normalize(); err != nil {
+		return err
+	}
+
 	args.NormalizeExtractSelector()
 
 	return nil
@@ -91,13 +282,36 @@ func (args *Config) Normalize() error {
 
 func (args *Config) Log() slog.Attr {
 	return slog.Group("args",
-		slog.String("start_url", args.StartURL),
+		slog.String("start_urls", strings.Join(args.StartURLs, ",")),
+		slog.String("allowed_domains", strings.Join(args.AllowedDomains, ",")),
 		slog.String("allowed_urls", strings.Join(args.AllowedURLs, ",")),
+		slog.String("disallowed_urls", strings.Join(args.DisallowedURLs, ",")),
 		slog.String("extract_urls", strings.Join(args.ExtractURLs, ",")),
+		slog.Bool("canonical", !args.Canonical.Disabled),
+		slog.Bool("traps", !args.Traps.Disabled),
+		slog.String("sitemaps", strings.Join(args.Sitemaps, ",")),
+		slog.Bool("sitemap_discover", args.SitemapDiscover),
+		slog.Bool("revalidate", args.Revalidate),
 		slog.String("entity_selector", args.ExtractSelector),
+		slog.Int("max_requests", args.MaxRequests),
+		slog.String("max_duration", args.MaxDuration.String()),
+		slog.Int("max_pages_per_host", args.MaxPagesPerHost),
+		slog.Int64("max_bytes", args.MaxBytes),
+		slog.Bool("respect_robots", args.RespectRobots),
+		slog.String("scheduling", string(args.Scheduling)),
+		slog.Bool("pagination", args.Pagination.Enabled()),
+		slog.Int("threads", args.Threads()),
+		slog.String("limits", args.LogLimits()),
 		slog.Bool("use_browser", args.UseBrowser),
+		slog.Int("browser_actions", len(args.BrowserActions)),
+		slog.Int("browser_tabs", args.Tabs()),
+		slog.Bool("auth", args.Auth != nil),
+		slog.String("cache", args.Cache.Mode),
+		slog.Bool("warc", args.WARC.Enabled),
+		slog.Bool("graph", args.Graph.Enabled),
 		slog.Int("depth", args.Depth),
 		slog.String("user_agent", args.UserAgent),
+		slog.String("user_agent_rotation", args.UserAgentRotation),
 	)
 }
 
@@ -110,28 +324,173 @@ func (args *Config) NormalizeExtractSelector() {
 
 func (args *Config) NormalizeURLs() error {
 
+	if err := args.NormalizeStartURLs(); err != nil {
+		return err
+	}
+
+	// explicit domains are allowed beside hosts of the start urls
+	explicit, err := args.NormalizeAllowedDomains()
+	if err != nil {
+		return err
+	}
+
+	// by default all urls of the allowed domains are allowed including main pages
+	if len(args.AllowedURLs) == 0 {
+		args.AllowedURLs = args.DefaultAllowedURLs(explicit)
+	}
+
+	// disallowed patterns should compile
+	for i, pattern := range args.DisallowedURLs {
+
+		args.DisallowedURLs[i] = strings.TrimSpace(pattern)
+
+		if _, err = NewPatterns(args.DisallowedURLs[i]); err != nil || len(args.DisallowedURLs[i]) == 0 {
+			return fmt.Errorf("disallowed url pattern is invalid: %s", pattern)
+		}
+	}
+
+	if err := args.Pagination.Normalize(); err != nil {
+		return err
+	}
+
+	// sitemaps should be valid absolute urls
+	for i, sitemap := range args.Sitemaps {
+
+		args.Sitemaps[i] = strings.TrimSpace(sitemap)
+
+		uri, err := url.ParseRequestURI(args.Sitemaps[i])
+		if err != nil || len(uri.Host) == 0 {
+			return fmt.Errorf("sitemap url is invalid: %s", sitemap)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeStartURLs merges StartURL with StartURLs and validates them
+func (args *Config) NormalizeStartURLs() error {
+
+	urls := make([]string, 0, len(args.StartURLs)+1)
+	seen := make(map[string]bool)
+
+	for _, startURL := range append([]string{args.StartURL}, args.StartURLs...) {
+
+		startURL = strings.TrimSpace(startURL)
+		if len(startURL) == 0 || seen[startURL] {
+			continue
+		}
+
+		// start url should be valid
+		startURI, err := url.ParseRequestURI(startURL)
+		if err != nil {
+			return fmt.Errorf("start url is invalid: %w", err)
+		}
+
+		// if host is empty, then it is invalid
+		if len(startURI.Host) == 0 {
+			return errors.New("start url host is invalid, add domain name")
+		}
+
+		seen[startURL] = true
+		urls = append(urls, startURL)
+	}
+
 	// start url is required
-	args.StartURL = strings.TrimSpace(args.StartURL)
-	if len(args.StartURL) == 0 {
+	if len(urls) == 0 {
 		return errors.New("start url is required")
 	}
 
-	// start url should be valid
-	startURI, err := url.ParseRequestURI(args.StartURL)
-	if err != nil {
-		return fmt.Errorf("start url is invalid: %w", err)
+	args.StartURL = urls[0]
+	args.StartURLs = urls
+
+	return nil
+}
+
+// NormalizeAllowedDomains validates domains and adds hosts of the start urls.
+// Returns the domains defined explicitly.
+func (args *Config) NormalizeAllowedDomains() (Domains, error) {
+
+	explicit := make(Domains, 0, len(args.AllowedDomains))
+
+	for _, domain := range args.AllowedDomains {
+
+		domain, err := NormalizeDomain(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(explicit, domain) {
+			explicit = append(explicit, domain)
+		}
 	}
 
-	// if host is empty, then it is invalid
-	if len(startURI.Host) == 0 {
-		return errors.New("start url host is invalid, add domain name")
+	domains := slices.Clone(explicit)
+	for _, startURL := range args.StartURLs {
+		if hostname := MustHostname(startURL); !domains.Match(hostname) {
+			domains = append(domains, strings.ToLower(hostname))
+		}
 	}
 
-	// by default all urls are allowed including main page
-	if len(args.AllowedURLs) == 0 {
+	args.AllowedDomains = domains
+
+	return explicit, nil
+}
+
+// DefaultAllowedURLs is the pattern per root of the start urls and per explicit domain
+func (args *Config) DefaultAllowedURLs(explicit Domains) []string {
+
+	allowed := make([]string, 0, len(args.StartURLs)+len(explicit))
+
+	for _, startURL := range args.StartURLs {
+
+		startURI, _ := url.Parse(startURL)
+
 		// no slash separator between root url and any
 		// to include main page w/o trailing slash.
-		args.AllowedURLs = []string{RootUrl(startURI) + "{any}"}
+		if pattern := RootUrl(startURI) + "{any}"; !slices.Contains(allowed, pattern) {
+			allowed = append(allowed, pattern)
+		}
+	}
+
+	for _, domain := range explicit {
+		allowed = append(allowed, DomainPattern(domain))
+	}
+
+	return allowed
+}
+
+// Domains is the crawl scope
+func (args *Config) Domains() Domains {
+	return args.AllowedDomains
+}
+
+// NormalizeScheduling sets the default policy and validates it
+func (args *Config) NormalizeScheduling() error {
+
+	args.Scheduling = frontier.Policy(strings.ToLower(strings.TrimSpace(string(args.Scheduling))))
+	if len(args.Scheduling) == 0 {
+		args.Scheduling = frontier.DefaultPolicy
+	}
+
+	if !args.Scheduling.Valid() {
+		return fmt.Errorf("scheduling policy is invalid: %s", args.Scheduling)
+	}
+
+	return nil
+}
+
+// NormalizeBrowserActions sets defaults and validates the browser actions
+func (args *Config) NormalizeBrowserActions() error {
+
+	for i, action := range args.BrowserActions {
+
+		if action == nil {
+			return fmt.Errorf("browser action %d is empty", i)
+		}
+
+		if err := action.Normalize(); err != nil {
+			return err
+		}
 	}
 
 	return nil
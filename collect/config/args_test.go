@@ -63,6 +63,40 @@ func TestNormalizeURLs(t *testing.T) {
 	}
 }
 
+func TestNormalizeStartURLs(t *testing.T) {
+
+	args := &config.Config{
+		StartURL:       "https://example.com",
+		StartURLs:      []string{"https://news.example.com/latest", " https://example.com", "https://cdn-articles.example.net/"},
+		AllowedDomains: []string{"*.Example.com."},
+	}
+
+	require.NoError(t, args.NormalizeURLs())
+
+	// start url stays the first
+	assert.Equal(t, "https://example.com", args.StartURL)
+	assert.Equal(t, []string{"https://example.com", "https://news.example.com/latest", "https://cdn-articles.example.net/"}, args.StartURLs)
+
+	// hosts of the start urls are allowed, but not duplicated by wildcard
+	assert.Equal(t, []string{"*.example.com", "cdn-articles.example.net"}, args.AllowedDomains)
+
+	// allowed urls per root and explicit domain
+	allowed, err := config.NewPatterns(args.AllowedURLs...)
+	require.NoError(t, err)
+
+	assert.True(t, allowed.Match("https://example.com"))
+	assert.True(t, allowed.Match("https://news.example.com/news/1.html"))
+	assert.True(t, allowed.Match("http://blog.example.com"))
+	assert.True(t, allowed.Match("https://cdn-articles.example.net/a.html"))
+	assert.False(t, allowed.Match("https://example.net/a.html"))
+	assert.False(t, allowed.Match("https://badexample.com/a.html"))
+
+	// normalized config is not changed by next normalize
+	before := *args
+	require.NoError(t, args.NormalizeURLs())
+	assert.Equal(t, before, *args)
+}
+
 func TestNormalizeUserAgent(t *testing.T) {
 
 	tests := []struct {
@@ -158,6 +192,54 @@ func (suite *ConfigTestSuite) TestNormalize() {
 			},
 			expected: errors.New("start url host is invalid, add domain name"),
 		},
+		{
+			args: config.Config{
+				StartURL: "https://example.com",
+				Sitemaps: []string{" https://example.com/sitemap.xml "},
+			},
+			expected: nil,
+		},
+		{
+			args: config.Config{
+				StartURL: "https://example.com",
+				Sitemaps: []string{"sitemap.xml"},
+			},
+			expected: errors.New("sitemap url is invalid: sitemap.xml"),
+		},
+		{
+			args: config.Config{
+				StartURLs:      []string{"https://example.com"},
+				AllowedDomains: []string{"https://example.com"},
+			},
+			expected: errors.New("allowed domain is invalid: https://example.com"),
+		},
+		{
+			args: config.Config{
+				StartURL:       "https://example.com",
+				DisallowedURLs: []string{"{any}/tag/{any}", "(print"},
+			},
+			expected: errors.New("disallowed url pattern is invalid: (print"),
+		},
+		{
+			args: config.Config{
+				StartURL:   "https://example.com",
+				Scheduling: "random",
+			},
+			expected: errors.New("scheduling policy is invalid: random"),
+		},
+		{
+			args: config.Config{
+				StartURL:   "https://example.com",
+				Pagination: config.Pagination{URLTemplate: "{base}?page=2"},
+			},
+			expected: errors.New("pagination url template is invalid, {n} placeholder required: {base}?page=2"),
+		},
+		{
+			args: config.Config{
+				StartURLs: []string{"https://example.com", "example.net"},
+			},
+			expected: errors.New("start url is invalid: parse \"example.net\": invalid URI for request"),
+		},
 	}
 
 	for _, tt := range tests {
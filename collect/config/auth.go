@@ -0,0 +1,194 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// LoggedOutCtx is the request context key of the page matched by Auth.LoggedOut,
+// the page is not extracted and its links are not followed
+const LoggedOutCtx = "LoggedOut"
+
+type (
+	// Auth is the session of the subscriber-only sources: form login, imported cookies
+	// and static headers per host. Session cookies are kept in the collect storage between runs.
+	//
+	// JSON representation:
+	//
+	//	{
+	//		"Login": {
+	//			"URL": "https://example.com/login",
+	//			"UsernameSelector": "input[name=email]",
+	//			"PasswordSelector": "input[type=password]",
+	//			"UsernameSecret": "EXAMPLE_USER",
+	//			"PasswordSecret": "EXAMPLE_PASSWORD"
+	//		},
+	//		"CookiesFile": "/secrets/cookies.txt",
+	//		"Hosts": [{"Host": "api.example.com", "TokenSecret": "EXAMPLE_TOKEN"}],
+	//		"LoggedOut": "Sign in to continue"
+	//	}
+	Auth struct {
+		// Login is the form login step, run before the crawl and on the logged-out response
+		Login *AuthLogin `json:"Login"`
+		// CookiesFile is the Netscape cookies.txt file imported before the crawl, e.g. exported from the browser
+		CookiesFile string `json:"CookiesFile"`
+		// Hosts are the static headers and bearer tokens per host
+		Hosts []*AuthHost `json:"Hosts"`
+		// LoggedOut is the regex marker of the logged-out page body, e.g. "Sign in to continue".
+		// The matched page is not extracted, the login is run again and the page is requested once more.
+		LoggedOut string `json:"LoggedOut"`
+		loggedOut *regexp.Regexp
+	}
+
+	// AuthLogin is the html form login, the form of the username field is submitted with all its inputs
+	AuthLogin struct {
+		// URL of the login form page
+		URL string `json:"URL"`
+		// UsernameSelector is the css selector of the username input
+		UsernameSelector string `json:"UsernameSelector"`
+		// PasswordSelector is the css selector of the password input
+		PasswordSelector string `json:"PasswordSelector"`
+		// UsernameSecret is the name of the Deploy secret with the username
+		UsernameSecret string `json:"UsernameSecret"`
+		// PasswordSecret is the name of the Deploy secret with the password
+		PasswordSecret string `json:"PasswordSecret"`
+		username       string
+		password       string
+	}
+
+	// AuthHost is the static headers and the bearer token sent to the host
+	AuthHost struct {
+		// Host is the request hostname, e.g. api.example.com
+		Host string `json:"Host"`
+		// Headers are sent as is
+		Headers map[string]string `json:"Headers"`
+		// TokenSecret is the name of the Deploy secret sent as "Authorization: Bearer <token>"
+		TokenSecret string `json:"TokenSecret"`
+		token       string
+	}
+)
+
+// Normalize validates the login form, hosts and the logged-out marker
+func (a *Auth) Normalize() error {
+
+	if a.Login != nil {
+		if err := a.Login.Normalize(); err != nil {
+			return err
+		}
+	}
+
+	for _, host := range a.Hosts {
+		host.Host = strings.ToLower(strings.TrimSpace(host.Host))
+		if len(host.Host) == 0 {
+			return errors.New("auth host is empty")
+		}
+	}
+
+	a.LoggedOut = strings.TrimSpace(a.LoggedOut)
+	if len(a.LoggedOut) == 0 {
+		return nil
+	}
+
+	re, err := regexp.Compile(a.LoggedOut)
+	if err != nil {
+		return fmt.Errorf("auth logged out marker is invalid: %w", err)
+	}
+	a.loggedOut = re
+
+	return nil
+}
+
+// Secrets resolves the credentials of the login and the hosts by the secret names
+func (a *Auth) Secrets(secrets map[string]string) error {
+
+	lookup := func(name string) (string, error) {
+		value, ok := secrets[name]
+		if !ok {
+			return "", fmt.Errorf("auth secret not found: %s", name)
+		}
+		return value, nil
+	}
+
+	var err error
+
+	if a.Login != nil {
+		if a.Login.username, err = lookup(a.Login.UsernameSecret); err != nil {
+			return err
+		}
+		if a.Login.password, err = lookup(a.Login.PasswordSecret); err != nil {
+			return err
+		}
+	}
+
+	for _, host := range a.Hosts {
+		if len(host.TokenSecret) == 0 {
+			continue
+		}
+		if host.token, err = lookup(host.TokenSecret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsLoggedOut returns true if the page body matches the LoggedOut marker
+func (a *Auth) IsLoggedOut(body []byte) bool {
+	return a.loggedOut != nil && a.loggedOut.Match(body)
+}
+
+// Header of the host requests, nil if the host has no static headers or token
+func (a *Auth) Header(host string) http.Header {
+
+	host = strings.ToLower(host)
+
+	for _, h := range a.Hosts {
+
+		if h.Host != host {
+			continue
+		}
+
+		header := http.Header{}
+		for name, value := range h.Headers {
+			header.Set(name, value)
+		}
+
+		if len(h.token) > 0 {
+			header.Set("Authorization", "Bearer "+h.token)
+		}
+
+		return header
+	}
+
+	return nil
+}
+
+// Normalize validates the login url and selectors
+func (l *AuthLogin) Normalize() error {
+
+	l.URL = strings.TrimSpace(l.URL)
+	if u, err := url.Parse(l.URL); err != nil || !u.IsAbs() {
+		return fmt.Errorf("auth login url is invalid: %s", l.URL)
+	}
+
+	l.UsernameSelector = strings.TrimSpace(l.UsernameSelector)
+	l.PasswordSelector = strings.TrimSpace(l.PasswordSelector)
+	if len(l.UsernameSelector) == 0 || len(l.PasswordSelector) == 0 {
+		return errors.New("auth login username and password selectors are required")
+	}
+
+	if len(l.UsernameSecret) == 0 || len(l.PasswordSecret) == 0 {
+		return errors.New("auth login username and password secrets are required")
+	}
+
+	return nil
+}
+
+// Credentials of the login resolved by Auth.Secrets
+func (l *AuthLogin) Credentials() (username, password string) {
+	return l.username, l.password
+}
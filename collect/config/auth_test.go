@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAuth_Normalize(t *testing.T) {
+
+	auth := &config.Auth{
+		Login: &config.AuthLogin{
+			URL:              " https://example.com/login ",
+			UsernameSelector: "#email",
+			PasswordSelector: "#password",
+			UsernameSecret:   "USER",
+			PasswordSecret:   "PASS",
+		},
+		Hosts: []*config.AuthHost{
+			{Host: " API.example.com ", Headers: map[string]string{"X-Client": "spider"}, TokenSecret: "TOKEN"},
+		},
+		LoggedOut: "Sign (in|up)",
+	}
+
+	require.NoError(t, auth.Normalize())
+	assert.Equal(t, "https://example.com/login", auth.Login.URL)
+	assert.True(t, auth.IsLoggedOut([]byte("<a>Sign up</a>")))
+	assert.False(t, auth.IsLoggedOut([]byte("<a>Sign out</a>")))
+
+	// secrets are required
+	assert.Error(t, auth.Secrets(map[string]string{"USER": "user"}))
+	require.NoError(t, auth.Secrets(map[string]string{"USER": "user", "PASS": "secret", "TOKEN": "token"}))
+
+	username, password := auth.Login.Credentials()
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "secret", password)
+
+	header := auth.Header("api.example.com")
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, "spider", header.Get("X-Client"))
+	assert.Nil(t, auth.Header("example.com"))
+
+	assert.Error(t, (&config.Auth{LoggedOut: "("}).Normalize())
+	assert.Error(t, (&config.Auth{Hosts: []*config.AuthHost{{Host: " "}}}).Normalize())
+	assert.Error(t, (&config.Auth{Login: &config.AuthLogin{URL: "/login"}}).Normalize())
+}
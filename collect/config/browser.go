@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// ActionWait waits for the Selector element to be visible
+	ActionWait = "wait"
+	// ActionNetworkIdle waits for no network requests in flight for the NetworkIdleTime
+	ActionNetworkIdle = "network-idle"
+	// ActionScroll scrolls to the bottom of the page Times times with the Duration pause
+	ActionScroll = "scroll"
+	// ActionClick clicks the Selector element, e.g. "Accept cookies" or "Show more"
+	ActionClick = "click"
+	// ActionType types the Value into the Selector input
+	ActionType = "type"
+	// ActionEval evaluates the Value JavaScript, promises are awaited
+	ActionEval = "eval"
+	// ActionSleep pauses for the Duration
+	ActionSleep = "sleep"
+
+	// DefaultBrowserTimeout is the page navigation timeout in browser mode
+	DefaultBrowserTimeout = Duration(30 * time.Second)
+	// DefaultActionTimeout is the timeout of the browser action if not set
+	DefaultActionTimeout = Duration(10 * time.Second)
+	// DefaultScrollPause is the pause after each scroll if Duration not set
+	DefaultScrollPause = Duration(500 * time.Millisecond)
+	// NetworkIdleTime is the quiet period of the network to consider the page loaded
+	NetworkIdleTime = 500 * time.Millisecond
+)
+
+// BlockResourceTypes are the resource types allowed to block during the page rendering
+var BlockResourceTypes = []string{"image", "media", "font", "stylesheet", "script"}
+
+// BrowserBlock is the list of requests blocked during the page rendering, e.g. images or trackers.
+//
+// JSON representation:
+//
+//	{
+//		"ResourceTypes": ["image", "media", "font"],
+//		"URLs": ["*doubleclick.net*", "*google-analytics.com*"]
+//	}
+type BrowserBlock struct {
+	// ResourceTypes to block before the request is sent: image, media, font, stylesheet or script.
+	// The saved bytes are estimated by the typical size of the resource type.
+	ResourceTypes []string `json:"ResourceTypes"`
+	// URLs globs to block before the request is sent, e.g. ad and analytics domains.
+	// "*" matches zero or more characters, "?" exactly one. The page document is never blocked.
+	URLs []string `json:"URLs"`
+}
+
+// Enabled returns true if any resource type or url is blocked
+func (b *BrowserBlock) Enabled() bool {
+	return len(b.ResourceTypes) > 0 || len(b.URLs) > 0
+}
+
+// Normalize validates resource types and url globs
+func (b *BrowserBlock) Normalize() error {
+
+	for i, resourceType := range b.ResourceTypes {
+
+		b.ResourceTypes[i] = strings.ToLower(strings.TrimSpace(resourceType))
+
+		if !slices.Contains(BlockResourceTypes, b.ResourceTypes[i]) {
+			return fmt.Errorf("browser block resource type is invalid: %s", resourceType)
+		}
+	}
+
+	for i, glob := range b.URLs {
+
+		b.URLs[i] = strings.TrimSpace(glob)
+
+		if len(b.URLs[i]) == 0 {
+			return errors.New("browser block url glob is empty")
+		}
+	}
+
+	return nil
+}
+
+// BrowserAction is the step run in the browser tab after the page loaded and before the html is taken.
+//
+// JSON representation:
+//
+//	[
+//		{"Action": "click", "Selector": "#accept-cookies", "Optional": true},
+//		{"Action": "scroll", "Times": 3, "Duration": "1s"},
+//		{"Action": "network-idle", "Timeout": "30s"},
+//		{"Action": "wait", "Selector": ".comments"}
+//	]
+type BrowserAction struct {
+	// Action is the step type: wait, network-idle, scroll, click, type, eval or sleep
+	Action string `json:"Action"`
+	// Selector is the css selector of the element to wait, click or type into
+	Selector string `json:"Selector"`
+	// Value is the text to type or JavaScript to evaluate
+	Value string `json:"Value"`
+	// Times is the number of scrolls to the bottom
+	// def: 1
+	Times int `json:"Times"`
+	// Duration of the sleep or the pause after each scroll
+	Duration Duration `json:"Duration"`
+	// Timeout of the step, not applied to sleep
+	// def: 10s, plus pauses for scroll
+	Timeout Duration `json:"Timeout"`
+	// Optional step failure is ignored, e.g. cookie dialog is not shown
+	Optional bool `json:"Optional"`
+}
+
+func (a *BrowserAction) String() string {
+
+	if len(a.Selector) > 0 {
+		return a.Action + " " + a.Selector
+	}
+
+	return a.Action
+}
+
+// Normalize sets default values and validates the action
+func (a *BrowserAction) Normalize() error {
+
+	a.Action = strings.ToLower(strings.TrimSpace(a.Action))
+	a.Selector = strings.TrimSpace(a.Selector)
+
+	switch a.Action {
+	case ActionWait, ActionClick, ActionType:
+		if len(a.Selector) == 0 {
+			return fmt.Errorf("browser action %s selector is required", a.Action)
+		}
+	case ActionEval:
+		if len(strings.TrimSpace(a.Value)) == 0 {
+			return fmt.Errorf("browser action %s value is required", a.Action)
+		}
+	case ActionScroll:
+		if a.Times <= 0 {
+			a.Times = 1
+		}
+		if a.Duration <= 0 {
+			a.Duration = DefaultScrollPause
+		}
+	case ActionSleep:
+		if a.Duration <= 0 {
+			return fmt.Errorf("browser action %s duration is required", a.Action)
+		}
+	case ActionNetworkIdle:
+	default:
+		return fmt.Errorf("browser action is invalid: %s", a.Action)
+	}
+
+	// scroll pauses are not counted by the default timeout
+	if a.Timeout <= 0 && a.Action == ActionScroll {
+		a.Timeout = DefaultActionTimeout + a.Duration*Duration(a.Times)
+	}
+
+	if a.Timeout <= 0 {
+		a.Timeout = DefaultActionTimeout
+	}
+
+	return nil
+}
+
+// BrowserTimeout is the page request timeout in browser mode: navigation and all the browser actions
+func (args *Config) BrowserTimeout() time.Duration {
+
+	timeout := DefaultBrowserTimeout
+
+	for _, action := range args.BrowserActions {
+
+		if action.Action == ActionSleep {
+			timeout += action.Duration
+			continue
+		}
+
+		timeout += action.Timeout
+	}
+
+	return timeout.Duration()
+}
+
+// Tabs is the size of the browser tabs pool
+func (args *Config) Tabs() int {
+
+	if args.BrowserTabs > 0 {
+		return args.BrowserTabs
+	}
+
+	return args.Threads()
+}
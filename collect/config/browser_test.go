@@ -0,0 +1,85 @@
+package config_test
+
+import (
+	"encoding/json"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBrowserAction_Normalize(t *testing.T) {
+
+	actions := make([]*config.BrowserAction, 0)
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"Action": "Click", "Selector": " #accept-cookies ", "Optional": true},
+		{"Action": "scroll", "Times": 3, "Duration": "1s"},
+		{"Action": "network-idle", "Timeout": "30s"},
+		{"Action": "sleep", "Duration": 2}
+	]`), &actions))
+
+	for _, action := range actions {
+		require.NoError(t, action.Normalize())
+	}
+
+	assert.Equal(t, "click #accept-cookies", actions[0].String())
+	assert.Equal(t, config.DefaultActionTimeout, actions[0].Timeout)
+	assert.Equal(t, config.Duration(13*time.Second), actions[1].Timeout)
+	assert.Equal(t, config.Duration(30*time.Second), actions[2].Timeout)
+	assert.Equal(t, config.Duration(2*time.Second), actions[3].Duration)
+
+	for _, invalid := range []*config.BrowserAction{
+		{Action: "hover", Selector: "a"},
+		{Action: config.ActionClick},
+		{Action: config.ActionType, Value: "text"},
+		{Action: config.ActionEval, Value: " "},
+		{Action: config.ActionSleep},
+	} {
+		assert.Error(t, invalid.Normalize(), invalid.Action)
+	}
+}
+
+func TestConfig_BrowserTimeout(t *testing.T) {
+
+	args := &config.Config{
+		StartURL: "https://example.com",
+		BrowserActions: []*config.BrowserAction{
+			{Action: config.ActionClick, Selector: ".show-more"},
+			{Action: config.ActionSleep, Duration: config.Duration(time.Second)},
+		},
+	}
+	require.NoError(t, args.Normalize())
+
+	// navigation, click timeout and sleep
+	assert.Equal(t, 41*time.Second, args.BrowserTimeout())
+}
+
+func TestConfig_Tabs(t *testing.T) {
+
+	args := &config.Config{
+		Limits: []*config.LimitRule{{DomainGlob: "*", Parallelism: 4}},
+	}
+	assert.Equal(t, 4, args.Tabs())
+
+	args.BrowserTabs = 2
+	assert.Equal(t, 2, args.Tabs())
+}
+
+func TestBrowserBlock_Normalize(t *testing.T) {
+
+	block := &config.BrowserBlock{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"ResourceTypes": [" Image", "font"],
+		"URLs": [" *google-analytics.com* "]
+	}`), block))
+
+	require.NoError(t, block.Normalize())
+	assert.True(t, block.Enabled())
+	assert.Equal(t, []string{"image", "font"}, block.ResourceTypes)
+	assert.Equal(t, []string{"*google-analytics.com*"}, block.URLs)
+
+	assert.False(t, (&config.BrowserBlock{}).Enabled())
+	assert.Error(t, (&config.BrowserBlock{ResourceTypes: []string{"document"}}).Normalize())
+	assert.Error(t, (&config.BrowserBlock{URLs: []string{" "}}).Normalize())
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"errors"
+)
+
+// NormalizeBudget validates the crawl budgets, zero is unlimited
+func (args *Config) NormalizeBudget() error {
+
+	if args.MaxRequests < 0 {
+		return errors.New("max requests is negative")
+	}
+
+	if args.MaxDuration < 0 {
+		return errors.New("max duration is negative")
+	}
+
+	if args.MaxPagesPerHost < 0 {
+		return errors.New("max pages per host is negative")
+	}
+
+	if args.MaxBytes < 0 {
+		return errors.New("max bytes is negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfig_NormalizeBudget(t *testing.T) {
+
+	// zero is unlimited
+	args := &config.Config{}
+	assert.NoError(t, args.NormalizeBudget())
+
+	args = &config.Config{MaxRequests: 10, MaxDuration: config.Duration(1), MaxPagesPerHost: 5, MaxBytes: 1 << 20}
+	assert.NoError(t, args.NormalizeBudget())
+
+	for _, args = range []*config.Config{
+		{MaxRequests: -1},
+		{MaxDuration: -1},
+		{MaxPagesPerHost: -1},
+		{MaxBytes: -1},
+	} {
+		assert.Error(t, args.NormalizeBudget())
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// CacheOff sends the requests to the network
+	CacheOff = "off"
+	// CacheRecord sends the requests to the network and writes every response to the archive
+	CacheRecord = "record"
+	// CacheReplay serves the responses from the archive, the network is not used
+	CacheReplay = "replay"
+)
+
+// Cache is the record and replay of the crawl responses. The recorded archive is replayed
+// to tune ExtractSelector and Fields without re-crawling the site, e.g. the same config runs in CI.
+//
+// JSON representation:
+//
+//	{
+//		"Mode": "replay",
+//		"Dir": "./archive"
+//	}
+type Cache struct {
+	// Mode is off, record or replay.
+	// def: off
+	Mode string `json:"Mode"`
+	// Dir is the local directory of the archive, the storage backend is used if empty
+	Dir string `json:"Dir"`
+}
+
+// Normalize sets the default mode and validates it
+func (c *Cache) Normalize() error {
+
+	c.Mode = strings.ToLower(strings.TrimSpace(c.Mode))
+	c.Dir = strings.TrimSpace(c.Dir)
+
+	switch c.Mode {
+	case "":
+		c.Mode = CacheOff
+	case CacheOff, CacheRecord, CacheReplay:
+	default:
+		return fmt.Errorf("cache mode is invalid: %s", c.Mode)
+	}
+
+	return nil
+}
+
+// Enabled returns true if the responses are recorded or replayed
+func (c *Cache) Enabled() bool {
+	return c.Mode == CacheRecord || c.Mode == CacheReplay
+}
+
+// Replay returns true if the crawl is served from the archive
+func (c *Cache) Replay() bool {
+	return c.Mode == CacheReplay
+}
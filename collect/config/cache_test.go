@@ -0,0 +1,26 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCache_Normalize(t *testing.T) {
+
+	cache := &config.Cache{}
+	require.NoError(t, cache.Normalize())
+	assert.Equal(t, config.CacheOff, cache.Mode)
+	assert.False(t, cache.Enabled())
+
+	cache = &config.Cache{Mode: " Replay ", Dir: " ./archive "}
+	require.NoError(t, cache.Normalize())
+	assert.Equal(t, config.CacheReplay, cache.Mode)
+	assert.Equal(t, "./archive", cache.Dir)
+	assert.True(t, cache.Enabled())
+	assert.True(t, cache.Replay())
+
+	cache = &config.Cache{Mode: "rewind"}
+	assert.Error(t, cache.Normalize())
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultTrackingParams are stripped from urls by default,
+// trailing "*" matches any param with the prefix.
+var DefaultTrackingParams = []string{
+	"utm_*",
+	"gclid",
+	"dclid",
+	"fbclid",
+	"yclid",
+	"msclkid",
+	"mc_cid",
+	"mc_eid",
+	"_ga",
+	"_gl",
+	"_hsenc",
+	"_hsmi",
+}
+
+// IndexFiles are directory index file names dropped from the path
+var IndexFiles = []string{
+	"index.html",
+	"index.htm",
+	"index.php",
+	"default.asp",
+	"default.aspx",
+}
+
+// Canonical is the url canonicalization rules applied before queueing and deduplication.
+// All rules are enabled by default, Keep* flags disable them.
+//
+// JSON representation:
+//
+//	{
+//		"StripParams": ["ref", "from"],
+//		"KeepTrailingSlash": true,
+//		"IgnoreLinkCanonical": false
+//	}
+type Canonical struct {
+	// Disabled turns off canonicalization, urls are used as is
+	Disabled bool `json:"Disabled"`
+	// StripParams are query params to strip in addition to DefaultTrackingParams,
+	// trailing "*" matches any param with the prefix, e.g. "utm_*"
+	StripParams []string `json:"StripParams"`
+	// KeepTrackingParams disables stripping of DefaultTrackingParams
+	KeepTrackingParams bool `json:"KeepTrackingParams"`
+	// KeepFragment disables dropping of #fragment
+	KeepFragment bool `json:"KeepFragment"`
+	// KeepQueryOrder disables sorting of query params
+	KeepQueryOrder bool `json:"KeepQueryOrder"`
+	// KeepTrailingSlash disables removing of the path trailing slash
+	KeepTrailingSlash bool `json:"KeepTrailingSlash"`
+	// KeepIndexFile disables removing of index.html and similar files from the path
+	KeepIndexFile bool `json:"KeepIndexFile"`
+	// IgnoreLinkCanonical disables preferring of the page <link rel="canonical"> url
+	IgnoreLinkCanonical bool `json:"IgnoreLinkCanonical"`
+}
+
+// Params is the list of query params to strip
+func (c *Canonical) Params() []string {
+
+	if c.KeepTrackingParams {
+		return c.StripParams
+	}
+
+	return append(slices.Clone(DefaultTrackingParams), c.StripParams...)
+}
+
+// String canonical form of the url, returns the url as is if it can't be parsed
+func (c *Canonical) String(uri string) string {
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	return c.URL(u).String()
+}
+
+// URL returns the canonical copy of the url
+func (c *Canonical) URL(u *url.URL) *url.URL {
+
+	canonical := *u
+
+	if c.Disabled || !canonical.IsAbs() {
+		return &canonical
+	}
+
+	// mixed-case scheme and host, default ports
+	canonical.Scheme = strings.ToLower(canonical.Scheme)
+	canonical.Host = strings.ToLower(canonical.Host)
+	canonical.Host = strings.TrimSuffix(canonical.Host, defaultPort(canonical.Scheme))
+
+	if !c.KeepFragment {
+		canonical.Fragment = ""
+		canonical.RawFragment = ""
+	}
+
+	canonical.RawQuery = c.query(canonical.RawQuery)
+
+	canonical.Path = c.path(canonical.Path)
+	canonical.RawPath = ""
+
+	return &canonical
+}
+
+func (c *Canonical) path(p string) string {
+
+	if !c.KeepIndexFile {
+		if dir, file := path.Split(p); slices.Contains(IndexFiles, strings.ToLower(file)) {
+			p = dir
+		}
+	}
+
+	if !c.KeepTrailingSlash && len(p) > 1 {
+		p = strings.TrimRight(p, "/")
+	}
+
+	// "http://example.com" and "http://example.com/" are the same
+	if len(p) == 0 {
+		p = "/"
+	}
+
+	return p
+}
+
+func (c *Canonical) query(raw string) string {
+
+	if len(raw) == 0 {
+		return raw
+	}
+
+	strip := c.Params()
+	pairs := strings.Split(raw, "&")
+	kept := make([]string, 0, len(pairs))
+
+	for _, pair := range pairs {
+
+		if len(pair) == 0 {
+			continue
+		}
+
+		name, _, _ := strings.Cut(pair, "=")
+		if key, err := url.QueryUnescape(name); err == nil && matchParam(strip, key) {
+			continue
+		}
+
+		kept = append(kept, pair)
+	}
+
+	// stable sort keeps the order of repeated params
+	if !c.KeepQueryOrder {
+		slices.SortStableFunc(kept, func(a, b string) int {
+			nameA, _, _ := strings.Cut(a, "=")
+			nameB, _, _ := strings.Cut(b, "=")
+			return strings.Compare(nameA, nameB)
+		})
+	}
+
+	return strings.Join(kept, "&")
+}
+
+func matchParam(params []string, key string) bool {
+
+	key = strings.ToLower(key)
+
+	for _, param := range params {
+
+		param = strings.ToLower(param)
+
+		if prefix, ok := strings.CutSuffix(param, "*"); ok && strings.HasPrefix(key, prefix) {
+			return true
+		}
+
+		if param == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func defaultPort(scheme string) string {
+
+	switch scheme {
+	case "http":
+		return ":80"
+	case "https":
+		return ":443"
+	}
+
+	return ""
+}
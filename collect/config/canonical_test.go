@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCanonical_String(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		rules     config.Canonical
+		uri       string
+		canonical string
+	}{
+		{
+			name:      "tracking params",
+			uri:       "https://example.com/news/1.html?utm_source=x&id=5&UTM_Medium=y&fbclid=z",
+			canonical: "https://example.com/news/1.html?id=5",
+		},
+		{
+			name:      "custom params",
+			rules:     config.Canonical{StripParams: []string{"ref", "from_*"}},
+			uri:       "https://example.com/news?ref=home&from_block=top&page=2",
+			canonical: "https://example.com/news?page=2",
+		},
+		{
+			name:      "fragment",
+			uri:       "https://example.com/news/1.html#comments",
+			canonical: "https://example.com/news/1.html",
+		},
+		{
+			name:      "sorted query",
+			uri:       "https://example.com/search?q=go&page=2&a=1&a=0",
+			canonical: "https://example.com/search?a=1&a=0&page=2&q=go",
+		},
+		{
+			name:      "host case and default port",
+			uri:       "HTTPS://Example.COM:443/News/",
+			canonical: "https://example.com/News",
+		},
+		{
+			name:      "index file",
+			uri:       "http://example.com:8080/news/index.html",
+			canonical: "http://example.com:8080/news",
+		},
+		{
+			name:      "root",
+			uri:       "https://example.com",
+			canonical: "https://example.com/",
+		},
+		{
+			name: "keep all",
+			rules: config.Canonical{
+				KeepTrackingParams: true,
+				KeepFragment:       true,
+				KeepQueryOrder:     true,
+				KeepTrailingSlash:  true,
+				KeepIndexFile:      true,
+			},
+			uri:       "https://example.com/news/index.html?utm_source=x&b=1&a=2#top",
+			canonical: "https://example.com/news/index.html?utm_source=x&b=1&a=2#top",
+		},
+		{
+			name:      "disabled",
+			rules:     config.Canonical{Disabled: true},
+			uri:       "https://Example.com/news/?utm_source=x#top",
+			canonical: "https://Example.com/news/?utm_source=x#top",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.canonical, tt.rules.String(tt.uri))
+		})
+	}
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"github.com/PuerkitoBio/goquery"
+	"github.com/editorpost/spider/extract/pipe"
 	"github.com/gocolly/colly/v2"
 	"github.com/gocolly/colly/v2/debug"
+	"github.com/gocolly/colly/v2/queue"
 	"github.com/gocolly/colly/v2/storage"
 	"net/http"
 )
@@ -12,6 +14,34 @@ type Extractor interface {
 	Extract(*colly.HTMLElement, *goquery.Selection) (bool, error)
 }
 
+// QueueStorage is the persistent request queue, unfinished run is resumed from it
+type QueueStorage interface {
+	queue.Storage
+	// Done marks the request processed
+	Done(req *colly.Request)
+	// Resumed returns true if the frontier of unfinished run is loaded
+	Resumed() bool
+}
+
+// CacheStorage is the archive of the recorded responses
+type CacheStorage interface {
+	Save(data []byte, filename string) error
+	Load(filename string) ([]byte, error)
+}
+
+// WARCWriter writes the records of the fetched responses
+type WARCWriter interface {
+	// Write the request and the response records of the raw http exchange
+	Write(req *http.Request, resp *http.Response, body []byte) (*pipe.WARCRef, error)
+	// Resource writes the resource record of the page rendered by the browser
+	Resource(uri, contentType string, body []byte) (*pipe.WARCRef, error)
+}
+
+// GraphStorage saves the link graph exports of the run
+type GraphStorage interface {
+	Save(data []byte, filename string) error
+}
+
 type Deps struct {
 	// RoundTripper is the function to return the next proxy from the list
 	RoundTripper http.RoundTripper
@@ -23,6 +53,20 @@ type Deps struct {
 	Debugger debug.Debugger
 	// Metrics is the spider event dispatcher and VictoriaMetrics
 	Monitor Metrics
+	// QueueStorage is the persistent request queue, in-memory queue is used if nil
+	QueueStorage QueueStorage
+	// Revisions enables conditional re-crawl of the pages visited by previous runs, disabled if nil
+	Revisions RevisionStorage
+	// Snapshot of the extracted pages captured by the browser, disabled if nil
+	Snapshot *pipe.SnapshotConfig
+	// Cookies is the cookie jar of the crawl session, in-memory jar is used if nil
+	Cookies http.CookieJar
+	// Cache is the archive of Config.Cache, the local Config.Cache.Dir is used if nil
+	Cache CacheStorage
+	// WARC of the fetched responses, disabled if nil
+	WARC WARCWriter
+	// Graph storage of the link graph exports, the graph is only summarized if nil
+	Graph GraphStorage
 }
 
 // Normalize default values
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// WildcardPrefix of the domain matches the domain itself and any subdomain,
+// e.g. "*.example.com" matches "example.com" and "news.example.com".
+const WildcardPrefix = "*."
+
+var hostnameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// Domains is the list of hostnames with optional wildcard subdomains
+type Domains []string
+
+// NormalizeDomain lowercases the domain and validates it, port and scheme are not allowed
+func NormalizeDomain(domain string) (string, error) {
+
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+
+	if !hostnameRegex.MatchString(strings.TrimPrefix(domain, WildcardPrefix)) {
+		return "", fmt.Errorf("allowed domain is invalid: %s", domain)
+	}
+
+	return domain, nil
+}
+
+// Match returns true if ANY domain matches the hostname,
+// empty list matches any hostname as colly does
+func (domains Domains) Match(hostname string) bool {
+
+	if len(domains) == 0 {
+		return true
+	}
+
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+
+	for _, domain := range domains {
+
+		if domain == hostname {
+			return true
+		}
+
+		if root, ok := strings.CutPrefix(domain, WildcardPrefix); ok {
+			if hostname == root || strings.HasSuffix(hostname, "."+root) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// MatchURL returns true if ANY domain matches the url hostname
+func (domains Domains) MatchURL(uri string) bool {
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+
+	return domains.Match(u.Hostname())
+}
+
+// Wildcard returns true if any domain has wildcard subdomains
+func (domains Domains) Wildcard() bool {
+
+	for _, domain := range domains {
+		if strings.HasPrefix(domain, WildcardPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DomainPattern is the regex of any http(s) url of the domain,
+// e.g. "*.example.com" => ^https?://([^/?#@]+\.)?example\.com([:/?#].*)?$
+func DomainPattern(domain string) string {
+
+	subdomains := ""
+	if root, ok := strings.CutPrefix(domain, WildcardPrefix); ok {
+		subdomains = `([^/?#@]+\.)?`
+		domain = root
+	}
+
+	return `^https?://` + subdomains + regexp.QuoteMeta(domain) + `([:/?#].*)?$`
+}
@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestDomains_Match(t *testing.T) {
+
+	domains := config.Domains{"example.com", "*.example.net"}
+
+	tests := []struct {
+		hostname string
+		match    bool
+	}{
+		{"example.com", true},
+		{"EXAMPLE.com.", true},
+		{"news.example.com", false},
+		{"example.net", true},
+		{"news.example.net", true},
+		{"a.b.example.net", true},
+		{"badexample.net", false},
+		{"example.org", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, domains.Match(tt.hostname), tt.hostname)
+	}
+
+	assert.True(t, domains.MatchURL("https://cdn.example.net:8080/a.jpg"))
+	assert.False(t, domains.MatchURL("https://example.org/"))
+	assert.True(t, domains.Wildcard())
+	assert.False(t, config.Domains{"example.com"}.Wildcard())
+
+	// no scope, any domain
+	assert.True(t, config.Domains{}.Match("example.org"))
+}
+
+func TestNormalizeDomain(t *testing.T) {
+
+	valid := map[string]string{
+		" Example.COM ":     "example.com",
+		"*.example.com":     "*.example.com",
+		"news.example.com.": "news.example.com",
+		"localhost":         "localhost",
+	}
+
+	for domain, expected := range valid {
+		actual, err := config.NormalizeDomain(domain)
+		assert.NoError(t, err, domain)
+		assert.Equal(t, expected, actual)
+	}
+
+	for _, domain := range []string{"", "*", "https://example.com", "example.com/news", "example.com:8080", "news.*.example.com", "-example.com"} {
+		_, err := config.NormalizeDomain(domain)
+		assert.Error(t, err, domain)
+	}
+}
+
+func TestDomainPattern(t *testing.T) {
+
+	re := regexp.MustCompile(config.DomainPattern("*.example.com"))
+	assert.True(t, re.MatchString("https://example.com"))
+	assert.True(t, re.MatchString("http://news.example.com/a?b=c"))
+	assert.True(t, re.MatchString("https://news.example.com:8080/"))
+	assert.False(t, re.MatchString("https://example.com.evil.net/"))
+	assert.False(t, re.MatchString("https://evil.net/?example.com"))
+
+	re = regexp.MustCompile(config.DomainPattern("example.com"))
+	assert.True(t, re.MatchString("https://example.com/"))
+	assert.False(t, re.MatchString("https://news.example.com/"))
+}
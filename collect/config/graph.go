@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// GraphJSONL is the graph export of the edges, one JSON object per line
+	GraphJSONL = "jsonl"
+	// GraphML is the graph export in the GraphML format
+	GraphML = "graphml"
+	// GraphDOT is the graph export in the Graphviz DOT format
+	GraphDOT = "dot"
+
+	// DefaultGraphEdges is the max number of the edges kept in memory until the export
+	DefaultGraphEdges = 1_000_000
+)
+
+// Graph of the crawl links: every distinct link found on the page is recorded as the edge
+// from the page to the link with the anchor text, the depth and the outcome.
+//
+// JSON representation:
+//
+//	{"Enabled": true, "Formats": ["jsonl", "graphml", "dot"], "MaxEdges": 1000000}
+type Graph struct {
+	// Enabled records the link graph of the run
+	Enabled bool `json:"Enabled"`
+	// Formats of the graph export: jsonl, graphml, dot.
+	// def: all
+	Formats []string `json:"Formats"`
+	// MaxEdges kept in memory until the export, the links over the cap are not recorded,
+	// e.g. navigation links of the large crawl.
+	// def: 1000000
+	MaxEdges int `json:"MaxEdges"`
+}
+
+// Normalize sets the default formats and edges cap, returns error on unknown format
+func (g *Graph) Normalize() error {
+
+	if g.MaxEdges < 0 {
+		return fmt.Errorf("graph max edges %d is negative", g.MaxEdges)
+	}
+
+	if g.MaxEdges == 0 {
+		g.MaxEdges = DefaultGraphEdges
+	}
+
+	if len(g.Formats) == 0 {
+		g.Formats = []string{GraphJSONL, GraphML, GraphDOT}
+	}
+
+	for _, format := range g.Formats {
+		if !slices.Contains([]string{GraphJSONL, GraphML, GraphDOT}, format) {
+			return fmt.Errorf("graph format %q is unknown", format)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGraph_Normalize(t *testing.T) {
+
+	graph := &config.Graph{Enabled: true}
+	require.NoError(t, graph.Normalize())
+	assert.Equal(t, []string{config.GraphJSONL, config.GraphML, config.GraphDOT}, graph.Formats)
+	assert.Equal(t, config.DefaultGraphEdges, graph.MaxEdges)
+
+	graph = &config.Graph{Formats: []string{config.GraphDOT}}
+	require.NoError(t, graph.Normalize())
+	assert.Equal(t, []string{config.GraphDOT}, graph.Formats)
+
+	graph = &config.Graph{Formats: []string{"gexf"}}
+	assert.Error(t, graph.Normalize())
+
+	graph = &config.Graph{MaxEdges: -1}
+	assert.Error(t, graph.Normalize())
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+)
+
+const (
+	// UserAgentFixed sends the UserAgent with every request
+	UserAgentFixed = "fixed"
+	// UserAgentRandom sends the random one of the UserAgents with every request
+	UserAgentRandom = "random"
+	// UserAgentSticky sends the same one of the UserAgents through the same proxy,
+	// so the proxy looks like the one browser to the site
+	UserAgentSticky = "sticky-proxy"
+)
+
+// NormalizeHeaders validates the headers and the user agents rotation
+func (args *Config) NormalizeHeaders() error {
+
+	headers := make(map[string]string, len(args.Headers))
+	for name, value := range args.Headers {
+
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if len(name) == 0 {
+			return errors.New("header name is empty")
+		}
+
+		// user agent is rotated
+		if name == "User-Agent" {
+			return errors.New("header User-Agent is set by UserAgent and UserAgents")
+		}
+
+		headers[name] = strings.TrimSpace(value)
+	}
+	args.Headers = headers
+
+	agents := make([]string, 0, len(args.UserAgents))
+	for _, agent := range args.UserAgents {
+		if agent = strings.TrimSpace(agent); len(agent) > 0 {
+			agents = append(agents, agent)
+		}
+	}
+	args.UserAgents = agents
+
+	args.UserAgentRotation = strings.ToLower(strings.TrimSpace(args.UserAgentRotation))
+
+	switch args.UserAgentRotation {
+	case "":
+		args.UserAgentRotation = UserAgentFixed
+	case UserAgentFixed:
+	case UserAgentRandom, UserAgentSticky:
+		if len(args.UserAgents) == 0 {
+			return fmt.Errorf("user agents are required by the rotation: %s", args.UserAgentRotation)
+		}
+	default:
+		return fmt.Errorf("user agent rotation is invalid: %s", args.UserAgentRotation)
+	}
+
+	return nil
+}
+
+// NextUserAgent of the rotation, the proxy url is the key of the sticky rotation,
+// empty if the request is sent directly
+func (args *Config) NextUserAgent(proxyURL string) string {
+
+	if len(args.UserAgents) == 0 {
+		return args.userAgent()
+	}
+
+	switch args.UserAgentRotation {
+	case UserAgentRandom:
+		return args.UserAgents[rand.IntN(len(args.UserAgents))]
+	case UserAgentSticky:
+		if len(proxyURL) == 0 {
+			return args.userAgent()
+		}
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(proxyURL))
+		return args.UserAgents[h.Sum32()%uint32(len(args.UserAgents))]
+	}
+
+	return args.userAgent()
+}
+
+// RequestHeader of the request: Headers and the user agent of the rotation
+func (args *Config) RequestHeader(proxyURL string) http.Header {
+
+	header := make(http.Header, len(args.Headers)+1)
+	for name, value := range args.Headers {
+		header.Set(name, value)
+	}
+
+	header.Set("User-Agent", args.NextUserAgent(proxyURL))
+
+	return header
+}
+
+// userAgent is the UserAgent or the default one if not normalized
+func (args *Config) userAgent() string {
+
+	if len(args.UserAgent) == 0 {
+		return DefaultUserAgent
+	}
+
+	return args.UserAgent
+}
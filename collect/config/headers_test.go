@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestConfig_NormalizeHeaders(t *testing.T) {
+
+	args := &config.Config{
+		UserAgent:  "spider",
+		UserAgents: []string{" a ", "b", ""},
+		Headers:    map[string]string{" accept-language ": " de ", "referer": "https://example.com/"},
+	}
+
+	require.NoError(t, args.NormalizeHeaders())
+	assert.Equal(t, config.UserAgentFixed, args.UserAgentRotation)
+	assert.Equal(t, []string{"a", "b"}, args.UserAgents)
+	assert.Equal(t, map[string]string{"Accept-Language": "de", "Referer": "https://example.com/"}, args.Headers)
+
+	header := args.RequestHeader("")
+	assert.Equal(t, "spider", header.Get("User-Agent"))
+	assert.Equal(t, "de", header.Get("Accept-Language"))
+	assert.Equal(t, "https://example.com/", header.Get("Referer"))
+
+	assert.Error(t, (&config.Config{Headers: map[string]string{"User-Agent": "spider"}}).NormalizeHeaders())
+	assert.Error(t, (&config.Config{UserAgentRotation: "random"}).NormalizeHeaders())
+	assert.Error(t, (&config.Config{UserAgentRotation: "round-robin", UserAgents: []string{"a"}}).NormalizeHeaders())
+}
+
+func TestConfig_NextUserAgent(t *testing.T) {
+
+	agents := []string{"a", "b", "c", "d"}
+
+	random := &config.Config{UserAgent: "spider", UserAgents: agents, UserAgentRotation: config.UserAgentRandom}
+	require.NoError(t, random.NormalizeHeaders())
+	assert.Contains(t, agents, random.NextUserAgent(""))
+
+	// same proxy, same user agent
+	sticky := &config.Config{UserAgent: "spider", UserAgents: agents, UserAgentRotation: config.UserAgentSticky}
+	require.NoError(t, sticky.NormalizeHeaders())
+	first := sticky.NextUserAgent("http://1.1.1.1:8080")
+	assert.Contains(t, agents, first)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, sticky.NextUserAgent("http://1.1.1.1:8080"))
+	}
+
+	// direct requests use the fixed one
+	assert.Equal(t, "spider", sticky.NextUserAgent(""))
+	assert.Equal(t, config.DefaultUserAgent, (&config.Config{}).NextUserAgent(""))
+}
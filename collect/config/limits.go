@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"github.com/gocolly/colly/v2"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultThreads is the number of queue consumer threads of the hosts not matching the Limits
+	DefaultThreads = 5
+)
+
+// LimitRule is the politeness rule for hosts matching the DomainGlob.
+// The first matching rule is applied, so specific rules go before "*".
+//
+// JSON representation:
+//
+//	{
+//		"DomainGlob": "*.example.com",
+//		"Parallelism": 2,
+//		"Delay": "1s",
+//		"RandomDelay": "500ms",
+//		"RequestsPerMinute": 30
+//	}
+type LimitRule struct {
+	// DomainGlob is the glob pattern of the host, e.g. "*", "example.com", "*.example.com"
+	DomainGlob string `json:"DomainGlob"`
+	// Parallelism is the maximum number of concurrent requests to the matching hosts
+	// def: 1
+	Parallelism int `json:"Parallelism"`
+	// Delay is the fixed duration to wait between requests of the same parallel slot
+	Delay Duration `json:"Delay"`
+	// RandomDelay is the extra random duration up to the value added to the Delay
+	RandomDelay Duration `json:"RandomDelay"`
+	// RequestsPerMinute is the ceiling of requests to the matching hosts,
+	// implemented by increasing the Delay, 0 means no limit.
+	RequestsPerMinute int `json:"RequestsPerMinute"`
+}
+
+// NormalizeLimits validates rules and sets defaults
+func (args *Config) NormalizeLimits() error {
+
+	for i, rule := range args.Limits {
+
+		if rule == nil {
+			return fmt.Errorf("limit rule %d is empty", i)
+		}
+
+		if err := rule.Normalize(); err != nil {
+			return fmt.Errorf("limit rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Threads is the number of queue consumer threads, sum of the rules parallelism.
+// The hosts not matching any rule have DefaultThreads on top, so the strict rule of one host
+// does not make the whole crawl single-threaded. The "*" rule matches all hosts.
+func (args *Config) Threads() int {
+
+	threads := 0
+	unmatched := true
+
+	for _, rule := range args.Limits {
+		threads += max(rule.Parallelism, 1)
+		if rule.DomainGlob == "*" {
+			unmatched = false
+		}
+	}
+
+	if unmatched {
+		threads += DefaultThreads
+	}
+
+	return threads
+}
+
+// CollyLimits creates colly rules from the Limits
+func (args *Config) CollyLimits() []*colly.LimitRule {
+
+	rules := make([]*colly.LimitRule, 0, len(args.Limits))
+	for _, rule := range args.Limits {
+		rules = append(rules, rule.Colly())
+	}
+
+	return rules
+}
+
+// LogLimits is the effective limits as a string
+func (args *Config) LogLimits() string {
+
+	rules := make([]string, 0, len(args.Limits))
+	for _, rule := range args.Limits {
+		rules = append(rules, rule.String())
+	}
+
+	return strings.Join(rules, "; ")
+}
+
+func (rule *LimitRule) Normalize() error {
+
+	rule.DomainGlob = strings.TrimSpace(rule.DomainGlob)
+	if len(rule.DomainGlob) == 0 {
+		rule.DomainGlob = "*"
+	}
+
+	if rule.Parallelism <= 0 {
+		rule.Parallelism = 1
+	}
+
+	if rule.Delay < 0 || rule.RandomDelay < 0 || rule.RequestsPerMinute < 0 {
+		return fmt.Errorf("negative limits for %s", rule.DomainGlob)
+	}
+
+	// validate glob pattern
+	return rule.Colly().Init()
+}
+
+// EffectiveDelay is the Delay increased to keep RequestsPerMinute ceiling.
+// Colly holds the parallel slot for the delay after each request,
+// so each slot makes at most minute/delay requests.
+func (rule *LimitRule) EffectiveDelay() time.Duration {
+
+	delay := rule.Delay.Duration()
+
+	if rule.RequestsPerMinute > 0 {
+		delay = max(delay, time.Duration(rule.Parallelism)*time.Minute/time.Duration(rule.RequestsPerMinute))
+	}
+
+	return delay
+}
+
+// Colly limit rule
+func (rule *LimitRule) Colly() *colly.LimitRule {
+	return &colly.LimitRule{
+		DomainGlob:  rule.DomainGlob,
+		Parallelism: rule.Parallelism,
+		Delay:       rule.EffectiveDelay(),
+		RandomDelay: rule.RandomDelay.Duration(),
+	}
+}
+
+func (rule *LimitRule) String() string {
+	return fmt.Sprintf("%s: parallelism=%d delay=%s random_delay=%s rpm=%d",
+		rule.DomainGlob,
+		rule.Parallelism,
+		rule.EffectiveDelay(),
+		rule.RandomDelay,
+		rule.RequestsPerMinute,
+	)
+}
@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"encoding/json"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+
+	tests := []struct {
+		js       string
+		expected time.Duration
+		err      bool
+	}{
+		{js: `"1.5s"`, expected: 1500 * time.Millisecond},
+		{js: `"2m"`, expected: 2 * time.Minute},
+		{js: `3`, expected: 3 * time.Second},
+		{js: `0.25`, expected: 250 * time.Millisecond},
+		{js: `""`, expected: 0},
+		{js: `null`, expected: 0},
+		{js: `"fast"`, err: true},
+		{js: `true`, err: true},
+	}
+
+	for _, tt := range tests {
+
+		var d config.Duration
+		err := json.Unmarshal([]byte(tt.js), &d)
+
+		if tt.err {
+			assert.Error(t, err, tt.js)
+			continue
+		}
+
+		require.NoError(t, err, tt.js)
+		assert.Equal(t, tt.expected, d.Duration(), tt.js)
+	}
+
+	b, err := json.Marshal(config.Duration(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, `"1s"`, string(b))
+}
+
+func TestNormalizeLimits(t *testing.T) {
+
+	args := &config.Config{}
+	require.NoError(t, json.Unmarshal([]byte(`{"Limits": [
+		{"DomainGlob": "*.example.com", "Parallelism": 2, "Delay": "1s", "RandomDelay": "500ms"},
+		{"DomainGlob": "fragile.net", "RequestsPerMinute": 6},
+		{}
+	]}`), args))
+
+	require.NoError(t, args.NormalizeLimits())
+	require.Len(t, args.Limits, 3)
+
+	// defaults
+	assert.Equal(t, "*", args.Limits[2].DomainGlob)
+	assert.Equal(t, 1, args.Limits[2].Parallelism)
+	assert.Equal(t, 4, args.Threads())
+
+	// delay increased for requests per minute ceiling
+	assert.Equal(t, time.Second, args.Limits[0].EffectiveDelay())
+	assert.Equal(t, 10*time.Second, args.Limits[1].EffectiveDelay())
+
+	rules := args.CollyLimits()
+	require.Len(t, rules, 3)
+	assert.Equal(t, 10*time.Second, rules[1].Delay)
+	assert.Equal(t, 500*time.Millisecond, rules[0].RandomDelay)
+
+	assert.Contains(t, args.LogLimits(), "fragile.net: parallelism=1 delay=10s random_delay=0s rpm=6")
+
+	// invalid rules
+	assert.Error(t, (&config.Config{Limits: []*config.LimitRule{nil}}).NormalizeLimits())
+	assert.Error(t, (&config.Config{Limits: []*config.LimitRule{{DomainGlob: "[a-"}}}).NormalizeLimits())
+	assert.Error(t, (&config.Config{Limits: []*config.LimitRule{{RequestsPerMinute: -1}}}).NormalizeLimits())
+
+	// no rules, no limits
+	assert.Equal(t, config.DefaultThreads, (&config.Config{}).Threads())
+
+	// the single strict rule does not limit the other hosts
+	fragile := &config.Config{Limits: []*config.LimitRule{{DomainGlob: "fragile.com", Parallelism: 1}}}
+	assert.Equal(t, 1+config.DefaultThreads, fragile.Threads())
+
+	// all hosts are matched
+	wide := &config.Config{Limits: []*config.LimitRule{{DomainGlob: "*.example.com", Parallelism: 4}, {DomainGlob: "*", Parallelism: 4}}}
+	assert.Equal(t, 8, wide.Threads())
+}
@@ -11,6 +11,24 @@ type Metrics interface {
 	OnError(resp *colly.Response, err error)
 	OnScraped(resp *colly.Response)
 	OnExtract(resp *colly.Response)
+	// OnRobots reports the request or page skipped by robots rule,
+	// e.g. robots.txt disallow, meta noindex or nofollow
+	OnRobots(req *colly.Request, rule string)
+	// OnDisallowed reports the url rejected by the DisallowedURLs pattern
+	OnDisallowed(uri, pattern string)
+	// OnNotModified reports the page not modified or unchanged since the previous run
+	OnNotModified(resp *colly.Response)
+	// OnBrowserTab reports the browser tab pool event, e.g. open or recycle, and the number of busy tabs
+	OnBrowserTab(event string, busy int)
+	// OnBrowserBlocked reports the number of requests blocked by the browser for the page
+	// and the bytes saved estimated by the resource types, not measured
+	OnBrowserBlocked(uri string, requests int, estimatedBytes int64)
+	// OnRetryOutcome reports the final outcome of the url retried, e.g. recovered or exhausted
+	OnRetryOutcome(uri, outcome string, attempts int)
+	// OnBudget reports the crawl budget spent, e.g. max_requests
+	OnBudget(budget string)
+	// OnTrap reports the url not queued by the crawler trap detector, e.g. template
+	OnTrap(uri, reason string)
 }
 
 type MetricsFallback struct{}
@@ -26,3 +44,19 @@ func (m *MetricsFallback) OnError(_ *colly.Response, _ error) {}
 func (m *MetricsFallback) OnScraped(_ *colly.Response) {}
 
 func (m *MetricsFallback) OnExtract(_ *colly.Response) {}
+
+func (m *MetricsFallback) OnRobots(_ *colly.Request, _ string) {}
+
+func (m *MetricsFallback) OnDisallowed(_, _ string) {}
+
+func (m *MetricsFallback) OnNotModified(_ *colly.Response) {}
+
+func (m *MetricsFallback) OnBrowserTab(_ string, _ int) {}
+
+func (m *MetricsFallback) OnBrowserBlocked(_ string, _ int, _ int64) {}
+
+func (m *MetricsFallback) OnRetryOutcome(_, _ string, _ int) {}
+
+func (m *MetricsFallback) OnBudget(_ string) {}
+
+func (m *MetricsFallback) OnTrap(_, _ string) {}
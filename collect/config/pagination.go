@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPaginationMaxPages is the number of pages per listing if Pagination.MaxPages not set
+	DefaultPaginationMaxPages = 100
+	// PaginationBase is the template placeholder of the first listing page url
+	PaginationBase = "{base}"
+	// PaginationNumber is the template placeholder of the next page number
+	PaginationNumber = "{n}"
+)
+
+// Pagination is the listing pagination walked page by page regardless of AllowedURLs and Depth.
+// Pages reached through pagination keep the depth of the first listing page.
+//
+// JSON representation:
+//
+//	{
+//		"Selector": "a.next",
+//		"URLTemplate": "{base}?page={n}",
+//		"MaxPages": 50,
+//		"ListingURLs": ["https://example.com/news"]
+//	}
+type Pagination struct {
+	// Selector is the css selector of the next page link, e.g. "a[rel=next]".
+	// Pagination stops on the page without the link.
+	Selector string `json:"Selector"`
+	// URLTemplate is the next page url, e.g. "{base}?page={n}", where {base} is the first listing page url
+	// and {n} is the next page number starting from 2. Used if Selector is empty
+	// or the matched element has no href, e.g. "Load more" button.
+	URLTemplate string `json:"URLTemplate"`
+	// MaxPages is the maximum number of pages per listing including the first page
+	// def: 100
+	MaxPages int `json:"MaxPages"`
+	// ListingURLs is the list of first listing page patterns, same placeholder syntax as AllowedURLs
+	// def: StartURLs
+	ListingURLs []string `json:"ListingURLs"`
+}
+
+// Enabled returns true if the next page selector or template is set
+func (p *Pagination) Enabled() bool {
+	return len(p.Selector) > 0 || len(p.URLTemplate) > 0
+}
+
+// Max is the maximum number of pages per listing
+func (p *Pagination) Max() int {
+
+	if p.MaxPages <= 0 {
+		return DefaultPaginationMaxPages
+	}
+
+	return p.MaxPages
+}
+
+// URL of the page n by the template, the result might be relative
+func (p *Pagination) URL(base string, n int) string {
+	return strings.NewReplacer(PaginationBase, base, PaginationNumber, strconv.Itoa(n)).Replace(p.URLTemplate)
+}
+
+// Normalize trims and validates the pagination
+func (p *Pagination) Normalize() error {
+
+	p.Selector = strings.TrimSpace(p.Selector)
+	p.URLTemplate = strings.TrimSpace(p.URLTemplate)
+
+	if len(p.URLTemplate) > 0 && !strings.Contains(p.URLTemplate, PaginationNumber) {
+		return fmt.Errorf("pagination url template is invalid, %s placeholder required: %s", PaginationNumber, p.URLTemplate)
+	}
+
+	if p.MaxPages < 0 {
+		return fmt.Errorf("pagination max pages is invalid: %d", p.MaxPages)
+	}
+
+	for i, pattern := range p.ListingURLs {
+
+		p.ListingURLs[i] = strings.TrimSpace(pattern)
+
+		if _, err := NewPatterns(p.ListingURLs[i]); err != nil || len(p.ListingURLs[i]) == 0 {
+			return fmt.Errorf("pagination listing url pattern is invalid: %s", pattern)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPagination(t *testing.T) {
+
+	p := &config.Pagination{URLTemplate: " {base}?page={n} "}
+	require.NoError(t, p.Normalize())
+
+	assert.True(t, p.Enabled())
+	assert.Equal(t, config.DefaultPaginationMaxPages, p.Max())
+	assert.Equal(t, "https://example.com/news?page=3", p.URL("https://example.com/news", 3))
+
+	assert.False(t, (&config.Pagination{MaxPages: 10}).Enabled())
+	assert.Error(t, (&config.Pagination{MaxPages: -1}).Normalize())
+	assert.Error(t, (&config.Pagination{Selector: "a.next", ListingURLs: []string{"("}}).Normalize())
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// RetryProxy is the error class of the bad proxy
+	RetryProxy = "proxy"
+	// RetryTimeout is the error class of the request deadline exceeded
+	RetryTimeout = "timeout"
+	// RetryNetwork is the error class of the connection failure, e.g. reset or refused
+	RetryNetwork = "network"
+)
+
+var (
+	// DefaultRetryStatuses are the transient statuses retried by default
+	DefaultRetryStatuses = []int{
+		http.StatusRequestTimeout,
+		http.StatusTooEarly,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+	// DefaultRetryErrors are the error classes retried by default
+	DefaultRetryErrors = []string{RetryProxy, RetryTimeout, RetryNetwork}
+)
+
+// RetryPolicy of the failed requests. The request is put back to the queue after the backoff,
+// so the queue thread is not blocked by the retry.
+//
+// JSON representation:
+//
+//	{
+//		"Statuses": [429, 503],
+//		"Errors": ["proxy", "timeout", "network"],
+//		"Attempts": 3,
+//		"ProxyAttempts": 15,
+//		"BaseDelay": "1s",
+//		"MaxDelay": "1m",
+//		"HostBudget": 100
+//	}
+type RetryPolicy struct {
+	// Disabled turns retries off
+	Disabled bool `json:"Disabled"`
+	// Statuses are the http statuses retried.
+	// def: 408, 425, 429, 500, 502, 503, 504
+	Statuses []int `json:"Statuses"`
+	// Errors are the error classes retried: proxy, timeout and network.
+	// def: all
+	Errors []string `json:"Errors"`
+	// Attempts is the number of retries of the url.
+	// def: 3
+	Attempts int `json:"Attempts"`
+	// ProxyAttempts is the number of retries of the url failed by the bad proxy, the next proxy is used.
+	// def: 15
+	ProxyAttempts int `json:"ProxyAttempts"`
+	// BaseDelay of the exponential backoff, doubled by each attempt.
+	// def: 1s
+	BaseDelay Duration `json:"BaseDelay"`
+	// MaxDelay of the backoff. The request is not retried if Retry-After is longer.
+	// def: 1m
+	MaxDelay Duration `json:"MaxDelay"`
+	// HostBudget is the number of retries per host of the run, so the failing host doesn't take the crawl time.
+	// def: 100
+	HostBudget int `json:"HostBudget"`
+}
+
+// Normalize sets the default values and validates the error classes
+func (p *RetryPolicy) Normalize() error {
+
+	if len(p.Statuses) == 0 {
+		p.Statuses = slices.Clone(DefaultRetryStatuses)
+	}
+
+	for _, status := range p.Statuses {
+		if status < 400 || status > 599 {
+			return fmt.Errorf("retry status is invalid: %d", status)
+		}
+	}
+
+	if len(p.Errors) == 0 {
+		p.Errors = slices.Clone(DefaultRetryErrors)
+	}
+
+	for i, class := range p.Errors {
+		p.Errors[i] = strings.ToLower(strings.TrimSpace(class))
+		if !slices.Contains(DefaultRetryErrors, p.Errors[i]) {
+			return fmt.Errorf("retry error class is invalid: %s", class)
+		}
+	}
+
+	if p.Attempts <= 0 {
+		p.Attempts = 3
+	}
+
+	if p.ProxyAttempts <= 0 {
+		p.ProxyAttempts = 15
+	}
+
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = Duration(time.Second)
+	}
+
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = Duration(time.Minute)
+	}
+
+	if p.MaxDelay < p.BaseDelay {
+		return fmt.Errorf("retry max delay %s is less than base delay %s", p.MaxDelay, p.BaseDelay)
+	}
+
+	if p.HostBudget <= 0 {
+		p.HostBudget = 100
+	}
+
+	return nil
+}
+
+// RetryStatus returns true if the status is retried
+func (p *RetryPolicy) RetryStatus(status int) bool {
+	return !p.Disabled && slices.Contains(p.Statuses, status)
+}
+
+// RetryError returns true if the error class is retried
+func (p *RetryPolicy) RetryError(class string) bool {
+	return !p.Disabled && slices.Contains(p.Errors, class)
+}
+
+// MaxAttempts of the error class
+func (p *RetryPolicy) MaxAttempts(class string) int {
+
+	if class == RetryProxy {
+		return p.ProxyAttempts
+	}
+
+	return p.Attempts
+}
+
+// Backoff before the attempt, starting from 1: exponential delay with the jitter of a half
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+
+	delay := p.BaseDelay.Duration()
+	for i := 1; i < attempt && delay < p.MaxDelay.Duration(); i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay.Duration())
+
+	half := delay / 2
+
+	return half + rand.N(half+1)
+}
@@ -0,0 +1,43 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Normalize(t *testing.T) {
+
+	policy := &config.RetryPolicy{Errors: []string{" Timeout "}}
+	require.NoError(t, policy.Normalize())
+
+	assert.Equal(t, config.DefaultRetryStatuses, policy.Statuses)
+	assert.Equal(t, []string{config.RetryTimeout}, policy.Errors)
+	assert.Equal(t, 3, policy.MaxAttempts(config.RetryTimeout))
+	assert.Equal(t, 15, policy.MaxAttempts(config.RetryProxy))
+	assert.True(t, policy.RetryStatus(503))
+	assert.False(t, policy.RetryStatus(404))
+	assert.True(t, policy.RetryError(config.RetryTimeout))
+	assert.False(t, policy.RetryError(config.RetryNetwork))
+
+	policy.Disabled = true
+	assert.False(t, policy.RetryStatus(503))
+
+	assert.Error(t, (&config.RetryPolicy{Statuses: []int{200}}).Normalize())
+	assert.Error(t, (&config.RetryPolicy{Errors: []string{"dns"}}).Normalize())
+	assert.Error(t, (&config.RetryPolicy{BaseDelay: config.Duration(time.Minute), MaxDelay: config.Duration(time.Second)}).Normalize())
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+
+	policy := &config.RetryPolicy{BaseDelay: config.Duration(time.Second), MaxDelay: config.Duration(10 * time.Second)}
+	require.NoError(t, policy.Normalize())
+
+	for attempt, delay := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 5: 10 * time.Second, 100: 10 * time.Second} {
+		backoff := policy.Backoff(attempt)
+		assert.GreaterOrEqual(t, backoff, delay/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, backoff, delay, "attempt %d", attempt)
+	}
+}
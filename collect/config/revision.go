@@ -0,0 +1,28 @@
+package config
+
+type (
+	// Revision is the page state of the previous runs, used for conditional re-crawl
+	Revision struct {
+		// ETag response header, sent back as If-None-Match
+		ETag string `json:"ETag"`
+		// LastModified response header, sent back as If-Modified-Since
+		LastModified string `json:"LastModified"`
+		// Hash is the sha256 of the response body
+		Hash string `json:"Hash"`
+		// PayloadID is the last payload extracted from the page
+		PayloadID string `json:"PayloadID"`
+	}
+
+	// RevisionStorage remembers page revisions between runs
+	RevisionStorage interface {
+		// Revision of the url or nil if the page is not visited before
+		Revision(uri string) *Revision
+		// SetRevision of the url
+		SetRevision(uri string, rev *Revision)
+	}
+)
+
+// Conditional returns true if the revision has validators for the conditional request
+func (rev *Revision) Conditional() bool {
+	return len(rev.ETag) > 0 || len(rev.LastModified) > 0
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+)
+
+// Traps is the crawler trap detection of the queued urls: infinite calendars,
+// repeated path segments, session ids and parameter explosions.
+// The offending pattern is quarantined, the next urls of it are not queued.
+// All rules are enabled by default, zero limits are set to the defaults.
+//
+// JSON representation:
+//
+//	{"MaxTemplateURLs": 1000, "MaxSegmentRepeats": 3, "MaxQueryCombinations": 100}
+type Traps struct {
+	// Disabled turns off the detection, urls are queued as is
+	Disabled bool `json:"Disabled"`
+	// MaxTemplateURLs is the number of distinct urls per path template, e.g. /archive/{num}/{num}/{num},
+	// entity urls matching ExtractURLs are not capped.
+	// def: 1000
+	MaxTemplateURLs int `json:"MaxTemplateURLs"`
+	// MaxSegmentRepeats is the number of times the same path segment might be found in the url path,
+	// e.g. /a/b/a/b/a is the trap of 3 repeats.
+	// def: 3
+	MaxSegmentRepeats int `json:"MaxSegmentRepeats"`
+	// MaxQueryCombinations is the number of distinct query strings per path, e.g. session ids or filters,
+	// entity urls matching ExtractURLs and pagination pages are not capped.
+	// def: 100
+	MaxQueryCombinations int `json:"MaxQueryCombinations"`
+}
+
+// Normalize sets the default limits, returns error on negative ones
+func (t *Traps) Normalize() error {
+
+	if t.MaxTemplateURLs < 0 || t.MaxSegmentRepeats < 0 || t.MaxQueryCombinations < 0 {
+		return errors.New("traps limits are negative")
+	}
+
+	// the segment found once is not repeated
+	if t.MaxSegmentRepeats == 1 {
+		return errors.New("traps max segment repeats is less than 2")
+	}
+
+	if t.MaxTemplateURLs == 0 {
+		t.MaxTemplateURLs = 1000
+	}
+
+	if t.MaxSegmentRepeats == 0 {
+		t.MaxSegmentRepeats = 3
+	}
+
+	if t.MaxQueryCombinations == 0 {
+		t.MaxQueryCombinations = 100
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package config_test
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTraps_Normalize(t *testing.T) {
+
+	traps := &config.Traps{}
+	require.NoError(t, traps.Normalize())
+	assert.Equal(t, config.Traps{MaxTemplateURLs: 1000, MaxSegmentRepeats: 3, MaxQueryCombinations: 100}, *traps)
+
+	traps = &config.Traps{MaxTemplateURLs: 10, MaxSegmentRepeats: 2, MaxQueryCombinations: 5}
+	require.NoError(t, traps.Normalize())
+	assert.Equal(t, 10, traps.MaxTemplateURLs)
+
+	assert.Error(t, (&config.Traps{MaxTemplateURLs: -1}).Normalize())
+	assert.Error(t, (&config.Traps{MaxSegmentRepeats: 1}).Normalize())
+}
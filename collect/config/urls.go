@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
@@ -96,13 +97,61 @@ func ContentLikeURL(urlStr string) bool {
 	return true
 }
 
+// Patterns is a list of compiled RegexPattern expressions
+type Patterns []*regexp.Regexp
+
+// NewPatterns compiles the RegexPattern expressions
+func NewPatterns(exprs ...string) (Patterns, error) {
+
+	patterns := make(Patterns, 0, len(exprs))
+
+	for _, expr := range exprs {
+		re, err := regexp.Compile(RegexPattern(expr))
+		if err != nil {
+			return nil, fmt.Errorf("url pattern %s: %w", expr, err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	return patterns, nil
+}
+
+// Match returns true if ANY pattern matches the url
+func (patterns Patterns) Match(uri string) bool {
+
+	for _, pattern := range patterns {
+		if pattern.MatchString(uri) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Index returns the index of the first pattern matching the url or -1
+func (patterns Patterns) Index(uri string) int {
+
+	for i, pattern := range patterns {
+		if pattern.MatchString(uri) {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // MustHostname from url
 func MustHostname(fromURL string) string {
+	return MustURL(fromURL).Hostname()
+}
+
+// MustURL parses the url or panics, use for normalized urls
+func MustURL(fromURL string) *url.URL {
 
 	uri, err := url.Parse(fromURL)
 	if err != nil {
 		panic(err)
 	}
 
-	return uri.Hostname()
+	return uri
 }
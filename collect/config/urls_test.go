@@ -3,6 +3,7 @@ package config_test
 import (
 	"github.com/editorpost/spider/collect/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -123,3 +124,23 @@ func TestCreateRegexpString(t *testing.T) {
 		})
 	}
 }
+
+func TestPatterns(t *testing.T) {
+
+	patterns, err := config.NewPatterns(
+		"https://example.com/articles/{num}",
+		"https://example.com/{news,blog}/{some}",
+	)
+	require.NoError(t, err)
+
+	assert.True(t, patterns.Match("https://example.com/articles/123"))
+	assert.True(t, patterns.Match("https://example.com/blog/post"))
+	assert.False(t, patterns.Match("https://example.com/articles/one"))
+	assert.False(t, config.Patterns{}.Match("https://example.com"))
+
+	assert.Equal(t, 1, patterns.Index("https://example.com/news/post"))
+	assert.Equal(t, -1, patterns.Index("https://example.com/tag/news"))
+
+	_, err = config.NewPatterns("(")
+	assert.Error(t, err)
+}
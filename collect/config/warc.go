@@ -0,0 +1,36 @@
+package config
+
+import "time"
+
+// WARC of the fetched responses: the request and the response records of the raw http exchange
+// are written to the WARC 1.1 files of the collect storage.
+//
+// JSON representation:
+//
+//	{"Enabled": true, "MaxSize": 100, "FlushInterval": "1m"}
+type WARC struct {
+	// Enabled writes every fetched response to the WARC file
+	Enabled bool `json:"Enabled"`
+	// MaxSize of the WARC file in megabytes, the next file is started after it.
+	// def: 100
+	MaxSize int `json:"MaxSize"`
+	// FlushInterval of the current file saved to the storage, the next file is started after it,
+	// the killed run loses the records of the last interval only.
+	// def: 1m
+	FlushInterval Duration `json:"FlushInterval"`
+}
+
+// Normalize sets the default file size and flush interval
+func (w *WARC) Normalize() {
+	if w.MaxSize <= 0 {
+		w.MaxSize = 100
+	}
+	if w.FlushInterval <= 0 {
+		w.FlushInterval = Duration(time.Minute)
+	}
+}
+
+// MaxBytes of the WARC file
+func (w *WARC) MaxBytes() int64 {
+	return int64(w.MaxSize) << 20
+}
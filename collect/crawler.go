@@ -3,27 +3,67 @@ package collect
 import (
 	"context"
 	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/events"
+	"github.com/editorpost/spider/collect/graph"
+	"github.com/editorpost/spider/collect/proxy"
 	"github.com/gocolly/colly/v2"
-	"github.com/gocolly/colly/v2/queue"
 	"log/slog"
+	"sync"
+	"time"
 )
 
+// DrainTimeout is the time of the requests in progress to finish after the run is cancelled,
+// the requests, browser tabs and extractors are cancelled after it
+var DrainTimeout = 20 * time.Second
+
 // Crawler for scraping a website
 type Crawler struct {
 	args      *config.Config
 	deps      *config.Deps
-	queue     *queue.Queue
+	queue     *Queue
 	collect   *colly.Collector
+	dispatch  *events.Dispatch
 	chromeCtx context.Context
+	tabs      *TabPool
+	proxies   *proxy.Pool
+	session   *Session
+	// snapshots of the pages rendered by the browser by request url
+	snapshots sync.Map
+	// entities are ExtractURLs patterns
+	entities config.Patterns
 }
 
 func NewCrawler(args *config.Config, deps *config.Deps) (*Crawler, error) {
 
+	// start urls and crawl scope are required by the collector
+	if err := args.NormalizeURLs(); err != nil {
+		return nil, err
+	}
+
+	// retry policy defaults are required by the dispatcher
+	if err := args.Retry.Normalize(); err != nil {
+		return nil, err
+	}
+
+	// cache mode is required by the transports
+	if err := args.Cache.Normalize(); err != nil {
+		return nil, err
+	}
+
+	// trap limits are required by the queue
+	if err := args.Traps.Normalize(); err != nil {
+		return nil, err
+	}
+
 	crawler := &Crawler{
 		args: args,
 		deps: deps.Normalize(),
 	}
 
+	if err := crawler.withCache(); err != nil {
+		return nil, err
+	}
+
 	if _, err := crawler.collector(); err != nil {
 		return nil, err
 	}
@@ -31,20 +71,41 @@ func NewCrawler(args *config.Config, deps *config.Deps) (*Crawler, error) {
 	return crawler, nil
 }
 
-// Run the scraping Crawler.
-func (crawler *Crawler) Run() error {
+// Run the scraping Crawler until the frontier is exhausted, a budget is spent or the ctx is done.
+// The queue is stopped by the done ctx, the requests in progress are drained within the DrainTimeout.
+func (crawler *Crawler) Run(ctx context.Context) error {
 
-	if crawler.args.UseBrowser {
-		// create chrome allocator context
-		cancel := crawler.setupChrome()
-		// disable async in browser mode
-		crawler.collect.Async = false
+	work, cancelWork := crawler.drain(ctx, DrainTimeout)
+	defer cancelWork()
+
+	// in-flight requests, browser tabs and extractors are cancelled with the work
+	crawler.collect.Context = work
+
+	// replayed pages are not rendered again
+	if crawler.args.UseBrowser && !crawler.args.Cache.Replay() {
+		// create chrome browser with the pool of tabs,
+		// pages are browsed concurrently by the queue threads
+		cancel, err := crawler.setupChrome(work)
+		if err != nil {
+			return err
+		}
 		defer cancel()
 	}
 
 	slog.Info("collector starting", crawler.args.Log())
 
-	if err := crawler.queue.AddURL(crawler.args.StartURL); err != nil {
+	started := time.Now()
+
+	// duration of the run is started before the login and seeding
+	budget := crawler.dispatch.Budget()
+	budget.Start()
+	defer budget.Close()
+
+	if err := crawler.login(); err != nil {
+		return err
+	}
+
+	if err := crawler.seed(); err != nil {
 		return err
 	}
 
@@ -54,6 +115,78 @@ func (crawler *Crawler) Run() error {
 
 	crawler.collect.Wait()
 
+	if ctx.Err() != nil {
+		slog.Info("collector cancelled", slog.String("cause", context.Cause(ctx).Error()))
+	}
+
+	// the links of the cancelled run are exported as well
+	return crawler.exportGraph(started)
+}
+
+// drain returns the work context of the run: the queue is stopped once the ctx is done,
+// the work is cancelled after the timeout, so the requests in progress might finish
+func (crawler *Crawler) drain(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+
+	work, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	stop := context.AfterFunc(ctx, func() {
+
+		slog.Info("collector stopping", slog.Duration("drain", timeout))
+		crawler.Stop()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			slog.Warn("collector drain timeout, requests cancelled")
+			cancel()
+		case <-work.Done():
+		}
+	})
+
+	return work, func() {
+		stop()
+		cancel()
+	}
+}
+
+// Result of the run
+type Result struct {
+	// BudgetResult is the budget ended the crawl, requests, bytes and duration
+	events.BudgetResult
+	// Graph is the in-degree and out-degree of the links by the url pattern, if Config.Graph enabled
+	Graph []*graph.Degree `json:"Graph,omitempty"`
+}
+
+// Result of the run: the budget ended the crawl, requests, bytes, duration and the link graph summary
+func (crawler *Crawler) Result() *Result {
+	return &Result{
+		BudgetResult: *crawler.dispatch.Budget().Result(),
+		Graph:        crawler.dispatch.Graph().Summary(),
+	}
+}
+
+// seed the queue with sitemaps and start urls,
+// skipped if the frontier of unfinished run is resumed
+func (crawler *Crawler) seed() error {
+
+	if crawler.deps.QueueStorage != nil && crawler.deps.QueueStorage.Resumed() {
+		slog.Info("collector resumed, seeding skipped")
+		return nil
+	}
+
+	// sitemap entries goes first
+	if err := crawler.withSitemaps(); err != nil {
+		return err
+	}
+
+	for _, startURL := range crawler.args.StartURLs {
+		if err := crawler.queue.AddURL(startURL); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1,19 +1,38 @@
 package collect_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/chromedp/cdproto/network"
 	"github.com/editorpost/donq/mongodb"
 	"github.com/editorpost/spider/collect"
 	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/events"
+	"github.com/editorpost/spider/collect/graph"
+	"github.com/editorpost/spider/collect/sitemap"
+	"github.com/editorpost/spider/extract/pipe"
 	"github.com/gocolly/colly/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io"
 	"log"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestCollect(t *testing.T) {
@@ -39,7 +58,7 @@ func TestCollect(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	err = crawler.Run()
+	err = crawler.Run(context.Background())
 
 	require.NoError(t, err)
 	assert.True(t, dispatched)
@@ -69,10 +88,1239 @@ func TestJSCollect(t *testing.T) {
 	)
 
 	require.NoError(t, err)
-	require.NoError(t, crawler.Run())
+	require.NoError(t, crawler.Run(context.Background()))
 	assert.True(t, dispatched)
 }
 
+func TestSitemapCollect(t *testing.T) {
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = w.Write([]byte("Sitemap: " + srv.URL + "/sitemap.xml"))
+		case "/sitemap.xml":
+			_, _ = w.Write([]byte(`<urlset><url><loc>` + srv.URL + `/articles/1.html</loc><lastmod>2024-05-01</lastmod></url>` +
+				`<url><loc>` + srv.URL + `/tags/1.html</loc></url></urlset>`))
+		default:
+			_, _ = w.Write([]byte(`<html><body><article>` + r.URL.Path + `</article></body></html>`))
+		}
+	}))
+	defer srv.Close()
+
+	lastMod := make(map[string]string)
+
+	crawler, err := collect.NewCrawler(
+		&config.Config{
+			StartURL:        srv.URL,
+			AllowedURLs:     []string{".*"},
+			ExtractURLs:     []string{srv.URL + "/articles/{any}"},
+			ExtractSelector: "article",
+			SitemapDiscover: true,
+			Depth:           1,
+		},
+		&config.Deps{
+			Extractor: config.NewExtractor(func(e *colly.HTMLElement, _ *goquery.Selection) (bool, error) {
+				lastMod[e.Request.URL.Path] = e.Request.Ctx.Get(sitemap.LastModCtx)
+				return true, nil
+			}),
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// listing pages of the sitemap are not queued
+	assert.Equal(t, map[string]string{"/articles/1.html": "2024-05-01"}, lastMod)
+}
+
+func TestRobotsCollect(t *testing.T) {
+
+	pages := map[string]string{
+		"/":              `<a href="/private/1.html">1</a><a href="/noindex.html">2</a><a href="/nofollow.html">3</a><a rel="nofollow" href="/skip.html">4</a>`,
+		"/noindex.html":  `<meta name="robots" content="noindex"><a href="/linked.html">5</a>`,
+		"/nofollow.html": `<meta name="Robots" content="nofollow"><a href="/hidden.html">6</a>`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/"))
+			return
+		}
+		_, _ = w.Write([]byte("<html>" + pages[r.URL.Path] + "</html>"))
+	}))
+	defer srv.Close()
+
+	extracted := make([]string, 0)
+	mute := &sync.Mutex{}
+
+	crawler, err := collect.NewCrawler(
+		&config.Config{
+			StartURL:        srv.URL,
+			AllowedURLs:     []string{".*"},
+			ExtractSelector: "html",
+			RespectRobots:   true,
+		},
+		&config.Deps{
+			Extractor: config.NewExtractor(func(e *colly.HTMLElement, _ *goquery.Selection) (bool, error) {
+				mute.Lock()
+				defer mute.Unlock()
+				extracted = append(extracted, e.Request.URL.Path)
+				return true, nil
+			}),
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	assert.ElementsMatch(t, []string{"/", "/nofollow.html", "/linked.html"}, extracted)
+}
+
+func TestMultiDomainCollect(t *testing.T) {
+
+	requested := make([]string, 0)
+	mute := &sync.Mutex{}
+
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mute.Lock()
+		defer mute.Unlock()
+		requested = append(requested, r.Host+r.URL.Path)
+		_, _ = w.Write([]byte(`<html><article>` + r.URL.Path + `</article></html>`))
+	}))
+	defer cdn.Close()
+
+	// same server on the host out of the scope
+	cdnURL := config.MustURL(cdn.URL)
+	cdnLocal := "http://localhost:" + cdnURL.Port()
+	outOfScope := "http://127.0.0.2:" + cdnURL.Port()
+
+	news := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><a href="` + cdnLocal + `/linked.html">1</a><a href="` + outOfScope + `/out.html">2</a></html>`))
+	}))
+	defer news.Close()
+
+	crawler, err := collect.NewCrawler(
+		&config.Config{
+			StartURLs:       []string{news.URL, cdnLocal + "/start.html"},
+			ExtractSelector: "article",
+			Depth:           2,
+		},
+		&config.Deps{},
+	)
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	host := "localhost:" + cdnURL.Port()
+	assert.ElementsMatch(t, []string{host + "/start.html", host + "/linked.html"}, requested)
+}
+
+func TestDisallowedCollect(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><article>` + r.URL.Path + `</article>` +
+			`<a href="/news/1.html">1</a><a href="/tag/news">2</a><a href="/search?q=news">3</a><a href="/print/1.html">4</a>` +
+			`<a href="/searchengine-review">5</a></html>`))
+	}))
+	defer srv.Close()
+
+	extracted := make([]string, 0)
+	monitor := &DisallowedMetrics{rejected: make(map[string]string)}
+
+	crawler, err := collect.NewCrawler(
+		&config.Config{
+			StartURL:        srv.URL,
+			DisallowedURLs:  []string{"{any}/tag/{any}", `{any}/search\?{any}`, `/print/`},
+			ExtractSelector: "article",
+			VisitOnce:       true,
+		},
+		&config.Deps{
+			Monitor: monitor,
+			Extractor: config.NewExtractor(func(e *colly.HTMLElement, _ *goquery.Selection) (bool, error) {
+				monitor.mute.Lock()
+				defer monitor.mute.Unlock()
+				extracted = append(extracted, e.Request.URL.Path)
+				return true, nil
+			}),
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// the query mark is escaped, the path starting with the same word is allowed
+	assert.ElementsMatch(t, []string{"/", "/news/1.html", "/searchengine-review"}, extracted)
+	assert.Equal(t, map[string]string{
+		srv.URL + "/tag/news":      "{any}/tag/{any}",
+		srv.URL + "/search?q=news": `{any}/search\?{any}`,
+		srv.URL + "/print/1.html":  "/print/",
+	}, monitor.rejected)
+}
+
+type DisallowedMetrics struct {
+	config.MetricsFallback
+	mute     sync.Mutex
+	rejected map[string]string
+}
+
+func (m *DisallowedMetrics) OnDisallowed(uri, pattern string) {
+	m.mute.Lock()
+	defer m.mute.Unlock()
+	m.rejected[uri] = pattern
+}
+
+func TestCanonicalCollect(t *testing.T) {
+
+	pages := map[string]string{
+		"/": `<a href="/news/1.html?utm_source=home">1</a><a href="/news/1.html#comments">2</a>` +
+			`<a href="/news/index.html">3</a><a href="/amp/1.html?b=2&a=1">4</a>`,
+		"/amp/1.html": `<link rel="canonical" href="/news/1.html">`,
+	}
+
+	requested := make([]string, 0)
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mute.Lock()
+		requested = append(requested, r.URL.RequestURI())
+		mute.Unlock()
+		_, _ = w.Write([]byte(`<html><head>` + pages[r.URL.Path] + `</head><article>` + r.URL.Path + `</article></html>`))
+	}))
+	defer srv.Close()
+
+	extracted := make(map[string]string)
+
+	pipeline := pipe.NewPipeline(func(p *pipe.Payload) error {
+		extracted[p.URL.RequestURI()] = p.OriginalURL.RequestURI()
+		return nil
+	})
+
+	// extract once, nothing extracted on previous runs
+	pipeline.History().Init(func() []string {
+		return nil
+	})
+
+	crawler, err := collect.NewCrawler(
+		&config.Config{
+			StartURL:        srv.URL,
+			ExtractSelector: "article",
+			VisitOnce:       true,
+			// sequential requests
+			Limits: []*config.LimitRule{{DomainGlob: "*", Parallelism: 1}},
+		},
+		&config.Deps{
+			Extractor: pipeline,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// variants are not requested
+	assert.ElementsMatch(t, []string{"/", "/news/1.html", "/news", "/amp/1.html?a=1&b=2"}, requested)
+
+	// amp version is the duplicate of the canonical
+	assert.Equal(t, map[string]string{
+		"/":            "/",
+		"/news/1.html": "/news/1.html",
+		"/news":        "/news",
+	}, extracted)
+}
+
+func TestEntityFirstCollect(t *testing.T) {
+
+	pages := map[string]string{
+		"/":            `<a href="/section/a">a</a><a href="/section/b">b</a><a href="/news/1.html">1</a><a href="/news?page=2">2</a><a href="/news/2.html">3</a>`,
+		"/news":        `<a href="/news/3.html">3</a>`,
+		"/section/a":   `<a href="/news/4.html">4</a>`,
+		"/section/b":   `<a href="/news/5.html">5</a>`,
+		"/news/1.html": `<article>1</article>`,
+		"/news/2.html": `<article>2</article>`,
+	}
+
+	requested := make([]string, 0)
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mute.Lock()
+		requested = append(requested, r.URL.RequestURI())
+		mute.Unlock()
+		_, _ = w.Write([]byte(`<html>` + pages[r.URL.Path] + `</html>`))
+	}))
+	defer srv.Close()
+
+	crawler, err := collect.NewCrawler(
+		&config.Config{
+			StartURL:        srv.URL,
+			ExtractURLs:     []string{srv.URL + "/news/{num}.html"},
+			ExtractSelector: "article",
+			ExtractLimit:    2,
+			Scheduling:      "entity-first",
+			Depth:           1,
+			// sequential requests
+			Limits: []*config.LimitRule{{DomainGlob: "*", Parallelism: 1}},
+		},
+		&config.Deps{
+			Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+				return true, nil
+			}),
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// budget is spent on the entities before the listings,
+	// the queue might load one more request before it's stopped
+	require.GreaterOrEqual(t, len(requested), 3)
+	assert.Equal(t, []string{"/", "/news/1.html", "/news/2.html"}, requested[:3])
+	assert.NotContains(t, requested, "/section/b")
+}
+
+func TestPaginationCollect(t *testing.T) {
+
+	tc := []struct {
+		name       string
+		pagination config.Pagination
+		requested  []string
+	}{
+		{
+			name:       "selector",
+			pagination: config.Pagination{Selector: "a.next", MaxPages: 4},
+			requested: []string{
+				"/news", "/news/1.html",
+				"/news?page=2", "/news/2.html",
+				"/news?page=3", "/news/3.html",
+				"/news?page=4", "/news/4.html",
+			},
+		},
+		{
+			name:       "template",
+			pagination: config.Pagination{URLTemplate: "{base}?page={n}", MaxPages: 3},
+			requested: []string{
+				"/news", "/news/1.html",
+				"/news?page=2", "/news/2.html",
+				"/news?page=3", "/news/3.html",
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+
+			requested := make([]string, 0)
+			mute := &sync.Mutex{}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mute.Lock()
+				requested = append(requested, r.URL.RequestURI())
+				mute.Unlock()
+
+				page := r.URL.Query().Get("page")
+				if page == "" {
+					page = "1"
+				}
+				next, _ := strconv.Atoi(page)
+
+				_, _ = fmt.Fprintf(w, `<html><a href="/news/%s.html">entity</a><a class="next" href="/news?page=%d">next</a></html>`, page, next+1)
+			}))
+			defer srv.Close()
+
+			crawler, err := collect.NewCrawler(
+				&config.Config{
+					StartURL: srv.URL + "/news",
+					// pagination is excluded from the allowed urls
+					AllowedURLs: []string{"^" + regexp.QuoteMeta(srv.URL+"/news") + "$", srv.URL + "/news/{num}.html"},
+					Pagination:  tt.pagination,
+					Scheduling:  "bfs",
+					VisitOnce:   true,
+					// entities are one level deeper than the listing
+					Depth: 2,
+					// sequential requests
+					Limits: []*config.LimitRule{{DomainGlob: "*", Parallelism: 1}},
+				},
+				&config.Deps{},
+			)
+			require.NoError(t, err)
+			require.NoError(t, crawler.Run(context.Background()))
+
+			assert.ElementsMatch(t, tt.requested, requested)
+		})
+	}
+}
+
+func TestRevalidateCollect(t *testing.T) {
+
+	run := 0
+	conditional := make([]string, 0)
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		mute.Lock()
+		defer mute.Unlock()
+
+		switch r.URL.Path {
+		case "/news/1.html":
+			// validated by etag
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				conditional = append(conditional, r.URL.Path)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			_, _ = w.Write([]byte(`<html><article>1</article></html>`))
+		case "/news/2.html":
+			// validated by body hash
+			_, _ = w.Write([]byte(`<html><article>2</article></html>`))
+		case "/news/3.html":
+			// changed on every run
+			_, _ = fmt.Fprintf(w, `<html><article>3 rev %d</article></html>`, run)
+		default:
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a><a href="/news/2.html">2</a><a href="/news/3.html">3</a></html>`))
+		}
+	}))
+	defer srv.Close()
+
+	revisions := &Revisions{revisions: make(map[string]*config.Revision)}
+	payloads := make(map[string]*pipe.Payload)
+
+	for run = 1; run <= 2; run++ {
+
+		extracted := make(map[string]*pipe.Payload)
+
+		pipeline := pipe.NewPipeline(func(p *pipe.Payload) error {
+			mute.Lock()
+			extracted[p.URL.Path] = p
+			mute.Unlock()
+			return nil
+		})
+
+		// extract once
+		pipeline.History().Init(func() []string {
+			urls := make([]string, 0)
+			for _, p := range payloads {
+				urls = append(urls, p.URL.String())
+			}
+			return urls
+		})
+
+		crawler, err := collect.NewCrawler(
+			&config.Config{
+				StartURL:        srv.URL,
+				ExtractURLs:     []string{srv.URL + "/news/{num}.html"},
+				ExtractSelector: "article",
+				VisitOnce:       true,
+				Revalidate:      true,
+			},
+			&config.Deps{
+				Extractor: pipeline,
+				Revisions: revisions,
+			},
+		)
+		require.NoError(t, err)
+		require.NoError(t, crawler.Run(context.Background()))
+
+		if run == 1 {
+			require.Len(t, extracted, 3)
+			payloads = extracted
+			continue
+		}
+
+		// not modified and unchanged pages are skipped
+		assert.Equal(t, []string{"/news/1.html"}, conditional)
+		require.Len(t, extracted, 1)
+
+		// changed page is extracted regardless of the history
+		changed := extracted["/news/3.html"]
+		require.NotNil(t, changed)
+		assert.Equal(t, payloads["/news/3.html"].ID, changed.PreviousID)
+		assert.Equal(t, changed.PreviousID, changed.Data[pipe.PreviousIDField])
+		assert.Equal(t, changed.ID, revisions.Revision(srv.URL+"/news/3.html").PayloadID)
+	}
+}
+
+func TestBrowserRevalidateCollect(t *testing.T) {
+
+	RequireChrome(t)
+
+	conditional := make([]string, 0)
+	cookies := make([]string, 0)
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		mute.Lock()
+		defer mute.Unlock()
+
+		if r.URL.Path != "/news/1.html" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if sid, err := r.Cookie("sid"); err == nil {
+			cookies = append(cookies, sid.Value)
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional = append(conditional, r.URL.Path)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		_, _ = w.Write([]byte(`<html><article>1</article></html>`))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/news/1.html")
+	require.NoError(t, err)
+
+	// the cookie of the jar is sent by the browser
+	jar := filepath.Join(t.TempDir(), "cookies.txt")
+	require.NoError(t, os.WriteFile(jar, []byte("# Netscape HTTP Cookie File\n"+u.Hostname()+"\tFALSE\t/\tFALSE\t0\tsid\tsession\n"), 0644))
+
+	// the page of the previous run
+	revisions := &Revisions{revisions: map[string]*config.Revision{u.String(): {ETag: `"v1"`}}}
+	monitor := &NotModifiedMonitor{}
+
+	args := &config.Config{
+		StartURL:        u.String(),
+		ExtractURLs:     []string{srv.URL + "/news/{num}.html"},
+		ExtractSelector: "article",
+		Revalidate:      true,
+		UseBrowser:      true,
+		Auth:            &config.Auth{CookiesFile: jar},
+	}
+	require.NoError(t, args.Normalize())
+
+	extracted := atomic.Int32{}
+	crawler, err := collect.NewCrawler(args, &config.Deps{
+		Monitor:   monitor,
+		Revisions: revisions,
+		Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+			extracted.Add(1)
+			return true, nil
+		}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// the validators of the request are sent by the browser, the page is not modified
+	assert.Equal(t, []string{"/news/1.html"}, conditional)
+	assert.Equal(t, []string{"session"}, cookies)
+	assert.Equal(t, int32(1), monitor.count.Load())
+	assert.Zero(t, extracted.Load())
+}
+
+// NotModifiedMonitor counts the pages not modified
+type NotModifiedMonitor struct {
+	config.MetricsFallback
+	count atomic.Int32
+}
+
+func (m *NotModifiedMonitor) OnNotModified(*colly.Response) {
+	m.count.Add(1)
+}
+
+// RequireChrome skips the test if the browser is not installed
+func RequireChrome(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "headless-shell"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return
+		}
+	}
+
+	t.Skip("chrome is not installed")
+}
+
+func TestAuthCollect(t *testing.T) {
+
+	logins := 0
+	session := ""
+	expired := false
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		mute.Lock()
+		defer mute.Unlock()
+
+		switch r.URL.Path {
+		case "/login":
+			_, _ = w.Write([]byte(`<html><form action="/session" method="post">
+				<input type="hidden" name="csrf" value="token">
+				<input name="email"><input type="password" name="pass">
+			</form></html>`))
+			return
+		case "/session":
+			if r.PostFormValue("csrf") != "token" || r.PostFormValue("email") != "user" || r.PostFormValue("pass") != "secret" {
+				_, _ = w.Write([]byte(`<html>Please sign in</html>`))
+				return
+			}
+			logins++
+			session = strconv.Itoa(logins)
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: session, Path: "/"})
+			_, _ = w.Write([]byte(`<html>Welcome</html>`))
+			return
+		}
+
+		// imported cookie and the host token
+		pref, _ := r.Cookie("pref")
+		if pref == nil || pref.Value != "dark" || r.Header.Get("Authorization") != "Bearer api-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// the session expires once on the second page
+		if r.URL.Path == "/news/2.html" && !expired {
+			expired = true
+			session = ""
+		}
+
+		sid, _ := r.Cookie("sid")
+		if sid == nil || sid.Value != session {
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a>Please sign in</html>`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/news/1.html", "/news/2.html":
+			_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+		default:
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a><a href="/news/2.html">2</a></html>`))
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	cookies := filepath.Join(t.TempDir(), "cookies.txt")
+	require.NoError(t, os.WriteFile(cookies, []byte("# Netscape HTTP Cookie File\n"+u.Hostname()+"\tFALSE\t/\tFALSE\t0\tpref\tdark\n"), 0644))
+
+	args := &config.Config{
+		StartURL:        srv.URL,
+		ExtractURLs:     []string{srv.URL + "/news/{num}.html"},
+		ExtractSelector: "article",
+		Auth: &config.Auth{
+			Login: &config.AuthLogin{
+				URL:              srv.URL + "/login",
+				UsernameSelector: "input[name=email]",
+				PasswordSelector: "input[type=password]",
+				UsernameSecret:   "USER",
+				PasswordSecret:   "PASS",
+			},
+			CookiesFile: cookies,
+			Hosts:       []*config.AuthHost{{Host: u.Hostname(), TokenSecret: "TOKEN"}},
+			LoggedOut:   "Please sign in",
+		},
+	}
+	require.NoError(t, args.Normalize())
+	require.NoError(t, args.Auth.Secrets(map[string]string{"USER": "user", "PASS": "secret", "TOKEN": "api-token"}))
+
+	extracted := make([]string, 0)
+	pipeline := pipe.NewPipeline(func(p *pipe.Payload) error {
+		mute.Lock()
+		extracted = append(extracted, p.URL.Path)
+		mute.Unlock()
+		return nil
+	})
+
+	crawler, err := collect.NewCrawler(args, &config.Deps{Extractor: pipeline})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// logged in again after the session expired, the page is requested once more
+	assert.Equal(t, 2, logins)
+	assert.ElementsMatch(t, []string{"/news/1.html", "/news/2.html"}, extracted)
+}
+
+func TestSessionRestored(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sid, _ := r.Cookie("sid"); sid == nil || sid.Value != "valid" {
+			_, _ = w.Write([]byte(`<html>Please sign in</html>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<html>Welcome</html>`))
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	auth := &config.Auth{
+		Login: &config.AuthLogin{
+			URL:              srv.URL + "/login",
+			UsernameSelector: "input[name=email]",
+			PasswordSelector: "input[type=password]",
+			UsernameSecret:   "USER",
+			PasswordSecret:   "PASS",
+		},
+		LoggedOut: "Please sign in",
+	}
+	require.NoError(t, auth.Normalize())
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	session := collect.NewSession(auth, jar, http.DefaultTransport)
+
+	// no cookies
+	assert.False(t, session.Restored(srv.URL))
+
+	// the tracking cookie is not the session
+	jar.SetCookies(u, []*http.Cookie{{Name: "_ga", Value: "GA1.1", Path: "/"}})
+	assert.False(t, session.Restored(srv.URL))
+
+	jar.SetCookies(u, []*http.Cookie{{Name: "sid", Value: "valid", Path: "/"}})
+	assert.True(t, session.Restored(srv.URL))
+}
+
+func TestHeadersCollect(t *testing.T) {
+
+	agents := make(map[string]bool)
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		mute.Lock()
+		agents[r.UserAgent()] = true
+		mute.Unlock()
+
+		if r.Header.Get("Accept-Language") != "de" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a><a href="/news/2.html">2</a><a href="/news/3.html">3</a></html>`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	args := &config.Config{
+		StartURL:    srv.URL,
+		ExtractURLs: []string{srv.URL + "/news/{num}.html"},
+		UserAgent:   "spider",
+		Headers:     map[string]string{"Accept-Language": "de"},
+	}
+	require.NoError(t, args.Normalize())
+
+	extracted := atomic.Int32{}
+	crawler, err := collect.NewCrawler(args, &config.Deps{
+		Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+			extracted.Add(1)
+			return true, nil
+		}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// fixed user agent is not replaced by the random one
+	assert.Equal(t, map[string]bool{"spider": true}, agents)
+	assert.Equal(t, int32(3), extracted.Load())
+}
+
+func TestRetryCollect(t *testing.T) {
+
+	requests := make(map[string]int)
+	mute := &sync.Mutex{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		mute.Lock()
+		requests[r.URL.Path]++
+		count := requests[r.URL.Path]
+		mute.Unlock()
+
+		switch r.URL.Path {
+		case "/news/1.html":
+			// recovered after the server asked to wait
+			if count == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`<html><article>1</article></html>`))
+		case "/news/2.html":
+			w.WriteHeader(http.StatusNotFound)
+		case "/news/3.html":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a><a href="/news/2.html">2</a><a href="/news/3.html">3</a></html>`))
+		}
+	}))
+	defer srv.Close()
+
+	args := &config.Config{
+		StartURL:    srv.URL,
+		ExtractURLs: []string{srv.URL + "/news/{num}.html"},
+		VisitOnce:   true,
+		Retry: config.RetryPolicy{
+			Attempts:  2,
+			BaseDelay: config.Duration(10 * time.Millisecond),
+			MaxDelay:  config.Duration(50 * time.Millisecond),
+		},
+	}
+	require.NoError(t, args.Normalize())
+
+	monitor := &RetryMonitor{outcomes: make(map[string]string)}
+	extracted := atomic.Int32{}
+
+	crawler, err := collect.NewCrawler(args, &config.Deps{
+		Monitor: monitor,
+		Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+			extracted.Add(1)
+			return true, nil
+		}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// 404 is not retried, 500 is retried up to the attempts
+	assert.Equal(t, map[string]int{"/": 1, "/news/1.html": 2, "/news/2.html": 1, "/news/3.html": 3}, requests)
+	assert.Equal(t, int32(1), extracted.Load())
+	assert.Equal(t, map[string]string{
+		srv.URL + "/news/1.html": events.RetryRecovered,
+		srv.URL + "/news/3.html": events.RetryExhausted,
+	}, monitor.outcomes)
+}
+
+func TestRetryBrowserAction(t *testing.T) {
+
+	policy := &config.RetryPolicy{Attempts: 2}
+	require.NoError(t, policy.Normalize())
+
+	u, err := url.Parse("https://example.com/news/1.html")
+	require.NoError(t, err)
+
+	delayed := &DelayQueue{}
+	retry := events.NewRetry(policy, delayed, &config.MetricsFallback{})
+
+	resp := func() *colly.Response {
+		return &colly.Response{Request: &colly.Request{URL: u, Method: http.MethodGet, Ctx: colly.NewContext()}}
+	}
+
+	// the failed action of the browser transport is not retried
+	action := &url.Error{Op: "Get", URL: u.String(), Err: fmt.Errorf("browser: %w", &events.BrowserActionError{
+		Step:     1,
+		Action:   "click .show-more",
+		Selector: ".show-more",
+		Err:      context.DeadlineExceeded,
+	})}
+	assert.ErrorIs(t, action, events.ErrBrowserAction)
+	assert.False(t, retry.Request(resp(), action))
+
+	// the network error is retried
+	assert.True(t, retry.Request(resp(), &url.Error{Op: "Get", URL: u.String(), Err: io.ErrUnexpectedEOF}))
+	assert.Equal(t, 1, delayed.count)
+}
+
+// DelayQueue counts the delayed requests
+type DelayQueue struct {
+	count int
+}
+
+func (q *DelayQueue) AddURL(string) error { return nil }
+
+func (q *DelayQueue) AddRequest(*colly.Request) error { return nil }
+
+func (q *DelayQueue) Delay(*colly.Request, time.Duration) error {
+	q.count++
+	return nil
+}
+
+func (q *DelayQueue) Stop() {}
+
+// RetryMonitor records the retry outcomes
+type RetryMonitor struct {
+	config.MetricsFallback
+	outcomes map[string]string
+	mute     sync.Mutex
+}
+
+func (m *RetryMonitor) OnRetryOutcome(uri, outcome string, _ int) {
+	m.mute.Lock()
+	defer m.mute.Unlock()
+	m.outcomes[uri] = outcome
+}
+
+// Revisions is the in-memory config.RevisionStorage
+type Revisions struct {
+	revisions map[string]*config.Revision
+	mute      sync.Mutex
+}
+
+func (r *Revisions) Revision(uri string) *config.Revision {
+	r.mute.Lock()
+	defer r.mute.Unlock()
+	return r.revisions[uri]
+}
+
+func (r *Revisions) SetRevision(uri string, rev *config.Revision) {
+	r.mute.Lock()
+	defer r.mute.Unlock()
+	r.revisions[uri] = rev
+}
+
+func TestCacheCollect(t *testing.T) {
+
+	requests := atomic.Int32{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		requests.Add(1)
+
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a><a href="/news/2.html">2</a></html>`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+	}))
+
+	archive := t.TempDir()
+
+	crawl := func(mode string) []string {
+
+		args := &config.Config{
+			StartURL:    srv.URL,
+			ExtractURLs: []string{srv.URL + "/news/{num}.html"},
+			Cache:       config.Cache{Mode: mode, Dir: archive},
+		}
+		require.NoError(t, args.Normalize())
+
+		extracted := make([]string, 0)
+		mute := &sync.Mutex{}
+
+		crawler, err := collect.NewCrawler(args, &config.Deps{
+			Extractor: config.NewExtractor(func(e *colly.HTMLElement, _ *goquery.Selection) (bool, error) {
+				mute.Lock()
+				extracted = append(extracted, e.DOM.Find("article").Text())
+				mute.Unlock()
+				return true, nil
+			}),
+		})
+		require.NoError(t, err)
+		require.NoError(t, crawler.Run(context.Background()))
+
+		slices.Sort(extracted)
+		return extracted
+	}
+
+	recorded := crawl(config.CacheRecord)
+	assert.Equal(t, []string{"/news/1.html", "/news/2.html"}, recorded)
+
+	// replayed without the network
+	srv.Close()
+	sent := requests.Load()
+
+	assert.Equal(t, recorded, crawl(config.CacheReplay))
+	assert.Equal(t, sent, requests.Load())
+
+	// not recorded pages are not found
+	archive = t.TempDir()
+	assert.Empty(t, crawl(config.CacheReplay))
+}
+
+func TestBudgetCollect(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		args    config.Config
+		stopped string
+		// max requests served
+		served int32
+	}{
+		{"frontier exhausted", config.Config{}, "", 11},
+		{"extract limit", config.Config{ExtractLimit: 2}, events.BudgetExtractLimit, 3},
+		{"max requests", config.Config{MaxRequests: 3}, events.BudgetMaxRequests, 3},
+		{"max pages per host", config.Config{MaxPagesPerHost: 4}, events.BudgetMaxPagesPerHost, 4},
+		{"max bytes", config.Config{MaxBytes: 1}, events.BudgetMaxBytes, 1},
+		{"max duration", config.Config{MaxDuration: config.Duration(50 * time.Millisecond)}, events.BudgetMaxDuration, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			served := atomic.Int32{}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+				served.Add(1)
+
+				if r.URL.Path == "/" {
+					links := ""
+					for i := 1; i <= 10; i++ {
+						links += fmt.Sprintf(`<a href="/news/%d.html">%d</a>`, i, i)
+					}
+					_, _ = fmt.Fprintf(w, `<html>%s</html>`, links)
+					return
+				}
+
+				// slow pages are stopped by the duration
+				if tt.args.MaxDuration > 0 {
+					time.Sleep(30 * time.Millisecond)
+				}
+
+				_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+			}))
+			defer srv.Close()
+
+			args := tt.args
+			args.StartURL = srv.URL
+			args.ExtractURLs = []string{srv.URL + "/news/{num}.html"}
+			args.Limits = []*config.LimitRule{{DomainGlob: "*", Parallelism: 1}}
+			require.NoError(t, args.Normalize())
+
+			crawler, err := collect.NewCrawler(&args, &config.Deps{
+				Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+					return true, nil
+				}),
+			})
+			require.NoError(t, err)
+			require.NoError(t, crawler.Run(context.Background()))
+
+			result := crawler.Result()
+			assert.Equal(t, tt.stopped, result.Stopped)
+			assert.LessOrEqual(t, served.Load(), tt.served)
+			assert.Equal(t, int64(served.Load()), result.Requests)
+			assert.Positive(t, result.Duration)
+		})
+	}
+}
+
+func TestCancelCollect(t *testing.T) {
+
+	drain := collect.DrainTimeout
+	collect.DrainTimeout = 100 * time.Millisecond
+	defer func() { collect.DrainTimeout = drain }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := atomic.Int32{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path == "/" {
+			links := ""
+			for i := 1; i <= 10; i++ {
+				links += fmt.Sprintf(`<a href="/news/%d.html">%d</a>`, i, i)
+			}
+			_, _ = fmt.Fprintf(w, `<html>%s</html>`, links)
+			return
+		}
+
+		// the run is cancelled by the first page, the hanging one is cancelled by the drain timeout
+		if served.Add(1) == 1 {
+			cancel()
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	args := &config.Config{
+		StartURL:    srv.URL,
+		ExtractURLs: []string{srv.URL + "/news/{num}.html"},
+		Limits:      []*config.LimitRule{{DomainGlob: "*", Parallelism: 1}},
+	}
+	require.NoError(t, args.Normalize())
+
+	crawler, err := collect.NewCrawler(args, &config.Deps{})
+	require.NoError(t, err)
+
+	started := time.Now()
+	require.NoError(t, crawler.Run(ctx))
+
+	// the queue is stopped, the hanging request is not waited for
+	assert.Less(t, time.Since(started), 3*time.Second)
+	assert.Equal(t, int32(1), served.Load())
+}
+
+func TestGraphCollect(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`<html>
+				<a href="/news/1.html">First</a>
+				<a href="/news/2.html">Second</a>
+				<a href="/tag/go">Tag</a>
+				<a href="/logo.png">Logo</a>
+				<a href="https://other.com/">Other</a>
+			</html>`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `<html><article>%s</article><a href="/">Home</a></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+
+	args := &config.Config{
+		StartURL:       srv.URL,
+		ExtractURLs:    []string{srv.URL + "/news/{num}.html"},
+		DisallowedURLs: []string{"{any}/tag/{any}"},
+		VisitOnce:      true,
+		Graph:          config.Graph{Enabled: true},
+	}
+	require.NoError(t, args.Normalize())
+
+	crawler, err := collect.NewCrawler(args, &config.Deps{
+		Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+			return true, nil
+		}),
+		Graph: collect.NewDirCache(dir),
+	})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	home := srv.URL + "/"
+	edges := make(map[string]graph.Edge)
+
+	files, err := filepath.Glob(filepath.Join(dir, "links-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var edge graph.Edge
+		require.NoError(t, json.Unmarshal([]byte(line), &edge))
+		edges[edge.Source+" "+edge.Target] = edge
+	}
+
+	assert.Equal(t, graph.Edge{Source: home, Target: srv.URL + "/news/1.html", Text: "First", Depth: 1, Status: graph.Extracted}, edges[home+" "+srv.URL+"/news/1.html"])
+	assert.Equal(t, graph.Edge{Source: home, Target: srv.URL + "/tag/go", Text: "Tag", Depth: 1, Status: graph.Filtered, Reason: events.LinkDisallowed}, edges[home+" "+srv.URL+"/tag/go"])
+	assert.Equal(t, events.LinkContent, edges[home+" "+srv.URL+"/logo.png"].Reason)
+	assert.Equal(t, events.LinkAllowed, edges[home+" https://other.com/"].Reason)
+	assert.Equal(t, graph.Queued, edges[srv.URL+"/news/2.html "+home].Status)
+
+	for _, ext := range []string{config.GraphML, config.GraphDOT} {
+		assert.FileExists(t, strings.TrimSuffix(files[0], config.GraphJSONL)+ext)
+	}
+
+	result := crawler.Result()
+	assert.Equal(t, []*graph.Degree{
+		// the home page and the filtered links of the allowed urls
+		{Pattern: srv.URL + "{any}", URLs: 3, In: 4, Out: 5},
+		{Pattern: srv.URL + "/news/{num}.html", URLs: 2, In: 2, Out: 2},
+		{Pattern: "https://other.com/", URLs: 1, In: 1, Out: 0},
+	}, result.Graph)
+}
+
+func TestTrapCollect(t *testing.T) {
+
+	calendar := atomic.Int32{}
+	listing := atomic.Int32{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// the infinite calendar links to the next day
+		if day, ok := strings.CutPrefix(r.URL.Path, "/calendar/"); ok {
+			calendar.Add(1)
+			next, _ := strconv.Atoi(day)
+			_, _ = fmt.Fprintf(w, `<html><a href="/calendar/%d">Next</a><a href="/news/1.html">1</a></html>`, next+1)
+			return
+		}
+
+		// the listing pages are not capped by the query combinations
+		if r.URL.Path == "/list" {
+			listing.Add(1)
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page < 10 {
+				_, _ = fmt.Fprintf(w, `<html><a href="/list?page=%d">Next</a></html>`, page+1)
+			}
+			return
+		}
+
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`<html><a href="/calendar/1">Calendar</a><a href="/list?page=1">List</a><a href="/news/1.html">1</a><a href="/news/2.html">2</a></html>`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	args := &config.Config{
+		StartURL:    srv.URL,
+		ExtractURLs: []string{srv.URL + "/news/{num}.html"},
+		VisitOnce:   true,
+		Traps:       config.Traps{MaxTemplateURLs: 5, MaxQueryCombinations: 3},
+	}
+	require.NoError(t, args.Normalize())
+
+	extracted := atomic.Int32{}
+
+	crawler, err := collect.NewCrawler(args, &config.Deps{
+		Extractor: config.NewExtractor(func(*colly.HTMLElement, *goquery.Selection) (bool, error) {
+			extracted.Add(1)
+			return true, nil
+		}),
+	})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+
+	// the calendar template is quarantined, the entities are crawled
+	assert.Equal(t, int32(5), calendar.Load())
+	assert.Equal(t, int32(10), listing.Load())
+	assert.Equal(t, int32(2), extracted.Load())
+}
+
+func TestResponseHeader(t *testing.T) {
+
+	header := collect.ResponseHeader(network.Headers{
+		"Set-Cookie":   "a=1\nb=2",
+		"Content-Type": "text/html",
+		"X-Values":     []any{"one", "two"},
+	})
+
+	assert.Equal(t, []string{"a=1", "b=2"}, header.Values("Set-Cookie"))
+	assert.Equal(t, "text/html", header.Get("Content-Type"))
+	assert.Equal(t, []string{"one", "two"}, header.Values("X-Values"))
+}
+
+func TestCopyRequest(t *testing.T) {
+
+	u, err := url.Parse("https://example.com/search")
+	require.NoError(t, err)
+
+	body := strings.NewReader("q=spider")
+	headers := http.Header{"Content-Type": []string{"application/x-www-form-urlencoded"}}
+	r := &colly.Request{URL: u, Method: http.MethodPost, Depth: 2, Body: body, Headers: &headers, Ctx: colly.NewContext()}
+
+	// the body is read by the previous attempt
+	_, _ = io.ReadAll(body)
+
+	ctx := colly.NewContext()
+	req := events.CopyRequest(r, ctx)
+
+	assert.Equal(t, u, req.URL)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, 2, req.Depth)
+	assert.Same(t, ctx, req.Ctx)
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Headers.Get("Content-Type"))
+
+	b, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "q=spider", string(b))
+
+	// headers are not shared
+	req.Headers.Set("Content-Type", "text/plain")
+	assert.Equal(t, "application/x-www-form-urlencoded", r.Headers.Get("Content-Type"))
+}
+
 func TestMongoConfig(t *testing.T) {
 	// Test the mongodb config
 	validResource := map[string]interface{}{
@@ -0,0 +1,185 @@
+package events
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// BudgetExtractLimit is the budget of the config.Config ExtractLimit
+	BudgetExtractLimit = "extract_limit"
+	// BudgetMaxRequests is the budget of the config.Config MaxRequests
+	BudgetMaxRequests = "max_requests"
+	// BudgetMaxDuration is the budget of the config.Config MaxDuration
+	BudgetMaxDuration = "max_duration"
+	// BudgetMaxPagesPerHost is the budget of the config.Config MaxPagesPerHost,
+	// the crawl ended by it if the pages of the capped hosts were skipped
+	BudgetMaxPagesPerHost = "max_pages_per_host"
+	// BudgetMaxBytes is the budget of the config.Config MaxBytes
+	BudgetMaxBytes = "max_bytes"
+)
+
+// Budget of the run: extracted entities, requests, duration, pages per host and downloaded bytes.
+// The queue is stopped gracefully by the first budget spent, the requests in progress are finished.
+type Budget struct {
+	args     *config.Config
+	queue    Queue
+	monitor  config.Metrics
+	requests atomic.Int64
+	bytes    atomic.Int64
+	started  time.Time
+	duration time.Duration
+	timer    *time.Timer
+	mute     *sync.Mutex
+	// pages requested per host
+	hosts map[string]int
+	// capped is true if the pages of the host were skipped by the cap
+	capped bool
+	// spent is the budget stopped the queue
+	spent string
+}
+
+// BudgetResult of the run
+type BudgetResult struct {
+	// Stopped is the budget ended the crawl, empty if the frontier is exhausted
+	Stopped  string          `json:"Stopped"`
+	Requests int64           `json:"Requests"`
+	Bytes    int64           `json:"Bytes"`
+	Duration config.Duration `json:"Duration"`
+}
+
+func NewBudget(args *config.Config, queue Queue, monitor config.Metrics) *Budget {
+	return &Budget{
+		args:    args,
+		queue:   queue,
+		monitor: monitor,
+		mute:    &sync.Mutex{},
+		hosts:   make(map[string]int),
+	}
+}
+
+// Start the duration of the run
+func (b *Budget) Start() {
+
+	b.mute.Lock()
+	defer b.mute.Unlock()
+
+	b.started = time.Now()
+
+	if b.args.MaxDuration > 0 {
+		b.timer = time.AfterFunc(b.args.MaxDuration.Duration(), func() {
+			b.Spend(BudgetMaxDuration)
+		})
+	}
+}
+
+// Close the duration of the run
+func (b *Budget) Close() {
+
+	b.mute.Lock()
+	defer b.mute.Unlock()
+
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+
+	b.duration = time.Since(b.started)
+}
+
+// Request is counted by the budget, false if the request is over the host cap or the max requests
+func (b *Budget) Request(r *colly.Request) bool {
+
+	if !b.host(r.URL.Hostname()) {
+		slog.Debug("budget: host capped", slog.String("url", r.URL.String()))
+		return false
+	}
+
+	if b.args.MaxRequests == 0 {
+		b.requests.Add(1)
+		return true
+	}
+
+	requests := b.requests.Add(1)
+
+	// the requests in progress after the queue stopped
+	if requests > int64(b.args.MaxRequests) {
+		b.requests.Add(-1)
+		return false
+	}
+
+	if requests == int64(b.args.MaxRequests) {
+		b.Spend(BudgetMaxRequests)
+	}
+
+	return true
+}
+
+// Response bytes are counted by the budget
+func (b *Budget) Response(r *colly.Response) {
+
+	total := b.bytes.Add(int64(len(r.Body)))
+
+	if b.args.MaxBytes > 0 && total >= b.args.MaxBytes {
+		b.Spend(BudgetMaxBytes)
+	}
+}
+
+// Spend the budget, the first one spent stops the queue
+func (b *Budget) Spend(budget string) {
+
+	b.mute.Lock()
+	if len(b.spent) > 0 {
+		b.mute.Unlock()
+		return
+	}
+	b.spent = budget
+	b.mute.Unlock()
+
+	slog.Info("budget spent, crawler stopping", slog.String("budget", budget))
+	b.monitor.OnBudget(budget)
+
+	b.queue.Stop()
+}
+
+// Result of the run
+func (b *Budget) Result() *BudgetResult {
+
+	b.mute.Lock()
+	defer b.mute.Unlock()
+
+	stopped := b.spent
+	if len(stopped) == 0 && b.capped {
+		stopped = BudgetMaxPagesPerHost
+	}
+
+	return &BudgetResult{
+		Stopped:  stopped,
+		Requests: b.requests.Load(),
+		Bytes:    b.bytes.Load(),
+		Duration: config.Duration(b.duration),
+	}
+}
+
+// host page is counted, false if the host is capped
+func (b *Budget) host(host string) bool {
+
+	if b.args.MaxPagesPerHost == 0 {
+		return true
+	}
+
+	b.mute.Lock()
+	defer b.mute.Unlock()
+
+	if b.hosts[host] >= b.args.MaxPagesPerHost {
+		b.capped = true
+		return false
+	}
+
+	b.hosts[host]++
+
+	return true
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/gocolly/colly/v2"
+	"hash/fnv"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// canonicalLink is the canonical form of the link to queue
+func (crawler *Dispatch) canonicalLink(link string) string {
+	return crawler.args.Canonical.String(link)
+}
+
+// canonicalPage sets the canonical url of the page to the request context,
+// <link rel="canonical"> is preferred if it's in the crawl scope.
+// Must be registered before visit and extract handlers.
+func (crawler *Dispatch) canonicalPage(e *colly.HTMLElement) {
+
+	if crawler.args.Canonical.Disabled {
+		return
+	}
+
+	canonical := crawler.args.Canonical.URL(e.Request.URL)
+
+	if link := crawler.linkCanonical(e); link != nil {
+		canonical = crawler.args.Canonical.URL(link)
+	}
+
+	uri := canonical.String()
+	e.Request.Ctx.Put(pipe.CanonicalURLCtx, uri)
+
+	if uri == e.Request.URL.String() {
+		return
+	}
+
+	// the canonical url content is the same, don't visit it again
+	if err := crawler.deps.Storage.Visited(VisitedHash(uri)); err != nil {
+		slog.Warn("canonical: visited", slog.String("url", uri), slog.String("error", err.Error()))
+	}
+}
+
+// linkCanonical is the absolute url of the <link rel="canonical">
+func (crawler *Dispatch) linkCanonical(e *colly.HTMLElement) *url.URL {
+
+	if crawler.args.Canonical.IgnoreLinkCanonical {
+		return nil
+	}
+
+	href := ""
+	e.ForEachWithBreak(`link[rel][href]`, func(_ int, link *colly.HTMLElement) bool {
+		for _, rel := range strings.Fields(link.Attr("rel")) {
+			if strings.EqualFold(rel, "canonical") {
+				href = link.Attr("href")
+				return false
+			}
+		}
+		return true
+	})
+
+	if len(href) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(e.Request.AbsoluteURL(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+
+	// canonical of other site is not trusted
+	if !crawler.args.Domains().Match(u.Hostname()) {
+		return nil
+	}
+
+	return u
+}
+
+// VisitedHash is the colly storage key of the GET request url
+func VisitedHash(uri string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(uri))
+	return h.Sum64()
+}
@@ -0,0 +1,39 @@
+package events
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"log/slog"
+)
+
+// newDisallowed compiles Config.DisallowedURLs patterns
+func (crawler *Dispatch) newDisallowed() config.Patterns {
+
+	patterns, err := config.NewPatterns(crawler.args.DisallowedURLs...)
+	if err != nil {
+		// validated by Config.Normalize
+		slog.Error("disallowed urls", slog.String("error", err.Error()))
+		return nil
+	}
+
+	return patterns
+}
+
+// isDisallowed checks the url against DisallowedURLs,
+// rejected urls are logged and counted with the pattern.
+func (crawler *Dispatch) isDisallowed(uri string) bool {
+
+	i := crawler.disallowed.Index(uri)
+	if i < 0 {
+		return false
+	}
+
+	pattern := crawler.args.DisallowedURLs[i]
+	crawler.deps.Monitor.OnDisallowed(uri, pattern)
+
+	slog.Info("disallowed: skipped",
+		slog.String("pattern", pattern),
+		slog.String("url", uri),
+	)
+
+	return true
+}
@@ -1,10 +1,12 @@
 package events
 
 import (
-	"github.com/PuerkitoBio/goquery"
 	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/graph"
+	"github.com/editorpost/spider/collect/robots"
 	"github.com/gocolly/colly/v2"
 	"sync/atomic"
+	"time"
 )
 
 type (
@@ -12,66 +14,128 @@ type (
 		args           *config.Config
 		deps           *config.Deps
 		queue          Queue
-		browser        Browser
-		proxyRetry     *Retry
-		errorRetry     *Retry
+		robots         *robots.Robots
+		collector      *colly.Collector
+		disallowed     config.Patterns
+		entities       config.Patterns
+		listings       config.Patterns
+		allowed        config.Patterns
+		retry          *Retry
+		budget         *Budget
+		graph          *graph.Graph
 		extractedCount atomic.Int32
 	}
 
-	Browser interface {
-		Browse(uri string) (*goquery.Selection, error)
-	}
-
 	Queue interface {
 		AddURL(uri string) error
+		AddRequest(r *colly.Request) error
+		// Delay the request put back to the queue, e.g. by the retry backoff
+		Delay(r *colly.Request, d time.Duration) error
 		Stop()
 	}
 )
 
-func NewDispatcher(args *config.Config, deps *config.Deps, queue Queue, browser Browser) *Dispatch { // long miles away...
-	return &Dispatch{
+func NewDispatcher(args *config.Config, deps *config.Deps, queue Queue) *Dispatch { // long miles away...
+
+	d := &Dispatch{
 		args:           args,
 		deps:           deps,
 		queue:          queue,
-		browser:        browser,
-		proxyRetry:     NewRetry(BadProxyRetries),
-		errorRetry:     NewRetry(ResponseRetries),
+		retry:          NewRetry(&args.Retry, queue, deps.Monitor),
+		budget:         NewBudget(args, queue, deps.Monitor),
 		extractedCount: atomic.Int32{},
 	}
+
+	d.robots = d.newRobots()
+	d.disallowed = d.newDisallowed()
+	d.entities = d.newEntities()
+	d.listings = d.newListings()
+	d.allowed = d.newAllowed()
+	d.graph = d.newGraph()
+
+	return d
 }
 
 // WithDispatcher sets up the event handlers for the crawler.
 // It sets handlers for HTML elements, errors, requests, and responses.
 // noinspection GoUnusedExportedFunction
-func WithDispatcher(args *config.Config, deps *config.Deps, queue Queue, browser Browser) func(*colly.Collector) {
-
-	d := NewDispatcher(args, deps, queue, browser)
-
-	return func(c *colly.Collector) {
-
-		// collect links
-		c.OnHTML(`a[href]`, d.visit())
-		// extract data
-		c.OnHTML(`html`, d.extract())
-		// catch errors, run retry
-		c.OnError(d.error)
-		// rest for monitoring
-		c.OnRequest(d.request)
-		c.OnResponse(d.response)
-		c.OnScraped(d.scraped)
+func WithDispatcher(args *config.Config, deps *config.Deps, queue Queue) func(*colly.Collector) {
+	return NewDispatcher(args, deps, queue).Setup
+}
+
+// Setup the event handlers of the collector
+func (crawler *Dispatch) Setup(c *colly.Collector) {
+
+	// the run context of the collector cancels the waits of the requests
+	crawler.collector = c
+
+	// meta robots directives and canonical url, must go before links and data
+	c.OnHTML(`html`, crawler.robotsMeta)
+	c.OnHTML(`html`, crawler.canonicalPage)
+	// collect links
+	c.OnHTML(`a[href]`, crawler.visit())
+	// follow listing pagination
+	if crawler.args.Pagination.Enabled() {
+		c.OnHTML(`html`, crawler.paginate)
 	}
+	// extract data
+	c.OnHTML(`html`, crawler.extract())
+	// catch errors, run retry
+	c.OnError(crawler.error)
+	// rest for monitoring
+	c.OnRequest(crawler.request)
+	c.OnResponse(crawler.response)
+	c.OnScraped(crawler.scraped)
+}
+
+// Budget of the run
+func (crawler *Dispatch) Budget() *Budget {
+	return crawler.budget
 }
 
 // request dispatcher
 func (crawler *Dispatch) request(r *colly.Request) {
+
+	// wildcard domains aren't checked by colly
+	if !crawler.args.Domains().Match(r.URL.Hostname()) {
+		r.Abort()
+		return
+	}
+
+	// allowed urls aren't checked by colly if pagination enabled,
+	// pagination pages are visited regardless of them
+	if crawler.args.Pagination.Enabled() && !isPaginationRequest(r) && !crawler.allowed.Match(r.URL.String()) {
+		r.Abort()
+		return
+	}
+
+	if !crawler.robotsRequest(r) {
+		return
+	}
+
+	// over the host cap or the max requests
+	if !crawler.budget.Request(r) {
+		r.Abort()
+		return
+	}
+
+	crawler.revisionRequest(r)
 	crawler.deps.Monitor.OnRequest(r)
 }
 
 // response dispatcher
 func (crawler *Dispatch) response(r *colly.Response) {
+	crawler.robotsHeaders(r)
+	crawler.budget.Response(r)
+	crawler.retry.Recovered(r.Request)
 	crawler.deps.Monitor.OnResponse(r)
 }
 
+// isLoggedOut returns true if the page is matched by the config.Auth logged-out marker
+func isLoggedOut(r *colly.Request) bool {
+	return r.Ctx != nil && r.Ctx.Get(config.LoggedOutCtx) == "true"
+}
+
 // scraped dispatcher
 func (crawler *Dispatch) scraped(r *colly.Response) {
 	crawler.deps.Monitor.OnScraped(r)
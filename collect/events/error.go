@@ -2,24 +2,66 @@ package events
 
 import (
 	"errors"
+	"fmt"
 	"github.com/editorpost/spider/collect/proxy"
 	"github.com/gocolly/colly/v2"
 	"log/slog"
 	"net/url"
 )
 
+// ErrBrowserAction is the error of the browser action failed on the page, e.g. the wrong selector.
+// The page is not retried, the failure is the extraction error.
+var ErrBrowserAction = errors.New("browser action failed")
+
+// BrowserActionError of the Config.BrowserActions step
+type BrowserActionError struct {
+	// Step index of the action
+	Step     int
+	Action   string
+	Selector string
+	Err      error
+}
+
+func (e *BrowserActionError) Error() string {
+	return fmt.Sprintf("browser action %d %s: %v", e.Step, e.Action, e.Err)
+}
+
+// Unwrap to the ErrBrowserAction and the error of the step
+func (e *BrowserActionError) Unwrap() []error {
+	return []error{ErrBrowserAction, e.Err}
+}
+
 // error logging
 func (crawler *Dispatch) error(resp *colly.Response, err error) {
 
+	crawler.budget.Response(resp)
+
+	// conditional request of the unchanged page
+	if crawler.notModified(resp) {
+		return
+	}
+
 	crawler.deps.Monitor.OnError(resp, err)
 
-	if errors.Is(err, proxy.ErrBadProxy) {
+	// put back to the queue with the backoff, the next proxy is used
+	if crawler.retry.Request(resp, err) {
+		return
+	}
 
-		// retry on error with new proxy candidate
-		if crawler.proxyRetry.Request(resp) {
-			return
-		}
+	// the page is loaded, but the browser action failed
+	var action *BrowserActionError
+	if errors.As(err, &action) {
+		slog.Warn("extraction error",
+			slog.String("error", err.Error()),
+			slog.String("url", resp.Request.URL.String()),
+			slog.Int("step", action.Step),
+			slog.String("action", action.Action),
+			slog.String("selector", action.Selector),
+		)
+		return
+	}
 
+	if errors.Is(err, proxy.ErrBadProxy) {
 		LogRespError("bad proxy", resp, err)
 		return
 	}
@@ -27,12 +69,6 @@ func (crawler *Dispatch) error(resp *colly.Response, err error) {
 	// catch *url.OnError
 	var urlErr *url.Error
 	if errors.As(err, &urlErr) {
-
-		// retry on error with new working proxy
-		if crawler.errorRetry.Request(resp) {
-			return
-		}
-
 		LogRespError("url error", resp, err)
 		return
 	}
@@ -44,6 +44,16 @@ func (crawler *Dispatch) extract() func(e *colly.HTMLElement) {
 			return
 		}
 
+		// meta robots noindex
+		if crawler.robotsNoIndex(doc) {
+			return
+		}
+
+		// login page instead of the content
+		if isLoggedOut(doc.Request) {
+			return
+		}
+
 		// check extraction limit
 		if crawler.IsExtractionLimitReached() {
 			slog.Info("extract: limit reached",
@@ -53,11 +63,18 @@ func (crawler *Dispatch) extract() func(e *colly.HTMLElement) {
 			return
 		}
 
+		// skip pages not changed since the previous run
+		rev, changed := crawler.revision(doc)
+		if !changed {
+			return
+		}
+
 		extracted := false
+		failed := false
 
 		// selected html selections matching the query
 		// might be empty if the query is not found
-		for _, selected := range crawler.selections(doc) {
+		for _, selected := range Selections(doc, crawler.args.ExtractSelector) {
 
 			ok, err := crawler.deps.Extractor.Extract(doc, selected)
 
@@ -68,6 +85,7 @@ func (crawler *Dispatch) extract() func(e *colly.HTMLElement) {
 					slog.String("url", doc.Request.URL.String()),
 					slog.String("title", doc.DOM.Find("title").Text()),
 				)
+				failed = true
 				continue
 			}
 
@@ -82,9 +100,15 @@ func (crawler *Dispatch) extract() func(e *colly.HTMLElement) {
 			// send metrics
 			crawler.deps.Monitor.OnExtract(doc.Response)
 			crawler.CountExtraction()
+			crawler.graph.Extract(doc.Request.URL.String())
 			extracted = true
 		}
 
+		// failed page is extracted again on the next run
+		if !failed {
+			crawler.revise(doc, rev)
+		}
+
 		if !extracted {
 			slog.Warn("no data extracted",
 				slog.String("url", doc.Request.URL.String()),
@@ -111,7 +135,7 @@ func (crawler *Dispatch) CountExtraction() {
 		// stop the queue
 		// existing requests will be processed
 		// catch them on extraction with IsExtractionLimitReached
-		crawler.queue.Stop()
+		crawler.budget.Spend(BudgetExtractLimit)
 	}
 }
 
@@ -121,39 +145,17 @@ func (crawler *Dispatch) IsExtractionLimitReached() bool {
 	return limit > 0 && count >= limit
 }
 
-// selections matching the query (with JS browse if Config.ExtractSelector is not found in GET response)
-func (crawler *Dispatch) selections(e *colly.HTMLElement) []*goquery.Selection {
-
-	var browser Browser
-
-	if crawler.args.UseBrowser {
-		browser = crawler.browser
-	}
-
-	return Selections(e, crawler.args.ExtractSelector, browser)
-}
-
-// Selections matching the query (with JS browse if Config.ExtractSelector is not found in GET response)
-func Selections(e *colly.HTMLElement, selector string, browser Browser) []*goquery.Selection {
+// Selections matching the query, the whole page for the "html" selector.
+// The page of the browser transport is already rendered.
+func Selections(e *colly.HTMLElement, selector string) []*goquery.Selection {
 
 	if selector == "html" {
 		return []*goquery.Selection{e.DOM}
 	}
 
-	var selection *goquery.Selection
-
-	if browser != nil {
-		var err error
-		if selection, err = browser.Browse(e.Request.URL.String()); err != nil {
-			return nil
-		}
-	} else {
-		selection = e.DOM.Find(selector)
-	}
-
 	var nodes []*goquery.Selection
 
-	selection.Each(func(i int, s *goquery.Selection) {
+	e.DOM.Find(selector).Each(func(i int, s *goquery.Selection) {
 		nodes = append(nodes, s)
 	})
 
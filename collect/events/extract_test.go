@@ -17,21 +17,21 @@ func TestSelections(t *testing.T) {
 	doc := tester.GetDocumentSelections(t, "../../tester/fixtures/cases/must_article_title.html", selector)
 
 	// parse DOM and get elements by selector
-	selections := events.Selections(doc, "html", nil)
+	selections := events.Selections(doc, "html")
 
 	// html is only one element
 	require.Len(t, selections, 1)
 
 	// h1 is only one element too
-	selections = events.Selections(doc, "h1", nil)
+	selections = events.Selections(doc, "h1")
 	require.Len(t, selections, 1)
 
 	// articles are 27 elements
-	selections = events.Selections(doc, "article", nil)
+	selections = events.Selections(doc, "article")
 	require.Len(t, selections, 27)
 
 	// but only 1 element with class ".node-article--full"
-	selections = events.Selections(doc, ".node-article--full", nil)
+	selections = events.Selections(doc, ".node-article--full")
 	require.Len(t, selections, 1)
 
 	// let's get article from the first selection
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// paginationPath matches listing pages, e.g. /page/2, /p/2
+	paginationPath = regexp.MustCompile(`/(page|p)/\d+/?$`)
+	// paginationParams are query params of listing pages, e.g. ?page=2
+	paginationParams = []string{"page", "p", "pg", "paged", "start", "offset"}
+)
+
+// newEntities compiles Config.ExtractURLs patterns
+func (crawler *Dispatch) newEntities() config.Patterns {
+
+	patterns, err := config.NewPatterns(crawler.args.ExtractURLs...)
+	if err != nil {
+		// validated on the extractor setup
+		slog.Error("extract urls", slog.String("error", err.Error()))
+		return nil
+	}
+
+	return patterns
+}
+
+// enqueue the link found on the page, one level deeper than the page
+func (crawler *Dispatch) enqueue(e *colly.HTMLElement, link string) error {
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+
+	ctx := colly.NewContext()
+	crawler.frontierClass(link, IsPagination(e, u)).Put(ctx)
+
+	return crawler.queue.AddRequest(&colly.Request{
+		URL:    u,
+		Method: http.MethodGet,
+		Depth:  e.Request.Depth + 1,
+		Ctx:    ctx,
+	})
+}
+
+// frontierClass of the link, entity pages are matching ExtractURLs
+func (crawler *Dispatch) frontierClass(link string, pagination bool) frontier.Class {
+
+	switch {
+	case crawler.entities.Match(link):
+		return frontier.Entity
+	case pagination:
+		return frontier.Pagination
+	}
+
+	return frontier.Other
+}
+
+// IsPagination detects pagination links by rel="next|prev", page path or query param
+func IsPagination(e *colly.HTMLElement, u *url.URL) bool {
+
+	for _, rel := range strings.Fields(strings.ToLower(e.Attr("rel"))) {
+		if rel == "next" || rel == "prev" {
+			return true
+		}
+	}
+
+	if paginationPath.MatchString(u.Path) {
+		return true
+	}
+
+	query := u.Query()
+	for _, param := range paginationParams {
+		if len(query.Get(param)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
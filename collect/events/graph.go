@@ -0,0 +1,73 @@
+package events
+
+import (
+	"github.com/editorpost/spider/collect/graph"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"strings"
+)
+
+const (
+	// LinkContent is the reason of the filtered link to the file, e.g. image or script
+	LinkContent = "content"
+	// LinkDomain is the reason of the filtered link out of the AllowedDomains
+	LinkDomain = "domain"
+	// LinkAllowed is the reason of the filtered link not matching AllowedURLs
+	LinkAllowed = "allowed"
+	// LinkDisallowed is the reason of the filtered link matching DisallowedURLs
+	LinkDisallowed = "disallowed"
+	// LinkNoFollow is the reason of the filtered nofollow link or the link of the nofollow page
+	LinkNoFollow = "nofollow"
+	// LinkDepth is the reason of the filtered link deeper than the Depth
+	LinkDepth = "depth"
+	// LinkTrap is the reason of the link of the crawler trap, e.g. infinite calendar
+	LinkTrap = "trap"
+	// LinkQueue is the reason of the link failed to be queued
+	LinkQueue = "queue"
+)
+
+// newGraph of the crawl links summarized by ExtractURLs and AllowedURLs, nil if disabled
+func (crawler *Dispatch) newGraph() *graph.Graph {
+
+	if !crawler.args.Graph.Enabled {
+		return nil
+	}
+
+	exprs := append(append([]string{}, crawler.args.ExtractURLs...), crawler.args.AllowedURLs...)
+
+	g, err := graph.NewGraph(crawler.args.Graph.MaxEdges, exprs...)
+	if err != nil {
+		// validated by Config.Normalize
+		slog.Error("graph url patterns", slog.String("error", err.Error()))
+		return nil
+	}
+
+	return g
+}
+
+// Graph of the crawl links, nil if disabled
+func (crawler *Dispatch) Graph() *graph.Graph {
+	return crawler.graph
+}
+
+// link of the page recorded to the graph, the reason is set for the filtered link
+func (crawler *Dispatch) link(e *colly.HTMLElement, target, status, reason string) {
+
+	if crawler.graph == nil {
+		return
+	}
+
+	crawler.graph.Add(&graph.Edge{
+		Source: e.Request.URL.String(),
+		Target: target,
+		Text:   strings.Join(strings.Fields(e.Text), " "),
+		Depth:  e.Request.Depth + 1,
+		Status: status,
+		Reason: reason,
+	})
+}
+
+// filtered link recorded to the graph
+func (crawler *Dispatch) filtered(e *colly.HTMLElement, target, reason string) {
+	crawler.link(e, target, graph.Filtered, reason)
+}
@@ -0,0 +1,155 @@
+package events
+
+import (
+	"errors"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/trap"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+const (
+	// PaginationPageCtx is the request context key of the listing page number
+	PaginationPageCtx = "PaginationPage"
+	// PaginationBaseCtx is the request context key of the first listing page url
+	PaginationBaseCtx = "PaginationBase"
+)
+
+// newListings compiles Pagination.ListingURLs patterns
+func (crawler *Dispatch) newListings() config.Patterns {
+
+	patterns, err := config.NewPatterns(crawler.args.Pagination.ListingURLs...)
+	if err != nil {
+		// validated on the config normalization
+		slog.Error("pagination listing urls", slog.String("error", err.Error()))
+		return nil
+	}
+
+	return patterns
+}
+
+// newAllowed compiles AllowedURLs patterns, checked by the dispatcher if pagination enabled
+func (crawler *Dispatch) newAllowed() config.Patterns {
+
+	patterns, err := config.NewPatterns(crawler.args.AllowedURLs...)
+	if err != nil {
+		slog.Error("allowed urls", slog.String("error", err.Error()))
+		return nil
+	}
+
+	return patterns
+}
+
+// paginate enqueues the next page of the listing with the same depth
+func (crawler *Dispatch) paginate(e *colly.HTMLElement) {
+
+	if isLoggedOut(e.Request) {
+		return
+	}
+
+	page, base, ok := crawler.listingPage(e.Request)
+	if !ok || page >= crawler.args.Pagination.Max() {
+		return
+	}
+
+	link, ok := crawler.nextPage(e, base, page+1)
+	if !ok {
+		return
+	}
+
+	// tracking params, fragments, etc.
+	link = crawler.canonicalLink(link)
+
+	// same page, e.g. the last page links to itself
+	if link == crawler.canonicalLink(e.Request.URL.String()) {
+		return
+	}
+
+	// allowed urls are not applied, but the crawl scope and denied sections are
+	if !crawler.args.Domains().MatchURL(link) || crawler.isDisallowed(link) {
+		return
+	}
+
+	// the crawler traps are logged by the queue
+	if err := crawler.enqueuePage(e, link, base, page+1); err != nil && !errors.Is(err, trap.ErrTrap) {
+		slog.Warn("crawler queue", slog.String("error", err.Error()))
+	}
+}
+
+// listingPage returns the page number and the first page url of the listing,
+// false if the page is not a listing.
+func (crawler *Dispatch) listingPage(r *colly.Request) (int, string, bool) {
+
+	// reached through pagination
+	if page, err := strconv.Atoi(r.Ctx.Get(PaginationPageCtx)); err == nil {
+		return page, r.Ctx.Get(PaginationBaseCtx), true
+	}
+
+	uri := r.URL.String()
+
+	if len(crawler.listings) > 0 {
+		return 1, uri, crawler.listings.Match(uri)
+	}
+
+	uri = crawler.canonicalLink(uri)
+
+	return 1, uri, slices.ContainsFunc(crawler.args.StartURLs, func(startURL string) bool {
+		return crawler.canonicalLink(startURL) == uri
+	})
+}
+
+// nextPage link by the selector, the url template is used if the element has no href
+func (crawler *Dispatch) nextPage(e *colly.HTMLElement, base string, n int) (string, bool) {
+
+	pagination := crawler.args.Pagination
+
+	if len(pagination.Selector) > 0 {
+
+		next := e.DOM.Find(pagination.Selector).First()
+		if next.Length() == 0 {
+			return "", false
+		}
+
+		if href, _ := next.Attr("href"); len(href) > 0 && href[0] != '#' {
+			return e.Request.AbsoluteURL(href), true
+		}
+	}
+
+	if len(pagination.URLTemplate) == 0 {
+		return "", false
+	}
+
+	link := e.Request.AbsoluteURL(pagination.URL(base, n))
+
+	return link, len(link) > 0
+}
+
+// enqueuePage of the listing with the depth of the first page
+func (crawler *Dispatch) enqueuePage(e *colly.HTMLElement, link, base string, page int) error {
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+
+	ctx := colly.NewContext()
+	ctx.Put(PaginationPageCtx, strconv.Itoa(page))
+	ctx.Put(PaginationBaseCtx, base)
+	crawler.frontierClass(link, true).Put(ctx)
+
+	return crawler.queue.AddRequest(&colly.Request{
+		URL:    u,
+		Method: http.MethodGet,
+		Depth:  e.Request.Depth,
+		Ctx:    ctx,
+	})
+}
+
+// isPaginationRequest returns true for requests queued by the pagination
+func isPaginationRequest(r *colly.Request) bool {
+	return len(r.Ctx.Get(PaginationPageCtx)) > 0
+}
@@ -1,96 +1,250 @@
 package events
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/proxy"
 	"github.com/gocolly/colly/v2"
+	"io"
 	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
 	"strconv"
 	"sync"
-	"sync/atomic"
+	"time"
 )
 
 const (
-	// BadProxyRetries is the number of retries for request errors from colly.onError handler.
-	BadProxyRetries = 15
-	// ResponseRetries is the number of retries for response errors from crawler.visit handler.
-	// it handler http status codes, anti-scraping, captcha, etc.
-	ResponseRetries = 3
 	// RetryCountCtx is the context key for the retry count
 	RetryCountCtx = "RequestRetriesCount"
+
+	// RetryRecovered is the outcome of the url succeeded after the retries
+	RetryRecovered = "recovered"
+	// RetryExhausted is the outcome of the url failed by all attempts
+	RetryExhausted = "exhausted"
+	// RetryBudget is the outcome of the url not retried since the host budget is spent
+	RetryBudget = "budget"
+	// RetryAfterTooLong is the outcome of the url not retried since Retry-After is longer than the max delay
+	RetryAfterTooLong = "retry_after"
+	// RetryNotRetryable is the outcome of the url failed by the status or error not retried
+	RetryNotRetryable = "not_retryable"
+	// RetryQueueFailed is the outcome of the url not put back to the queue
+	RetryQueueFailed = "queue_failed"
 )
 
+// Retry puts the failed requests back to the queue by the config.RetryPolicy:
+// retryable statuses and error classes, exponential backoff with jitter, Retry-After of 429 and 503
+// and the retry budget per host. The attempts of the url are kept in the request context.
 type Retry struct {
-	limit  uint16
-	mute   *sync.Mutex
-	_count map[string]uint16
+	policy  *config.RetryPolicy
+	queue   Queue
+	monitor config.Metrics
+	mute    *sync.Mutex
+	// retries spent per host
+	hosts map[string]int
 }
 
-func NewRetry(limit uint16) *Retry {
+func NewRetry(policy *config.RetryPolicy, queue Queue, monitor config.Metrics) *Retry {
 	return &Retry{
-		limit:  limit,
-		mute:   &sync.Mutex{},
-		_count: make(map[string]uint16),
+		policy:  policy,
+		queue:   queue,
+		monitor: monitor,
+		mute:    &sync.Mutex{},
+		hosts:   make(map[string]int),
 	}
 }
 
-func (r *Retry) Request(resp *colly.Response) bool {
+// Request puts the failed request back to the queue after the backoff,
+// returns false and records the outcome if the request is not retried
+func (r *Retry) Request(resp *colly.Response, err error) bool {
+
+	class, retryable := r.retryable(resp, err)
+	if !retryable {
+		r.outcome(resp.Request, RetryNotRetryable)
+		return false
+	}
 
-	if r.Limited(resp) {
+	attempt := Attempts(resp.Request) + 1
+	if attempt > r.policy.MaxAttempts(class) {
+		r.outcome(resp.Request, RetryExhausted)
 		return false
 	}
 
-	r.inc(resp.Request.URL.String())
+	delay := r.policy.Backoff(attempt)
+
+	// the server asks to wait, but not longer than the policy allows
+	if after, ok := RetryAfter(resp); ok {
+		if after > r.policy.MaxDelay.Duration() {
+			r.outcome(resp.Request, RetryAfterTooLong)
+			return false
+		}
+		delay = max(delay, after)
+	}
 
-	tries := &atomic.Int32{}
+	if !r.spend(resp.Request.URL.Hostname()) {
+		r.outcome(resp.Request, RetryBudget)
+		return false
+	}
 
-	for {
+	req := CopyRequest(resp.Request, resp.Request.Ctx)
+	req.Ctx.Put(RetryCountCtx, strconv.Itoa(attempt))
 
-		tries.Add(1)
+	if err = r.queue.Delay(req, delay); err != nil {
+		slog.Warn("retry failed",
+			slog.String("url", resp.Request.URL.String()),
+			slog.String("err", err.Error()),
+		)
+		r.outcome(resp.Request, RetryQueueFailed)
+		return false
+	}
 
-		// add count to request context
-		resp.Request.Ctx.Put(RetryCountCtx, strconv.Itoa(int(tries.Load())))
+	slog.Debug("retry delayed",
+		slog.String("url", resp.Request.URL.String()),
+		slog.String("class", class),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+	)
 
-		// do request (all event lifecycle starts again)
-		err := resp.Request.Retry()
+	return true
+}
 
-		// not actual response error, since request might be executed in async mode
-		if err == nil {
-			return true
-		}
+// Recovered records the outcome of the retried request succeeded
+func (r *Retry) Recovered(req *colly.Request) {
 
-		if tries.Load() >= int32(r.limit) {
-			slog.Debug("retry failed",
-				slog.String("url", resp.Request.URL.String()),
-				slog.String("err", err.Error()),
-			)
-			// limit reached
-			return false
-		}
+	if Attempts(req) == 0 {
+		return
 	}
 
+	r.outcome(req, RetryRecovered)
 }
 
-func (r *Retry) Limited(resp *colly.Response) bool {
+// retryable returns the class of the failure and true if the policy retries it
+func (r *Retry) retryable(resp *colly.Response, err error) (string, bool) {
 
-	url := resp.Request.URL.String()
-	count := r.Count(url)
+	// the page is loaded, the same action fails again
+	if errors.Is(err, ErrBrowserAction) {
+		return "", false
+	}
+
+	if errors.Is(err, proxy.ErrBadProxy) {
+		return config.RetryProxy, r.policy.RetryError(config.RetryProxy)
+	}
+
+	// http status error
+	if resp.StatusCode >= http.StatusBadRequest {
+		return strconv.Itoa(resp.StatusCode), r.policy.RetryStatus(resp.StatusCode)
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return config.RetryTimeout, r.policy.RetryError(config.RetryTimeout)
+	}
 
-	return count > r.limit
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return config.RetryNetwork, r.policy.RetryError(config.RetryNetwork)
+	}
+
+	return "", false
 }
 
-func (r *Retry) Count(url string) uint16 {
+// spend the retry of the host budget, false if the budget is spent
+func (r *Retry) spend(host string) bool {
 
 	r.mute.Lock()
 	defer r.mute.Unlock()
 
-	if _, ok := r._count[url]; !ok {
-		r._count[url] = 0
+	if r.hosts[host] >= r.policy.HostBudget {
+		return false
 	}
 
-	return r._count[url]
+	r.hosts[host]++
+
+	return true
 }
 
-func (r *Retry) inc(url string) {
-	r.mute.Lock()
-	defer r.mute.Unlock()
-	r._count[url]++
+// outcome of the url after the retries, the first failure without retries is not recorded
+func (r *Retry) outcome(req *colly.Request, outcome string) {
+
+	attempts := Attempts(req)
+	if attempts == 0 && outcome == RetryNotRetryable {
+		return
+	}
+
+	r.monitor.OnRetryOutcome(req.URL.String(), outcome, attempts)
+
+	slog.Info("retry outcome",
+		slog.String("url", req.URL.String()),
+		slog.String("outcome", outcome),
+		slog.Int("attempts", attempts),
+	)
+}
+
+// Attempts of the request retried, zero for the first request
+func Attempts(req *colly.Request) int {
+
+	if req.Ctx == nil {
+		return 0
+	}
+
+	attempts, _ := strconv.Atoi(req.Ctx.Get(RetryCountCtx))
+
+	return attempts
+}
+
+// RetryAfter of the 429 and 503 response in seconds or the http date
+func RetryAfter(resp *colly.Response) (time.Duration, bool) {
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	if resp.Headers == nil {
+		return 0, false
+	}
+
+	value := resp.Headers.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// CopyRequest of the url, the method, the depth, the body and the headers with the context,
+// the body read by the previous attempt is rewound
+func CopyRequest(r *colly.Request, ctx *colly.Context) *colly.Request {
+
+	req := &colly.Request{
+		URL:    r.URL,
+		Method: r.Method,
+		Depth:  r.Depth,
+		Ctx:    ctx,
+	}
+
+	if r.Headers != nil {
+		headers := r.Headers.Clone()
+		req.Headers = &headers
+	}
+
+	if seeker, ok := r.Body.(io.ReadSeeker); ok {
+		if _, err := seeker.Seek(0, io.SeekStart); err == nil {
+			if body, err := io.ReadAll(seeker); err == nil {
+				req.Body = bytes.NewReader(body)
+			}
+		}
+	}
+
+	return req
 }
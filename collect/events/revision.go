@@ -0,0 +1,102 @@
+package events
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"net/http"
+)
+
+// revisionRequest sends validators of the previous revision, if the page is not modified,
+// the 304 response is handled by notModified
+func (crawler *Dispatch) revisionRequest(r *colly.Request) {
+
+	if crawler.deps.Revisions == nil {
+		return
+	}
+
+	rev := crawler.deps.Revisions.Revision(r.URL.String())
+	if rev == nil || !rev.Conditional() {
+		return
+	}
+
+	if len(rev.ETag) > 0 {
+		r.Headers.Set("If-None-Match", rev.ETag)
+	}
+
+	if len(rev.LastModified) > 0 {
+		r.Headers.Set("If-Modified-Since", rev.LastModified)
+	}
+}
+
+// notModified returns true for 304 response of the conditional request
+func (crawler *Dispatch) notModified(resp *colly.Response) bool {
+
+	if crawler.deps.Revisions == nil || resp.StatusCode != http.StatusNotModified {
+		return false
+	}
+
+	crawler.deps.Monitor.OnNotModified(resp)
+	slog.Info("not modified", slog.String("url", resp.Request.URL.String()))
+
+	return true
+}
+
+// revision of the page, returns false if the page is not changed since the previous run.
+// The changed page is marked in the request context to extract it again and link to the previous payload.
+func (crawler *Dispatch) revision(doc *colly.HTMLElement) (*config.Revision, bool) {
+
+	if crawler.deps.Revisions == nil {
+		return nil, true
+	}
+
+	uri := doc.Request.URL.String()
+	sum := sha256.Sum256(doc.Response.Body)
+	rev := &config.Revision{Hash: hex.EncodeToString(sum[:])}
+
+	// validators are kept for entity pages only,
+	// since not modified response has no links to follow
+	if len(crawler.args.ExtractURLs) > 0 {
+		rev.ETag = doc.Response.Headers.Get("ETag")
+		rev.LastModified = doc.Response.Headers.Get("Last-Modified")
+	}
+
+	prev := crawler.deps.Revisions.Revision(uri)
+	if prev == nil {
+		return rev, true
+	}
+
+	rev.PayloadID = prev.PayloadID
+
+	if prev.Hash == rev.Hash {
+		// validators might be changed by the server
+		crawler.deps.Revisions.SetRevision(uri, rev)
+		crawler.deps.Monitor.OnNotModified(doc.Response)
+		slog.Info("not changed", slog.String("url", uri))
+		return nil, false
+	}
+
+	doc.Request.Ctx.Put(pipe.RevisedCtx, "true")
+	if len(prev.PayloadID) > 0 {
+		doc.Request.Ctx.Put(pipe.PreviousIDCtx, prev.PayloadID)
+	}
+
+	return rev, true
+}
+
+// revise saves the page revision with the last extracted payload id
+func (crawler *Dispatch) revise(doc *colly.HTMLElement, rev *config.Revision) {
+
+	if crawler.deps.Revisions == nil || rev == nil {
+		return
+	}
+
+	if payloadID := doc.Request.Ctx.Get(pipe.PayloadIDCtx); len(payloadID) > 0 {
+		rev.PayloadID = payloadID
+	}
+
+	crawler.deps.Revisions.SetRevision(doc.Request.URL.String(), rev)
+}
@@ -0,0 +1,139 @@
+package events
+
+import (
+	"context"
+	"github.com/editorpost/spider/collect/robots"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// newRobots creates robots.txt policy if Config.RespectRobots enabled
+func (crawler *Dispatch) newRobots() *robots.Robots {
+
+	if !crawler.args.RespectRobots {
+		return nil
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	if crawler.deps.RoundTripper != nil {
+		client.Transport = crawler.deps.RoundTripper
+	}
+
+	return robots.NewRobots(client, crawler.args.UserAgent)
+}
+
+// robotsRequest aborts requests disallowed by robots.txt
+// and waits for the Crawl-delay of the host.
+// Returns false if the request aborted.
+func (crawler *Dispatch) robotsRequest(r *colly.Request) bool {
+
+	if crawler.robots == nil {
+		return true
+	}
+
+	if !crawler.robots.Allowed(r.URL) {
+		crawler.robotsSkip(r, robots.Disallow)
+		r.Abort()
+		return false
+	}
+
+	// the wait is cancelled with the run, the request is not sent
+	if err := crawler.robots.Wait(crawler.context(), r.URL); err != nil {
+		r.Abort()
+		return false
+	}
+
+	return true
+}
+
+// context of the run, background if the collector is not set up
+func (crawler *Dispatch) context() context.Context {
+
+	if crawler.collector == nil || crawler.collector.Context == nil {
+		return context.Background()
+	}
+
+	return crawler.collector.Context
+}
+
+// robotsHeaders reads X-Robots-Tag header directives to the request context
+func (crawler *Dispatch) robotsHeaders(r *colly.Response) {
+
+	if crawler.robots == nil || r.Headers == nil {
+		return
+	}
+
+	crawler.robotsDirectives(r.Request, robots.ParseDirectives(r.Headers.Values("X-Robots-Tag")...))
+}
+
+// robotsMeta reads meta robots directives to the request context.
+// Must be registered before visit and extract handlers.
+func (crawler *Dispatch) robotsMeta(e *colly.HTMLElement) {
+
+	if crawler.robots == nil {
+		return
+	}
+
+	values := make([]string, 0)
+	e.ForEach(`meta[name]`, func(_ int, meta *colly.HTMLElement) {
+		if strings.EqualFold(meta.Attr("name"), "robots") {
+			values = append(values, meta.Attr("content"))
+		}
+	})
+
+	crawler.robotsDirectives(e.Request, robots.ParseDirectives(values...))
+}
+
+func (crawler *Dispatch) robotsDirectives(r *colly.Request, d robots.Directives) {
+
+	if d.NoIndex && !isRobotsCtx(r, robots.NoIndexCtx) {
+		r.Ctx.Put(robots.NoIndexCtx, "true")
+		crawler.robotsSkip(r, robots.NoIndex)
+	}
+
+	if d.NoFollow && !isRobotsCtx(r, robots.NoFollowCtx) {
+		r.Ctx.Put(robots.NoFollowCtx, "true")
+		crawler.robotsSkip(r, robots.NoFollow)
+	}
+}
+
+// robotsNoFollow checks if the link must not be followed
+func (crawler *Dispatch) robotsNoFollow(e *colly.HTMLElement) bool {
+
+	if crawler.robots == nil {
+		return false
+	}
+
+	if isRobotsCtx(e.Request, robots.NoFollowCtx) {
+		return true
+	}
+
+	if robots.IsNoFollowLink(e.Attr("rel")) {
+		crawler.deps.Monitor.OnRobots(e.Request, robots.NoFollowLink)
+		return true
+	}
+
+	return false
+}
+
+// robotsNoIndex checks if the page must not be extracted
+func (crawler *Dispatch) robotsNoIndex(e *colly.HTMLElement) bool {
+	return crawler.robots != nil && isRobotsCtx(e.Request, robots.NoIndexCtx)
+}
+
+func (crawler *Dispatch) robotsSkip(r *colly.Request, rule string) {
+
+	crawler.deps.Monitor.OnRobots(r, rule)
+
+	slog.Info("robots: skipped",
+		slog.String("rule", rule),
+		slog.String("url", r.URL.String()),
+	)
+}
+
+func isRobotsCtx(r *colly.Request, key string) bool {
+	return r.Ctx != nil && r.Ctx.Get(key) == "true"
+}
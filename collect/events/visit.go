@@ -1,7 +1,10 @@
 package events
 
 import (
+	"errors"
 	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/graph"
+	"github.com/editorpost/spider/collect/trap"
 	"github.com/gocolly/colly/v2"
 	"log/slog"
 	"strings"
@@ -12,6 +15,11 @@ func (crawler *Dispatch) visit() func(e *colly.HTMLElement) {
 
 	return func(e *colly.HTMLElement) {
 
+		// links of the login page are not followed
+		if isLoggedOut(e.Request) {
+			return
+		}
+
 		// absolute url
 		link := e.Request.AbsoluteURL(e.Attr("href"))
 
@@ -20,14 +28,55 @@ func (crawler *Dispatch) visit() func(e *colly.HTMLElement) {
 			return
 		}
 
+		// tracking params, fragments, etc.
+		link = crawler.canonicalLink(link)
+
 		// skip images, scripts, etc.
 		if !config.ContentLikeURL(link) {
+			crawler.filtered(e, link, LinkContent)
+			return
+		}
+
+		// skip links out of the crawl scope
+		if !crawler.args.Domains().MatchURL(link) {
+			crawler.filtered(e, link, LinkDomain)
+			return
+		}
+
+		// skip links not allowed, filtered by the collector anyway
+		if len(crawler.allowed) > 0 && !crawler.allowed.Match(link) {
+			crawler.filtered(e, link, LinkAllowed)
+			return
+		}
+
+		// skip denied sections, e.g. tags or search
+		if crawler.isDisallowed(link) {
+			crawler.filtered(e, link, LinkDisallowed)
+			return
+		}
+
+		// skip nofollow pages and links
+		if crawler.robotsNoFollow(e) {
+			crawler.filtered(e, link, LinkNoFollow)
 			return
 		}
 
-		// visit the link
-		if err := crawler.queue.AddURL(link); err != nil {
+		// skip links deeper than the max depth, filtered by the collector anyway
+		if crawler.args.Depth > 0 && e.Request.Depth+1 > crawler.args.Depth {
+			crawler.filtered(e, link, LinkDepth)
+			return
+		}
+
+		// visit the link, the crawler traps are logged by the queue
+		if err := crawler.enqueue(e, link); errors.Is(err, trap.ErrTrap) {
+			crawler.filtered(e, link, LinkTrap)
+			return
+		} else if err != nil {
+			crawler.filtered(e, link, LinkQueue)
 			slog.Warn("crawler queue", slog.String("error", err.Error()))
+			return
 		}
+
+		crawler.link(e, link, graph.Queued, "")
 	}
 }
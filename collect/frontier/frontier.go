@@ -0,0 +1,239 @@
+// Package frontier provides the priority ordering of the crawl queue.
+package frontier
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"github.com/gocolly/colly/v2"
+	"strconv"
+	"sync"
+)
+
+const (
+	// BFS fetches shallow pages first in discovery order
+	BFS Policy = "bfs"
+	// DFS fetches the latest discovered and deepest pages first
+	DFS Policy = "dfs"
+	// EntityFirst fetches entity pages, then pagination, then other pages, shallow pages first
+	EntityFirst Policy = "entity-first"
+
+	// DefaultPolicy spends the budget on entity pages first
+	DefaultPolicy = EntityFirst
+
+	// ClassCtx is the request context key of the request Class
+	ClassCtx = "FrontierClass"
+)
+
+const (
+	// Entity page matching ExtractURLs
+	Entity Class = iota + 1
+	// Pagination page of the listing
+	Pagination
+	// Other allowed page, e.g. hub or listing
+	Other
+)
+
+type (
+	// Policy is the ordering of the frontier
+	Policy string
+
+	// Class of the request, lower is fetched first by EntityFirst policy
+	Class int
+
+	// Entry is the queued request with the ordering keys
+	Entry struct {
+		// Request is the serialized colly request
+		Request []byte
+		Class   Class
+		Depth   int
+		// Key is the optional identifier of the entry
+		Key string
+		seq uint64
+	}
+
+	// Heap orders entries by the Policy, not safe for concurrent use
+	Heap struct {
+		policy  Policy
+		entries []*Entry
+		seq     uint64
+	}
+
+	// Frontier is the in-memory colly queue.Storage ordered by the Policy
+	Frontier struct {
+		// MaxSize is the capacity, new requests are discarded if reached
+		MaxSize int
+		heap    *Heap
+		mute    *sync.Mutex
+	}
+
+	// serializedRequest is the subset of colly serialized request fields
+	serializedRequest struct {
+		Depth int
+		Ctx   map[string]any
+	}
+)
+
+// Valid returns false for unknown policies
+func (p Policy) Valid() bool {
+	return p == BFS || p == DFS || p == EntityFirst
+}
+
+// Put the class to the request context
+func (c Class) Put(ctx *colly.Context) {
+	ctx.Put(ClassCtx, strconv.Itoa(int(c)))
+}
+
+// NewEntry from colly serialized request
+func NewEntry(r []byte) (*Entry, error) {
+
+	req := &serializedRequest{}
+	if err := json.Unmarshal(r, req); err != nil {
+		return nil, fmt.Errorf("frontier request: %w", err)
+	}
+
+	return &Entry{
+		Request: r,
+		Class:   ParseClass(req.Ctx[ClassCtx]),
+		Depth:   req.Depth,
+	}, nil
+}
+
+// ParseClass from the serialized context value, Other if not set
+func ParseClass(value any) Class {
+
+	s, _ := value.(string)
+
+	class, err := strconv.Atoi(s)
+	if err != nil || class < int(Entity) || class > int(Other) {
+		return Other
+	}
+
+	return Class(class)
+}
+
+func NewHeap(policy Policy) *Heap {
+
+	if !policy.Valid() {
+		policy = DefaultPolicy
+	}
+
+	return &Heap{
+		policy:  policy,
+		entries: make([]*Entry, 0),
+	}
+}
+
+// Push the entry, entries of the same priority are ordered by push sequence
+func (h *Heap) Push(e *Entry) {
+	h.seq++
+	e.seq = h.seq
+	heap.Push((*entries)(h), e)
+}
+
+// Pop the highest priority entry or nil if empty
+func (h *Heap) Pop() *Entry {
+
+	if len(h.entries) == 0 {
+		return nil
+	}
+
+	return heap.Pop((*entries)(h)).(*Entry)
+}
+
+func (h *Heap) Len() int {
+	return len(h.entries)
+}
+
+// less returns true if the entry a must be fetched before b
+func (h *Heap) less(a, b *Entry) bool {
+
+	switch h.policy {
+	case DFS:
+		if a.Depth != b.Depth {
+			return a.Depth > b.Depth
+		}
+		return a.seq > b.seq
+	case EntityFirst:
+		if a.Class != b.Class {
+			return a.Class < b.Class
+		}
+	}
+
+	if a.Depth != b.Depth {
+		return a.Depth < b.Depth
+	}
+
+	return a.seq < b.seq
+}
+
+// entries implements heap.Interface over the Heap
+type entries Heap
+
+func (h *entries) Len() int           { return len(h.entries) }
+func (h *entries) Less(i, j int) bool { return (*Heap)(h).less(h.entries[i], h.entries[j]) }
+func (h *entries) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *entries) Push(x any)         { h.entries = append(h.entries, x.(*Entry)) }
+
+func (h *entries) Pop() any {
+	n := len(h.entries)
+	e := h.entries[n-1]
+	h.entries[n-1] = nil
+	h.entries = h.entries[:n-1]
+	return e
+}
+
+// NewFrontier creates in-memory queue storage ordered by the policy
+func NewFrontier(policy Policy, maxSize int) *Frontier {
+	return &Frontier{
+		MaxSize: maxSize,
+		heap:    NewHeap(policy),
+		mute:    &sync.Mutex{},
+	}
+}
+
+// Init implements queue.Storage
+func (f *Frontier) Init() error {
+	return nil
+}
+
+// AddRequest implements queue.Storage
+func (f *Frontier) AddRequest(r []byte) error {
+
+	e, err := NewEntry(r)
+	if err != nil {
+		return err
+	}
+
+	f.mute.Lock()
+	defer f.mute.Unlock()
+
+	if f.MaxSize > 0 && f.heap.Len() >= f.MaxSize {
+		return colly.ErrQueueFull
+	}
+
+	f.heap.Push(e)
+
+	return nil
+}
+
+// GetRequest implements queue.Storage
+func (f *Frontier) GetRequest() ([]byte, error) {
+
+	f.mute.Lock()
+	defer f.mute.Unlock()
+
+	e := f.heap.Pop()
+	if e == nil {
+		return nil, nil
+	}
+
+	return e.Request, nil
+}
+
+// QueueSize implements queue.Storage
+func (f *Frontier) QueueSize() (int, error) {
+	f.mute.Lock()
+	defer f.mute.Unlock()
+	return f.heap.Len(), nil
+}
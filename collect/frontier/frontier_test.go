@@ -0,0 +1,85 @@
+package frontier_test
+
+import (
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/gocolly/colly/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+)
+
+func TestFrontier_Policies(t *testing.T) {
+
+	// discovery order
+	requests := []struct {
+		uri   string
+		class frontier.Class
+		depth int
+	}{
+		{"/hub", frontier.Other, 1},
+		{"/news/1.html", frontier.Entity, 2},
+		{"/news?page=2", frontier.Pagination, 1},
+		{"/news/2.html", frontier.Entity, 1},
+		{"/about", frontier.Other, 2},
+	}
+
+	tests := []struct {
+		policy   frontier.Policy
+		expected []string
+	}{
+		{frontier.BFS, []string{"/hub", "/news?page=2", "/news/2.html", "/news/1.html", "/about"}},
+		{frontier.DFS, []string{"/about", "/news/1.html", "/news/2.html", "/news?page=2", "/hub"}},
+		{frontier.EntityFirst, []string{"/news/2.html", "/news/1.html", "/news?page=2", "/hub", "/about"}},
+	}
+
+	c := colly.NewCollector()
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+
+			f := frontier.NewFrontier(tt.policy, 0)
+
+			for _, r := range requests {
+				u, err := url.Parse("https://example.com" + r.uri)
+				require.NoError(t, err)
+
+				ctx := colly.NewContext()
+				r.class.Put(ctx)
+
+				b, err := (&colly.Request{URL: u, Method: "GET", Depth: r.depth, Ctx: ctx}).Marshal()
+				require.NoError(t, err)
+				require.NoError(t, f.AddRequest(b))
+			}
+
+			order := make([]string, 0)
+			for size, _ := f.QueueSize(); size > 0; size, _ = f.QueueSize() {
+				b, err := f.GetRequest()
+				require.NoError(t, err)
+				req, err := c.UnmarshalRequest(b)
+				require.NoError(t, err)
+				order = append(order, req.URL.RequestURI())
+			}
+
+			assert.Equal(t, tt.expected, order)
+		})
+	}
+}
+
+func TestFrontier_MaxSize(t *testing.T) {
+
+	f := frontier.NewFrontier(frontier.BFS, 1)
+	require.NoError(t, f.AddRequest([]byte(`{"URL":"https://example.com"}`)))
+	assert.ErrorIs(t, f.AddRequest([]byte(`{"URL":"https://example.com/a"}`)), colly.ErrQueueFull)
+
+	// no class in context
+	e, err := frontier.NewEntry([]byte(`{"URL":"https://example.com","Depth":2}`))
+	require.NoError(t, err)
+	assert.Equal(t, frontier.Other, e.Class)
+	assert.Equal(t, 2, e.Depth)
+}
+
+func TestPolicy_Valid(t *testing.T) {
+	assert.True(t, frontier.EntityFirst.Valid())
+	assert.False(t, frontier.Policy("random").Valid())
+}
@@ -0,0 +1,33 @@
+package collect
+
+import (
+	"fmt"
+	"github.com/editorpost/spider/collect/graph"
+	"log/slog"
+	"time"
+)
+
+// exportGraph of the run links to the graph storage, the files are named by the run start time
+func (crawler *Crawler) exportGraph(started time.Time) error {
+
+	links := crawler.dispatch.Graph()
+	if links == nil {
+		return nil
+	}
+
+	if crawler.deps.Graph == nil {
+		slog.Warn("graph: storage is not set, export skipped")
+		return nil
+	}
+
+	edges := links.Edges()
+	name := "links-" + started.UTC().Format("20060102150405")
+
+	if err := graph.Export(edges, crawler.deps.Graph, name, crawler.args.Graph.Formats); err != nil {
+		return fmt.Errorf("graph export: %w", err)
+	}
+
+	slog.Info("graph exported", slog.String("name", name), slog.Int("edges", len(edges)))
+
+	return nil
+}
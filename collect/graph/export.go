@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/editorpost/spider/collect/config"
+	"io"
+	"strconv"
+)
+
+// Export the edges to the storage in the formats, the file is named by the name and the format extension,
+// e.g. links.jsonl, links.graphml and links.dot
+func Export(edges []Edge, storage config.GraphStorage, name string, formats []string) error {
+
+	for _, format := range formats {
+
+		buf := &bytes.Buffer{}
+
+		if err := Write(buf, edges, format); err != nil {
+			return err
+		}
+
+		filename := name + "." + format
+		if err := storage.Save(buf.Bytes(), filename); err != nil {
+			return fmt.Errorf("graph file %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// Write the edges in the format: jsonl, graphml or dot
+func Write(w io.Writer, edges []Edge, format string) error {
+
+	switch format {
+	case config.GraphJSONL:
+		return WriteJSONL(w, edges)
+	case config.GraphML:
+		return WriteGraphML(w, edges)
+	case config.GraphDOT:
+		return WriteDOT(w, edges)
+	}
+
+	return fmt.Errorf("graph format %q is unknown", format)
+}
+
+// WriteJSONL writes the edge per line
+func WriteJSONL(w io.Writer, edges []Edge) error {
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for _, edge := range edges {
+		if err := enc.Encode(edge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteGraphML writes the directed graph, the node id is the url
+func WriteGraphML(w io.Writer, edges []Edge) error {
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	buf.WriteString(`  <key id="text" for="edge" attr.name="text" attr.type="string"/>` + "\n")
+	buf.WriteString(`  <key id="depth" for="edge" attr.name="depth" attr.type="int"/>` + "\n")
+	buf.WriteString(`  <key id="status" for="edge" attr.name="status" attr.type="string"/>` + "\n")
+	buf.WriteString(`  <key id="reason" for="edge" attr.name="reason" attr.type="string"/>` + "\n")
+	buf.WriteString(`  <graph id="crawl" edgedefault="directed">` + "\n")
+
+	for _, node := range nodes(edges) {
+		_, _ = fmt.Fprintf(buf, "    <node id=\"%s\"/>\n", escapeXML(node))
+	}
+
+	for i, edge := range edges {
+		_, _ = fmt.Fprintf(buf, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, escapeXML(edge.Source), escapeXML(edge.Target))
+		_, _ = fmt.Fprintf(buf, "      <data key=\"text\">%s</data>\n", escapeXML(edge.Text))
+		_, _ = fmt.Fprintf(buf, "      <data key=\"depth\">%d</data>\n", edge.Depth)
+		_, _ = fmt.Fprintf(buf, "      <data key=\"status\">%s</data>\n", edge.Status)
+		if len(edge.Reason) > 0 {
+			_, _ = fmt.Fprintf(buf, "      <data key=\"reason\">%s</data>\n", edge.Reason)
+		}
+		buf.WriteString("    </edge>\n")
+	}
+
+	buf.WriteString("  </graph>\n</graphml>\n")
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// WriteDOT writes the Graphviz digraph, the filtered links are dashed
+func WriteDOT(w io.Writer, edges []Edge) error {
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("digraph crawl {\n")
+
+	for _, node := range nodes(edges) {
+		_, _ = fmt.Fprintf(buf, "  %s;\n", strconv.Quote(node))
+	}
+
+	for _, edge := range edges {
+
+		style := "solid"
+		if edge.Status == Filtered {
+			style = "dashed"
+		}
+
+		_, _ = fmt.Fprintf(buf, "  %s -> %s [label=%s, depth=%d, status=%s, style=%s];\n",
+			strconv.Quote(edge.Source),
+			strconv.Quote(edge.Target),
+			strconv.Quote(edge.Text),
+			edge.Depth,
+			edge.Status,
+			style,
+		)
+	}
+
+	buf.WriteString("}\n")
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// nodes of the edges in the order of appearance
+func nodes(edges []Edge) []string {
+
+	seen := make(map[string]bool)
+	list := make([]string, 0)
+
+	for _, edge := range edges {
+		for _, node := range []string{edge.Source, edge.Target} {
+			if !seen[node] {
+				seen[node] = true
+				list = append(list, node)
+			}
+		}
+	}
+
+	return list
+}
+
+// escapeXML text and attribute value
+func escapeXML(s string) string {
+	buf := &bytes.Buffer{}
+	_ = xml.EscapeText(buf, []byte(s))
+	return buf.String()
+}
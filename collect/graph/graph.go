@@ -0,0 +1,259 @@
+// Package graph records the links of the crawl: the page linked to the target url,
+// exports the edges and summarizes the degrees by the url patterns.
+package graph
+
+import (
+	"github.com/editorpost/spider/collect/config"
+	"log/slog"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// Filtered link is not queued, e.g. out of the domains or disallowed
+	Filtered = "filtered"
+	// Queued link is put to the queue
+	Queued = "queued"
+	// Extracted link is queued and the data is extracted from the page
+	Extracted = "extracted"
+
+	// OtherPattern groups the urls of the patterns not derived, e.g. invalid url
+	OtherPattern = "other"
+)
+
+// digits is the numeric path segment
+var digits = regexp.MustCompile(`^\d+$`)
+
+type (
+	// Edge from the page to the link found on it
+	Edge struct {
+		Source string `json:"Source"`
+		Target string `json:"Target"`
+		// Text of the link anchor
+		Text string `json:"Text"`
+		// Depth of the target page
+		Depth int `json:"Depth"`
+		// Status of the link: filtered, queued or extracted
+		Status string `json:"Status"`
+		// Reason the link is filtered, e.g. domain, disallowed, nofollow
+		Reason string `json:"Reason,omitempty"`
+	}
+
+	// Degree of the urls matching the pattern
+	Degree struct {
+		Pattern string `json:"Pattern"`
+		// URLs of the pattern, sources and targets
+		URLs int `json:"URLs"`
+		// In is the number of links to the urls of the pattern
+		In int `json:"In"`
+		// Out is the number of links from the urls of the pattern
+		Out int `json:"Out"`
+	}
+
+	// Graph of the crawl links, safe for concurrent use.
+	// The nil graph records nothing.
+	Graph struct {
+		exprs    []string
+		patterns config.Patterns
+		edges    []*Edge
+		max      int
+		// links are the recorded source and target pairs
+		links     map[link]bool
+		dropped   int
+		extracted map[string]bool
+		mute      *sync.Mutex
+	}
+
+	// link of the edge, the source and target pair
+	link struct {
+		source string
+		target string
+	}
+)
+
+// NewGraph of the max edges summarized by the url patterns, e.g. Config.ExtractURLs and Config.AllowedURLs,
+// the urls not matching them are grouped by the derived URLPattern. Zero max is unlimited.
+func NewGraph(maxEdges int, exprs ...string) (*Graph, error) {
+
+	patterns, err := config.NewPatterns(exprs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Graph{
+		exprs:     exprs,
+		patterns:  patterns,
+		edges:     make([]*Edge, 0),
+		max:       maxEdges,
+		links:     make(map[link]bool),
+		extracted: make(map[string]bool),
+		mute:      &sync.Mutex{},
+	}, nil
+}
+
+// Add the edge of the link found on the page, the same link of the page is recorded once.
+// The edges over the max are dropped, the first one is logged.
+func (g *Graph) Add(edge *Edge) {
+
+	if g == nil {
+		return
+	}
+
+	g.mute.Lock()
+	defer g.mute.Unlock()
+
+	key := link{source: edge.Source, target: edge.Target}
+	if g.links[key] {
+		return
+	}
+
+	if g.max > 0 && len(g.edges) >= g.max {
+		if g.dropped == 0 {
+			slog.Warn("graph: max edges reached, links are not recorded", slog.Int("max", g.max))
+		}
+		g.dropped++
+		return
+	}
+
+	g.links[key] = true
+	g.edges = append(g.edges, edge)
+}
+
+// Dropped is the number of the edges over the max
+func (g *Graph) Dropped() int {
+
+	if g == nil {
+		return 0
+	}
+
+	g.mute.Lock()
+	defer g.mute.Unlock()
+
+	return g.dropped
+}
+
+// Extract marks the page extracted, queued links to it are reported as extracted
+func (g *Graph) Extract(uri string) {
+
+	if g == nil {
+		return
+	}
+
+	g.mute.Lock()
+	defer g.mute.Unlock()
+
+	g.extracted[uri] = true
+}
+
+// Edges recorded in the discovery order with the final status
+func (g *Graph) Edges() []Edge {
+
+	if g == nil {
+		return nil
+	}
+
+	g.mute.Lock()
+	defer g.mute.Unlock()
+
+	edges := make([]Edge, 0, len(g.edges))
+
+	for _, edge := range g.edges {
+		e := *edge
+		if e.Status == Queued && g.extracted[e.Target] {
+			e.Status = Extracted
+		}
+		edges = append(edges, e)
+	}
+
+	return edges
+}
+
+// Summary of the in-degree and out-degree by the url pattern, the most linked patterns go first
+func (g *Graph) Summary() []*Degree {
+
+	if g == nil {
+		return nil
+	}
+
+	degrees := make(map[string]*Degree)
+	urls := make(map[string]bool)
+
+	degree := func(uri string) *Degree {
+
+		pattern := g.Pattern(uri)
+
+		d, ok := degrees[pattern]
+		if !ok {
+			d = &Degree{Pattern: pattern}
+			degrees[pattern] = d
+		}
+
+		if !urls[uri] {
+			urls[uri] = true
+			d.URLs++
+		}
+
+		return d
+	}
+
+	for _, edge := range g.Edges() {
+		degree(edge.Source).Out++
+		degree(edge.Target).In++
+	}
+
+	summary := slices.Collect(maps.Values(degrees))
+
+	slices.SortFunc(summary, func(a, b *Degree) int {
+		if a.In != b.In {
+			return b.In - a.In
+		}
+		return strings.Compare(a.Pattern, b.Pattern)
+	})
+
+	return summary
+}
+
+// Pattern of the url: the first matching pattern of the graph or the derived URLPattern
+func (g *Graph) Pattern(uri string) string {
+
+	if i := g.patterns.Index(uri); i >= 0 {
+		return g.exprs[i]
+	}
+
+	return URLPattern(uri)
+}
+
+// URLPattern of the url in the RegexPattern placeholders: the first path segment is kept,
+// numeric segments are {num}, the others are {dir} and the query is {any},
+// e.g. https://example.com/news/{num}/{dir} for https://example.com/news/2024/title.html
+func URLPattern(uri string) string {
+
+	u, err := url.Parse(uri)
+	if err != nil || len(u.Host) == 0 {
+		return OtherPattern
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	for i, segment := range segments {
+		switch {
+		case len(segment) == 0:
+		case digits.MatchString(segment):
+			segments[i] = "{num}"
+		case i > 0:
+			segments[i] = "{dir}"
+		}
+	}
+
+	pattern := u.Scheme + "://" + u.Host + "/" + strings.Join(segments, "/")
+
+	if len(u.RawQuery) > 0 {
+		pattern += "?{any}"
+	}
+
+	return pattern
+}
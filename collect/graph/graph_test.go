@@ -0,0 +1,103 @@
+package graph_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestURLPattern(t *testing.T) {
+
+	tests := []struct {
+		uri     string
+		pattern string
+	}{
+		{"https://example.com/", "https://example.com/"},
+		{"https://example.com/news", "https://example.com/news"},
+		{"https://example.com/news/2024/title.html", "https://example.com/news/{num}/{dir}"},
+		{"https://example.com/2024/title", "https://example.com/{num}/{dir}"},
+		{"https://example.com/search?q=go", "https://example.com/search?{any}"},
+		{"/relative", graph.OtherPattern},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.pattern, graph.URLPattern(tt.uri), tt.uri)
+	}
+}
+
+func TestGraph_Summary(t *testing.T) {
+
+	g, err := graph.NewGraph(0, "https://example.com/news/{num}.html")
+	require.NoError(t, err)
+
+	g.Add(&graph.Edge{Source: "https://example.com/", Target: "https://example.com/news/1.html", Status: graph.Queued})
+	// the same link of the page, e.g. navigation
+	g.Add(&graph.Edge{Source: "https://example.com/", Target: "https://example.com/news/1.html", Status: graph.Queued})
+	g.Add(&graph.Edge{Source: "https://example.com/", Target: "https://example.com/news/2.html", Status: graph.Queued})
+	g.Add(&graph.Edge{Source: "https://example.com/news/1.html", Target: "https://example.com/news/2.html", Status: graph.Queued})
+	g.Add(&graph.Edge{Source: "https://example.com/news/1.html", Target: "https://other.com/", Status: graph.Filtered, Reason: "domain"})
+	g.Extract("https://example.com/news/2.html")
+
+	edges := g.Edges()
+	require.Len(t, edges, 4)
+	assert.Equal(t, graph.Queued, edges[0].Status)
+	assert.Equal(t, graph.Extracted, edges[1].Status)
+	assert.Equal(t, graph.Extracted, edges[2].Status)
+	assert.Equal(t, graph.Filtered, edges[3].Status)
+
+	assert.Equal(t, []*graph.Degree{
+		{Pattern: "https://example.com/news/{num}.html", URLs: 2, In: 3, Out: 2},
+		{Pattern: "https://other.com/", URLs: 1, In: 1, Out: 0},
+		{Pattern: "https://example.com/", URLs: 1, In: 0, Out: 2},
+	}, g.Summary())
+
+	// the edges over the max are dropped
+	capped, err := graph.NewGraph(1)
+	require.NoError(t, err)
+	capped.Add(&graph.Edge{Source: "https://example.com/", Target: "https://example.com/a"})
+	capped.Add(&graph.Edge{Source: "https://example.com/", Target: "https://example.com/b"})
+	assert.Len(t, capped.Edges(), 1)
+	assert.Equal(t, 1, capped.Dropped())
+
+	// the nil graph records nothing
+	var disabled *graph.Graph
+	disabled.Add(&graph.Edge{})
+	assert.Nil(t, disabled.Edges())
+	assert.Nil(t, disabled.Summary())
+}
+
+func TestWrite(t *testing.T) {
+
+	edges := []graph.Edge{
+		{Source: "https://example.com/", Target: "https://example.com/a?x=1&y=2", Text: `"A" & <B>`, Depth: 2, Status: graph.Queued},
+		{Source: "https://example.com/", Target: "https://other.com/", Text: "Other", Depth: 2, Status: graph.Filtered, Reason: "domain"},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, graph.Write(buf, edges, config.GraphJSONL))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var edge graph.Edge
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &edge))
+	assert.Equal(t, edges[1], edge)
+
+	buf.Reset()
+	require.NoError(t, graph.Write(buf, edges, config.GraphML))
+	assert.Contains(t, buf.String(), `<node id="https://example.com/a?x=1&amp;y=2"/>`)
+	assert.Contains(t, buf.String(), `<data key="text">&#34;A&#34; &amp; &lt;B&gt;</data>`)
+	assert.Contains(t, buf.String(), `<data key="reason">domain</data>`)
+	assert.Equal(t, 3, strings.Count(buf.String(), "<node "))
+
+	buf.Reset()
+	require.NoError(t, graph.Write(buf, edges, config.GraphDOT))
+	assert.Contains(t, buf.String(), `"https://example.com/" -> "https://other.com/" [label="Other", depth=2, status=filtered, style=dashed];`)
+	assert.Contains(t, buf.String(), `label="\"A\" & <B>"`)
+
+	assert.Error(t, graph.Write(buf, edges, "gexf"))
+}
@@ -7,6 +7,18 @@ import (
 	"time"
 )
 
+// StartProxyPool of the Config.ProxySources if the proxy is enabled, nil otherwise.
+// The pool is shared by the collector transport and the browser tabs.
+func StartProxyPool(args *config.Config) (*proxy.Pool, error) {
+
+	// replayed crawl has no network
+	if !args.ProxyEnabled || args.Cache.Replay() {
+		return nil, nil
+	}
+
+	return proxy.StartPool(args.StartURL, args.RequestHeader, args.ProxySources...)
+}
+
 // WithProxyPool sets up the proxy for the crawler.
 //
 // Summary:
@@ -15,25 +27,11 @@ import (
 // - default request timeout is 15 seconds
 // - enable cookies (on error - log and skip setting cookies)
 // - finally, it sets up retries for response and proxy errors.
-func WithProxyPool(args *config.Config) (colly.CollectorOption, error) {
-
-	var (
-		err       error
-		poolReady bool
-		proxies   *proxy.Pool
-	)
-
-	if args.ProxyEnabled {
-		proxies, err = proxy.StartPool(args.StartURL, args.ProxySources...)
-		if err != nil {
-			return nil, err
-		}
-		poolReady = true
-	}
+func WithProxyPool(proxies *proxy.Pool) colly.CollectorOption {
 
 	return func(c *colly.Collector) {
 
-		if poolReady {
+		if proxies != nil {
 			// the transport is used to make HTTP requests.
 			// with proxy.Pool it is used to rotate the proxy
 			// and collect metrics from good/bad proxy responses
@@ -42,7 +40,5 @@ func WithProxyPool(args *config.Config) (colly.CollectorOption, error) {
 
 		// increase timeouts due proxy rotation
 		c.SetRequestTimeout(15 * time.Second)
-
-	}, nil
-
+	}
 }
@@ -18,6 +18,12 @@ import (
 // Returns:
 // - An error if the request fails or the response does not meet the criteria.
 func Check(proxyURL, testURL, contains string, timeout time.Duration) error {
+	return CheckHeader(proxyURL, testURL, contains, timeout, nil)
+}
+
+// CheckHeader is Check with the request headers, e.g. the user agent of the crawler.
+// Default user agent is sent if the header has no one.
+func CheckHeader(proxyURL, testURL, contains string, timeout time.Duration, header http.Header) error {
 	proxy, err := url.Parse(proxyURL)
 	if err != nil {
 		return fmt.Errorf("unable to parse proxy endpoint: %w", err)
@@ -41,7 +47,13 @@ func Check(proxyURL, testURL, contains string, timeout time.Duration) error {
 		return fmt.Errorf("unable to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/58.0.3029.110 Safari/537.3")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/58.0.3029.110 Safari/537.3")
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
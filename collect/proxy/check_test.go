@@ -67,3 +67,23 @@ func TestCheckInvalidProxyURL(t *testing.T) {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+func TestCheckHeader(t *testing.T) {
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "spider" || r.Header.Get("Accept-Language") != "de" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("expected content"))
+	}))
+	defer proxyServer.Close()
+
+	header := http.Header{"User-Agent": {"spider"}, "Accept-Language": {"de"}}
+	if err := proxy.CheckHeader(proxyServer.URL, "http://example.com", "expected content", 5*time.Second, header); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := proxy.Check(proxyServer.URL, "http://example.com", "expected content", 5*time.Second); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
@@ -0,0 +1,40 @@
+package proxy_test
+
+import (
+	"github.com/editorpost/spider/collect/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPool_Feedback(t *testing.T) {
+
+	pool := proxy.NewPool("http://example.com")
+	pool.Loader = func() ([]string, error) {
+		return []string{"http://127.0.0.1:3128"}, nil
+	}
+	pool.Checker = func(string) error {
+		return nil
+	}
+	require.NoError(t, pool.Start())
+
+	var p *proxy.Proxy
+	require.Eventually(t, func() bool {
+		p, _ = pool.Next()
+		return p != nil
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "http://127.0.0.1:3128", p.String())
+	assert.NoError(t, pool.Feedback(p, true))
+
+	// bad proxy is moved to the suspect list after 10 fails
+	for i := 0; i < 10; i++ {
+		assert.ErrorIs(t, pool.Feedback(p, false), proxy.ErrBadProxy)
+	}
+	assert.NoError(t, pool.Feedback(p, false))
+
+	// deleted proxy
+	assert.ErrorIs(t, pool.Feedback(nil, false), proxy.ErrBadProxy)
+	assert.NoError(t, pool.Feedback(nil, true))
+}
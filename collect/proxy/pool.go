@@ -28,14 +28,18 @@ type Pool struct {
 	Loader func() ([]string, error)
 	// Checker is a function to check the proxy by URI string
 	Checker func(string) error
-	rtp     *http.Transport
+	// Header is a function to return the headers of the request through the proxy by URI string,
+	// e.g. the user agent sticky to the proxy. Default user agent is sent if nil.
+	Header func(string) http.Header
+	rtp    *http.Transport
 }
 
-// StartPool initializes a new pool with the given start Endpoint and proxy sources.
-func StartPool(startURL string, proxySources ...string) (*Pool, error) {
+// StartPool initializes a new pool with the given start Endpoint, request headers and proxy sources.
+func StartPool(startURL string, header func(string) http.Header, proxySources ...string) (*Pool, error) {
 
 	// start the proxy pool
 	pool := NewPool(startURL)
+	pool.Header = header
 
 	// provide user defined proxy sources
 	// or used default public sources
@@ -67,25 +71,7 @@ func NewPool(testURL string) *Pool {
 		Proxy:             pool.GetProxyURL,
 		DisableKeepAlives: true,
 		OnProxyConnectResponse: func(_ context.Context, proxyURL *url.URL, req *http.Request, resp *http.Response) error {
-
-			p := pool.valid.Get(proxyURL.String())
-			p.AddUsageMetric()
-
-			if resp.StatusCode == http.StatusOK {
-				p.AddSuccessMetric()
-				return nil
-			}
-
-			p.AddFailMetric()
-
-			if p.fails.Load() > 10 {
-
-				pool.valid.Delete(proxyURL.String())
-				pool.suspect.Add(p)
-				return nil
-			}
-
-			return ErrBadProxy
+			return pool.Feedback(pool.valid.Get(proxyURL.String()), resp.StatusCode == http.StatusOK)
 		},
 	}
 
@@ -111,13 +97,67 @@ func (pool *Pool) Transport() *http.Transport {
 	return pool.rtp
 }
 
+// Feedback of the proxy response, e.g. the CONNECT response or the browser navigation.
+// The proxy failed more than 10 times is moved to the suspect list.
+// Returns ErrBadProxy if the proxy failed.
+func (pool *Pool) Feedback(p *Proxy, success bool) error {
+
+	// proxy might be deleted from the valid list
+	if p == nil {
+		if success {
+			return nil
+		}
+		return ErrBadProxy
+	}
+
+	p.AddUsageMetric()
+
+	if success {
+		p.AddSuccessMetric()
+		return nil
+	}
+
+	p.AddFailMetric()
+
+	if p.fails.Load() > 10 {
+
+		pool.valid.Delete(p.String())
+		pool.suspect.Add(p)
+		return nil
+	}
+
+	return ErrBadProxy
+}
+
 // GetProxyURL returns the next valid from the pool or blocks until one is available.
 // Every 30 seconds prints report of the valid pool.
 func (pool *Pool) GetProxyURL(pr *http.Request) (*url.URL, error) {
 
+	proxy, err := pool.next(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	// headers depend on the proxy picked for the request
+	if pool.Header != nil && pr != nil {
+		for name, values := range pool.Header(proxy.String()) {
+			pr.Header[name] = values
+		}
+	}
+
+	return proxy.URL, nil
+}
+
+// Next valid proxy of the pool or blocks until one is available, e.g. for the browser tab
+func (pool *Pool) Next() (*Proxy, error) {
+	return pool.next(nil)
+}
+
+func (pool *Pool) next(pr *http.Request) (*Proxy, error) {
+
 	// load next valid proxy
 	if proxy := pool.valid.Next(pr); proxy != nil {
-		return proxy.URL, nil
+		return proxy, nil
 	}
 
 	// wait for a valid proxy
@@ -135,7 +175,7 @@ func (pool *Pool) GetProxyURL(pr *http.Request) (*url.URL, error) {
 		// try
 		if proxy := pool.valid.Next(pr); proxy != nil {
 			proxy.AddUsageMetric()
-			return proxy.URL, nil
+			return proxy, nil
 		}
 
 		// report
@@ -192,7 +232,11 @@ func (pool *Pool) CheckProxy(p *Proxy, wg *sync.WaitGroup) {
 	checker := pool.Checker
 	if checker == nil {
 		checker = func(proxyURL string) error {
-			return Check(proxyURL, pool.checkURL, pool.checkContent, pool.checkTimeout)
+			var header http.Header
+			if pool.Header != nil {
+				header = pool.Header(proxyURL)
+			}
+			return CheckHeader(proxyURL, pool.checkURL, pool.checkContent, pool.checkTimeout, header)
 		}
 	}
 
@@ -0,0 +1,291 @@
+package collect
+
+import (
+	"fmt"
+	"github.com/editorpost/spider/collect/events"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/editorpost/spider/collect/trap"
+	"github.com/gocolly/colly/v2"
+	"github.com/gocolly/colly/v2/queue"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Queue is the colly queue adding requests directly to the storage.
+// The colly queue.AddRequest blocks on the wake channel if called after the queue stopped,
+// e.g. by links of the pages in progress when ExtractLimit reached.
+// Failed requests are delayed by the retry backoff and put back to the storage,
+// the queue runs until the delayed requests are done.
+// The urls of the crawler traps are not added, if the detector is set.
+type Queue struct {
+	*queue.Queue
+	storage queue.Storage
+	traps   *trap.Detector
+	lock    sync.Mutex
+	stopped bool
+	// delayed requests waiting for the backoff
+	delayed int
+	timers  map[*time.Timer][]byte
+	wake    chan struct{}
+}
+
+// processed marks the request done in the storage, e.g. the persistent frontier
+type processed interface {
+	Done(r *colly.Request)
+}
+
+// requeued puts the loaded request back to the storage as pending, e.g. the persistent frontier
+type requeued interface {
+	Requeue(r *colly.Request)
+}
+
+// NewQueue of the threads consuming the storage
+func NewQueue(threads int, storage queue.Storage) (*Queue, error) {
+
+	q, err := queue.New(threads, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Queue{
+		Queue:   q,
+		storage: storage,
+		timers:  make(map[*time.Timer][]byte),
+		wake:    make(chan struct{}, 1),
+	}, nil
+}
+
+// AddURL to the queue storage, returns trap.ErrTrap if the url is the crawler trap
+func (q *Queue) AddURL(uri string) error {
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+
+	return q.AddRequest(&colly.Request{
+		URL:    u,
+		Method: http.MethodGet,
+		Ctx:    colly.NewContext(),
+	})
+}
+
+// AddRequest to the queue storage, returns trap.ErrTrap if the url is the crawler trap
+func (q *Queue) AddRequest(r *colly.Request) error {
+
+	// pagination is limited by the MaxPages, e.g. ?page=N
+	if q.traps != nil && !isPaginationClass(r) {
+		if err := q.traps.Check(r.URL); err != nil {
+			return err
+		}
+	}
+
+	b, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return q.storage.AddRequest(b)
+}
+
+// isPaginationClass returns true for the listing page request
+func isPaginationClass(r *colly.Request) bool {
+	return r.Ctx != nil && frontier.ParseClass(r.Ctx.Get(frontier.ClassCtx)) == frontier.Pagination
+}
+
+// Delay the request and put it to the queue after the duration
+func (q *Queue) Delay(r *colly.Request, d time.Duration) error {
+
+	// marshalled now, the request context is changed by the running handlers
+	b, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.stopped {
+		return nil
+	}
+
+	q.delayed++
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+
+		if err := q.storage.AddRequest(b); err != nil {
+			slog.Warn("crawler queue", slog.String("error", err.Error()))
+		}
+
+		q.lock.Lock()
+		q.delayed--
+		delete(q.timers, timer)
+		q.lock.Unlock()
+
+		q.signal()
+	})
+	q.timers[timer] = b
+
+	return nil
+}
+
+// Run starts the consumer threads and blocks until the storage is empty,
+// no requests are in progress and no requests are delayed, or the queue is stopped
+func (q *Queue) Run(c *colly.Collector) error {
+
+	requests := make(chan *colly.Request)
+	complete := make(chan struct{})
+
+	for i := 0; i < q.Threads; i++ {
+		go func() {
+			for r := range requests {
+				q.do(r)
+				complete <- struct{}{}
+			}
+		}()
+	}
+	defer close(requests)
+
+	active := 0
+
+	for {
+
+		size, err := q.storage.QueueSize()
+		if err != nil {
+			return err
+		}
+
+		q.lock.Lock()
+		stopped, delayed := q.stopped, q.delayed
+		q.lock.Unlock()
+
+		if stopped {
+			q.drain(complete, active)
+			return nil
+		}
+
+		if size == 0 && active == 0 && delayed == 0 {
+			return nil
+		}
+
+		var sent chan<- *colly.Request
+		var req *colly.Request
+		var b []byte
+
+		if size > 0 {
+			if b, err = q.storage.GetRequest(); err != nil {
+				// the storage failure is not retried, the requests in progress are completed
+				q.drain(complete, active)
+				return fmt.Errorf("queue storage: %w", err)
+			}
+			// the invalid request is removed from the storage
+			if req, err = c.UnmarshalRequest(append([]byte(nil), b...)); err != nil {
+				slog.Warn("crawler queue: invalid request", slog.String("error", err.Error()))
+				continue
+			}
+			sent = requests
+		}
+
+	Sent:
+		for {
+			select {
+			case sent <- req:
+				active++
+				break Sent
+			case <-complete:
+				active--
+				// nothing to send, new links of the completed page are checked
+				if sent == nil {
+					break Sent
+				}
+			case <-q.wake:
+				if sent == nil {
+					break Sent
+				}
+			}
+
+			// stopped by the completed page, the loaded request is kept in the storage
+			if q.isStopped() {
+				q.requeue(req, b)
+				q.drain(complete, active)
+				return nil
+			}
+		}
+	}
+}
+
+// Stop the queue, the delayed requests are put back to the storage without waiting for the backoff,
+// so the resumed run retries them
+func (q *Queue) Stop() {
+
+	q.lock.Lock()
+	q.stopped = true
+	for timer, b := range q.timers {
+		if timer.Stop() {
+			q.delayed--
+			if err := q.storage.AddRequest(b); err != nil {
+				slog.Warn("crawler queue", slog.String("error", err.Error()))
+			}
+		}
+		delete(q.timers, timer)
+	}
+	q.lock.Unlock()
+
+	q.signal()
+}
+
+// requeue the loaded request not sent to the threads, the crawler trap check is not repeated
+func (q *Queue) requeue(req *colly.Request, b []byte) {
+
+	if storage, ok := q.storage.(requeued); ok {
+		storage.Requeue(req)
+		return
+	}
+
+	if err := q.storage.AddRequest(b); err != nil {
+		slog.Warn("crawler queue", slog.String("error", err.Error()))
+	}
+}
+
+// isStopped returns true if the queue is stopped
+func (q *Queue) isStopped() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.stopped
+}
+
+// do the request, the retried one and the one requested again after the login
+// are done regardless of the visited storage. The request is marked processed in the storage
+// once done, including the one aborted or filtered by the collector.
+func (q *Queue) do(r *colly.Request) {
+
+	if done, ok := q.storage.(processed); ok {
+		defer done.Done(r)
+	}
+
+	if r.Ctx.Get(events.RetryCountCtx) != "" || isRelogin(r) {
+		_ = r.Retry()
+		return
+	}
+
+	_ = r.Do()
+}
+
+// drain waits for the requests in progress after the queue stopped
+func (q *Queue) drain(complete <-chan struct{}, active int) {
+	for ; active > 0; active-- {
+		<-complete
+	}
+}
+
+// signal the run loop without blocking
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,204 @@
+package robots
+
+import (
+	"context"
+	"github.com/temoto/robotstxt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Disallow is the robots.txt Disallow rule
+	Disallow = "disallow"
+	// NoIndex is the meta robots or X-Robots-Tag noindex directive
+	NoIndex = "noindex"
+	// NoFollow is the meta robots or X-Robots-Tag nofollow directive
+	NoFollow = "nofollow"
+	// NoFollowLink is the rel="nofollow" attribute of the link
+	NoFollowLink = "nofollow_link"
+
+	// NoIndexCtx is the request context key, set if the page must not be extracted
+	NoIndexCtx = "RobotsNoIndex"
+	// NoFollowCtx is the request context key, set if the page links must not be followed
+	NoFollowCtx = "RobotsNoFollow"
+
+	// MaxCrawlDelay limits the Crawl-delay of robots.txt,
+	// some sites define hours of delay to block crawlers
+	MaxCrawlDelay = time.Minute
+)
+
+type (
+	// Robots fetches and caches robots.txt per host for the user agent
+	Robots struct {
+		client    *http.Client
+		userAgent string
+		mute      *sync.Mutex
+		hosts     map[string]*host
+	}
+
+	host struct {
+		once  sync.Once
+		data  *robotstxt.RobotsData
+		delay time.Duration
+		mute  sync.Mutex
+		next  time.Time
+	}
+
+	// Directives of meta robots tag or X-Robots-Tag header
+	Directives struct {
+		NoIndex  bool
+		NoFollow bool
+	}
+)
+
+func NewRobots(client *http.Client, userAgent string) *Robots {
+
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+
+	return &Robots{
+		client:    client,
+		userAgent: userAgent,
+		mute:      &sync.Mutex{},
+		hosts:     make(map[string]*host),
+	}
+}
+
+// Allowed checks the url against robots.txt Disallow/Allow rules
+func (r *Robots) Allowed(u *url.URL) bool {
+
+	path := u.EscapedPath()
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	return r.host(u).data.TestAgent(path, r.userAgent)
+}
+
+// Delay returns robots.txt Crawl-delay of the url host
+func (r *Robots) Delay(u *url.URL) time.Duration {
+	return r.host(u).delay
+}
+
+// Wait blocks until the Crawl-delay since the previous request to the host is passed
+// or the ctx is done, returns the ctx error if the wait is cancelled
+func (r *Robots) Wait(ctx context.Context, u *url.URL) error {
+
+	h := r.host(u)
+	if h.delay == 0 {
+		return nil
+	}
+
+	h.mute.Lock()
+	now := time.Now()
+	wait := h.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	h.next = now.Add(wait + h.delay)
+	h.mute.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *Robots) host(u *url.URL) *host {
+
+	key := u.Scheme + "://" + u.Host
+
+	r.mute.Lock()
+	h, ok := r.hosts[key]
+	if !ok {
+		h = &host{}
+		r.hosts[key] = h
+	}
+	r.mute.Unlock()
+
+	h.once.Do(func() {
+		h.data = r.fetch(key + "/robots.txt")
+		h.delay = min(h.data.FindGroup(r.userAgent).CrawlDelay, MaxCrawlDelay)
+	})
+
+	return h
+}
+
+// fetch robots.txt, any fetch or parse error allows everything
+func (r *Robots) fetch(uri string) *robotstxt.RobotsData {
+
+	allowAll, _ := robotstxt.FromStatusAndBytes(http.StatusNotFound, nil)
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return allowAll
+	}
+
+	req.Header.Set("User-Agent", r.userAgent)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		slog.Warn("robots.txt fetch", slog.String("url", uri), slog.String("error", err.Error()))
+		return allowAll
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+
+	data, err := robotstxt.FromResponse(resp)
+	if err != nil {
+		slog.Warn("robots.txt parse", slog.String("url", uri), slog.String("error", err.Error()))
+		return allowAll
+	}
+
+	return data
+}
+
+// ParseDirectives of meta robots content or X-Robots-Tag header values,
+// e.g. "noindex, nofollow", "none" or "googlebot: noindex"
+func ParseDirectives(values ...string) Directives {
+
+	d := Directives{}
+
+	for _, value := range values {
+		for _, directive := range strings.Split(strings.ToLower(value), ",") {
+
+			directive = strings.TrimSpace(directive)
+
+			// user agent prefixed X-Robots-Tag, e.g. "googlebot: noindex"
+			if _, after, found := strings.Cut(directive, ":"); found {
+				directive = strings.TrimSpace(after)
+			}
+
+			switch directive {
+			case "none":
+				d.NoIndex, d.NoFollow = true, true
+			case NoIndex:
+				d.NoIndex = true
+			case NoFollow:
+				d.NoFollow = true
+			}
+		}
+	}
+
+	return d
+}
+
+// IsNoFollowLink checks the rel attribute of the link
+func IsNoFollowLink(rel string) bool {
+	for _, value := range strings.Fields(strings.ToLower(rel)) {
+		if value == NoFollow {
+			return true
+		}
+	}
+	return false
+}
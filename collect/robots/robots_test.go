@@ -0,0 +1,122 @@
+package robots_test
+
+import (
+	"context"
+	"github.com/editorpost/spider/collect/robots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRobots_Allowed(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/\nAllow: /private/open\nDisallow: /*?print=\nCrawl-delay: 2\n\nUser-agent: spider\nDisallow: /\n"))
+	}))
+	defer srv.Close()
+
+	rules := robots.NewRobots(srv.Client(), "Mozilla/5.0")
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/", true},
+		{"/news/article-1.html", true},
+		{"/private/page", false},
+		{"/private/open", true},
+		{"/news/article-1.html?print=1", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, rules.Allowed(MustURL(t, srv.URL+tt.path)), tt.path)
+	}
+
+	assert.Equal(t, 2*time.Second, rules.Delay(MustURL(t, srv.URL)))
+
+	// specific user agent group
+	assert.False(t, robots.NewRobots(srv.Client(), "spider/1.0").Allowed(MustURL(t, srv.URL+"/news")))
+}
+
+func TestRobots_Wait(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("User-agent: *\nCrawl-delay: 30\n"))
+	}))
+	defer srv.Close()
+
+	rules := robots.NewRobots(srv.Client(), "Mozilla/5.0")
+	u := MustURL(t, srv.URL+"/page")
+
+	// the first request is not delayed
+	require.NoError(t, rules.Wait(context.Background(), u))
+
+	// the next one waits for the delay until the ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	assert.ErrorIs(t, rules.Wait(ctx, u), context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestRobots_AllowedFallback(t *testing.T) {
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	rules := robots.NewRobots(notFound.Client(), "Mozilla/5.0")
+	assert.True(t, rules.Allowed(MustURL(t, notFound.URL+"/any")))
+	assert.Zero(t, rules.Delay(MustURL(t, notFound.URL)))
+
+	// server errors are full disallow by the spec
+	failed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failed.Close()
+
+	assert.False(t, robots.NewRobots(failed.Client(), "Mozilla/5.0").Allowed(MustURL(t, failed.URL+"/any")))
+}
+
+func TestParseDirectives(t *testing.T) {
+
+	tests := []struct {
+		values   []string
+		expected robots.Directives
+	}{
+		{nil, robots.Directives{}},
+		{[]string{"index, follow"}, robots.Directives{}},
+		{[]string{"NOINDEX"}, robots.Directives{NoIndex: true}},
+		{[]string{"noindex,nofollow"}, robots.Directives{NoIndex: true, NoFollow: true}},
+		{[]string{"none"}, robots.Directives{NoIndex: true, NoFollow: true}},
+		{[]string{"max-snippet:20", "googlebot: nofollow"}, robots.Directives{NoFollow: true}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, robots.ParseDirectives(tt.values...), tt.values)
+	}
+}
+
+func TestIsNoFollowLink(t *testing.T) {
+	assert.True(t, robots.IsNoFollowLink("nofollow"))
+	assert.True(t, robots.IsNoFollowLink("noopener NoFollow"))
+	assert.False(t, robots.IsNoFollowLink("noopener"))
+	assert.False(t, robots.IsNoFollowLink(""))
+}
+
+func MustURL(t *testing.T, uri string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
@@ -3,10 +3,13 @@ package collect
 import (
 	"github.com/editorpost/spider/collect/config"
 	"github.com/editorpost/spider/collect/events"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/editorpost/spider/collect/trap"
+	"github.com/editorpost/spider/extract/pipe"
 	"github.com/gocolly/colly/v2"
-	"github.com/gocolly/colly/v2/extensions"
 	"github.com/gocolly/colly/v2/queue"
 	"log/slog"
+	"net/http"
 	"net/http/cookiejar"
 	"regexp"
 )
@@ -21,44 +24,103 @@ func (crawler *Crawler) collector() (*colly.Collector, error) {
 
 	slog.Info("setup collector", crawler.args.Log())
 
-	if err := crawler.withQueue(); err != nil {
+	err := crawler.withQueue()
+	if err != nil {
 		return nil, err
 	}
 
-	withProxyPool, err := WithProxyPool(crawler.args)
+	// browser tabs use the same proxy pool
+	crawler.proxies, err = StartProxyPool(crawler.args)
 	if err != nil {
 		return nil, err
 	}
 
+	// pages are rendered by the browser transport
+	crawler.dispatch = events.NewDispatcher(crawler.args, crawler.deps, events.Queue(crawler.queue))
+
 	// Set up a new collector with a maximum depth and maximum body size
 	crawler.collect = colly.NewCollector(
 		colly.MaxDepth(crawler.args.Depth),
 		colly.MaxBodySize(10<<20), // 10MB
 		crawler.VisitUrlsFilter(crawler.args),
-		events.WithDispatcher(crawler.args, crawler.deps, events.Queue(crawler.queue), events.Browser(crawler)),
-		withProxyPool,
+		crawler.dispatch.Setup,
+		WithProxyPool(crawler.proxies),
 	)
 
+	// render pages by the browser, links are discovered and data is extracted from the same navigation
+	if crawler.args.UseBrowser {
+		crawler.collect.WithTransport(&ChromeTransport{crawler: crawler})
+		crawler.collect.SetRequestTimeout(crawler.args.BrowserTimeout())
+	}
+
+	// responses are recorded to the archive or replayed from it
+	if crawler.args.Cache.Enabled() {
+		crawler.collect.WithTransport(crawler.cached(crawler.transport()))
+	}
+
+	// screenshots and PDFs of the extracted pages
+	crawler.withSnapshots()
+
+	// raw http exchanges of the fetched responses
+	crawler.withWARC()
+
+	// extractors of the page are cancelled with the run
+	crawler.collect.OnResponse(func(r *colly.Response) {
+		r.Ctx.Put(pipe.RunCtx, crawler.collect.Context)
+	})
+
 	// revisit the same URL
 	crawler.collect.AllowURLRevisit = !crawler.args.VisitOnce
 
+	// per-host politeness rules
+	if err = crawler.collect.Limits(crawler.args.CollyLimits()); err != nil {
+		return nil, err
+	}
+
 	if err = crawler.collect.SetStorage(crawler.deps.Storage); err != nil {
 		return nil, err
 	}
 
-	// Set a random user agent
-	extensions.RandomUserAgent(crawler.collect)
+	// headers and the user agent of the rotation,
+	// the proxy transport sets the user agent sticky to the proxy
+	crawler.collect.OnRequest(crawler.headers)
 
-	// cookie handling
+	// cookie handling, the session jar is kept between runs
 	// for turning off - crawler.collect.DisableCookies()
-	j, err := cookiejar.New(&cookiejar.Options{})
-	if err == nil {
-		crawler.collect.SetCookieJar(j)
+	if crawler.deps.Cookies == nil {
+		crawler.deps.Cookies, _ = cookiejar.New(&cookiejar.Options{})
+	}
+	crawler.collect.SetCookieJar(crawler.deps.Cookies)
+
+	// login and the auth headers of the hosts
+	if err = crawler.withAuth(); err != nil {
+		return nil, err
 	}
 
 	return crawler.collect, nil
 }
 
+// transport of the collector: the browser, the proxy pool or the direct one
+func (crawler *Crawler) transport() http.RoundTripper {
+
+	if crawler.args.UseBrowser {
+		return &ChromeTransport{crawler: crawler}
+	}
+
+	if crawler.proxies != nil {
+		return crawler.proxies.Transport()
+	}
+
+	return http.DefaultTransport
+}
+
+// headers of the config set to the request
+func (crawler *Crawler) headers(r *colly.Request) {
+	for name, values := range crawler.args.RequestHeader("") {
+		(*r.Headers)[name] = values
+	}
+}
+
 // VisitUrlsFilter sets up the Endpoint filters for the collector.
 // It applies a regular expression filter to the URLs visited by the collector.
 // Allowed Endpoint pattern is used to extract links in hope to find entity URLs.
@@ -66,12 +128,23 @@ func (crawler *Crawler) collector() (*colly.Collector, error) {
 func (crawler *Crawler) VisitUrlsFilter(args *config.Config) colly.CollectorOption {
 	return func(collector *colly.Collector) {
 
-		// Append the host of the start Endpoint to the allowed domains of the collector
-		collector.AllowedDomains = append(collector.AllowedDomains, config.MustHostname(args.StartURL))
+		// colly matches exact hostnames only,
+		// wildcard domains are checked by the dispatcher
+		if !args.Domains().Wildcard() {
+			collector.AllowedDomains = append(collector.AllowedDomains, args.AllowedDomains...)
+		}
 
-		// Append the allowed Endpoint to the Endpoint filters of the collector
-		for _, allowedURL := range args.AllowedURLs {
-			crawler.VisitUrlFilter(allowedURL, collector)
+		// Append the allowed Endpoint to the Endpoint filters of the collector,
+		// checked by the dispatcher if pagination enabled to let pagination pages through
+		if !args.Pagination.Enabled() {
+			for _, allowedURL := range args.AllowedURLs {
+				crawler.VisitUrlFilter(allowedURL, collector)
+			}
+		}
+
+		// if ANY disallowed expression matches the URL, the URL is not visited
+		for _, disallowedURL := range args.DisallowedURLs {
+			collector.DisallowedURLFilters = append(collector.DisallowedURLFilters, regexp.MustCompile(config.RegexPattern(disallowedURL)))
 		}
 	}
 }
@@ -87,19 +160,37 @@ func (crawler *Crawler) VisitUrlFilter(allowedURL string, collector *colly.Colle
 }
 
 // withQueue sets up the request queue for the crawler.
-// It creates a new request queue with 25 consumer threads and an in-memory queue storage with a maximum size of 50MB.
+// It creates a new request queue with Config.Threads consumer threads and the Deps.QueueStorage,
+// or an in-memory frontier ordered by Config.Scheduling with a maximum size of 5M requests if not set.
 // If an error occurs during the collector, it panics and stops the execution.
 //
 // create a request queue with number of consumer threads
 // https://go-colly.org/docs/examples/queue/
 func (crawler *Crawler) withQueue() (err error) {
 
-	crawler.queue, err = queue.New(
-		5, // Number of consumer threads
-		&queue.InMemoryQueueStorage{MaxSize: 5000000}, // 5MB
+	var storage queue.Storage = frontier.NewFrontier(crawler.args.Scheduling, 5000000)
+	if crawler.deps.QueueStorage != nil {
+		storage = crawler.deps.QueueStorage
+	}
+
+	crawler.queue, err = NewQueue(
+		crawler.args.Threads(), // Number of consumer threads
+		storage,
 	)
+	if err != nil {
+		return err
+	}
+
+	// infinite calendars, session ids, etc. are not queued
+	if !crawler.args.Traps.Disabled {
+		entities, err := config.NewPatterns(crawler.args.ExtractURLs...)
+		if err != nil {
+			return err
+		}
+		crawler.queue.traps = trap.NewDetector(&crawler.args.Traps, entities, crawler.deps.Monitor)
+	}
 
-	return err
+	return nil
 }
 
 //goland:noinspection GoLinter
@@ -0,0 +1,135 @@
+package collect
+
+import (
+	"errors"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/editorpost/spider/collect/sitemap"
+	"github.com/editorpost/spider/collect/trap"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// withSitemaps seeds the queue with sitemap entries.
+// Entries must match AllowedURLs, and ExtractURLs if defined, but not DisallowedURLs,
+// so only entity pages are queued and the budget isn't spent on listings.
+// Sitemap values are passed to the request context, see sitemap.Entry.Context.
+func (crawler *Crawler) withSitemaps() error {
+
+	if len(crawler.args.Sitemaps) == 0 && !crawler.args.SitemapDiscover {
+		return nil
+	}
+
+	allowed, err := config.NewPatterns(crawler.args.AllowedURLs...)
+	if err != nil {
+		return err
+	}
+
+	disallowed, err := config.NewPatterns(crawler.args.DisallowedURLs...)
+	if err != nil {
+		return err
+	}
+
+	extract, err := config.NewPatterns(crawler.args.ExtractURLs...)
+	if err != nil {
+		return err
+	}
+
+	loader := sitemap.NewLoader(crawler.sitemapClient(), crawler.args.UserAgent)
+	sitemaps := crawler.args.Sitemaps
+
+	if crawler.args.SitemapDiscover {
+		roots := make(map[string]bool)
+		for _, startURL := range crawler.args.StartURLs {
+
+			// robots.txt is per host
+			root := config.RootUrl(config.MustURL(startURL))
+			if roots[root] {
+				continue
+			}
+			roots[root] = true
+
+			discovered, discoverErr := loader.Discover(root)
+			if discoverErr != nil {
+				slog.Warn("sitemap discover", slog.String("error", discoverErr.Error()))
+			}
+			sitemaps = append(sitemaps, discovered...)
+		}
+	}
+
+	queued := 0
+
+	for _, entry := range loader.Load(sitemaps...) {
+
+		if !allowed.Match(entry.Loc) || !crawler.args.Domains().MatchURL(entry.Loc) {
+			continue
+		}
+
+		if len(extract) > 0 && !extract.Match(entry.Loc) {
+			continue
+		}
+
+		if i := disallowed.Index(entry.Loc); i >= 0 {
+			crawler.deps.Monitor.OnDisallowed(entry.Loc, crawler.args.DisallowedURLs[i])
+			continue
+		}
+
+		// entries are filtered by ExtractURLs
+		class := frontier.Other
+		if len(extract) > 0 {
+			class = frontier.Entity
+		}
+
+		// the crawler traps are logged by the queue
+		if err = crawler.queueSitemapEntry(entry, class); errors.Is(err, trap.ErrTrap) {
+			continue
+		} else if err != nil {
+			slog.Warn("sitemap queue", slog.String("url", entry.Loc), slog.String("error", err.Error()))
+			continue
+		}
+
+		queued++
+	}
+
+	slog.Info("sitemap entries queued", slog.Int("sitemaps", len(sitemaps)), slog.Int("queued", queued))
+
+	return nil
+}
+
+func (crawler *Crawler) queueSitemapEntry(entry sitemap.Entry, class frontier.Class) error {
+
+	u, err := url.Parse(entry.Loc)
+	if err != nil {
+		return err
+	}
+
+	u = crawler.args.Canonical.URL(u)
+
+	ctx := colly.NewContext()
+	for key, value := range entry.Context() {
+		ctx.Put(key, value)
+	}
+	class.Put(ctx)
+
+	return crawler.queue.AddRequest(&colly.Request{
+		URL:    u,
+		Method: http.MethodGet,
+		// as if linked from the start page
+		Depth: 1,
+		Ctx:   ctx,
+	})
+}
+
+func (crawler *Crawler) sitemapClient() *http.Client {
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	if crawler.deps.RoundTripper != nil {
+		client.Transport = crawler.deps.RoundTripper
+	}
+
+	return client
+}
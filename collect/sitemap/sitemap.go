@@ -0,0 +1,316 @@
+package sitemap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// LastModCtx is the request context key for the sitemap <lastmod> value
+	LastModCtx = "SitemapLastMod"
+	// PublicationDateCtx is the request context key for the <news:publication_date> value
+	PublicationDateCtx = "SitemapPublicationDate"
+	// NewsTitleCtx is the request context key for the <news:title> value
+	NewsTitleCtx = "SitemapNewsTitle"
+	// ImagesCtx is the request context key for the comma separated <image:loc> values
+	ImagesCtx = "SitemapImages"
+
+	// MaxIndexDepth limits the recursion of nested sitemap indexes
+	MaxIndexDepth = 5
+	// MaxBodySize limits the size of a single (uncompressed) sitemap file, 50MB by protocol
+	MaxBodySize = 50 << 20
+)
+
+type (
+	// Entry is a single <url> of the sitemap
+	Entry struct {
+		Loc             string
+		LastMod         string
+		PublicationDate string
+		NewsTitle       string
+		Images          []string
+	}
+
+	// Loader fetches sitemaps and expands sitemap indexes recursively.
+	Loader struct {
+		client    *http.Client
+		userAgent string
+		mute      *sync.Mutex
+		loaded    map[string]bool
+	}
+
+	// Document is both <urlset> and <sitemapindex> root,
+	// they are distinguished by XMLName.Local
+	Document struct {
+		XMLName  xml.Name
+		URLs     []xmlURL     `xml:"url"`
+		Sitemaps []xmlSitemap `xml:"sitemap"`
+	}
+
+	xmlSitemap struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod"`
+	}
+
+	xmlURL struct {
+		Loc     string     `xml:"loc"`
+		LastMod string     `xml:"lastmod"`
+		News    xmlNews    `xml:"news"`
+		Images  []xmlImage `xml:"image"`
+	}
+
+	xmlNews struct {
+		PublicationDate string `xml:"publication_date"`
+		Title           string `xml:"title"`
+	}
+
+	xmlImage struct {
+		Loc string `xml:"loc"`
+	}
+)
+
+func NewLoader(client *http.Client, userAgent string) *Loader {
+
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Loader{
+		client:    client,
+		userAgent: userAgent,
+		mute:      &sync.Mutex{},
+		loaded:    make(map[string]bool),
+	}
+}
+
+// Load fetches sitemaps and returns entries of all nested sitemaps.
+// Broken sitemaps are logged and skipped, so one bad index doesn't stop the whole crawl.
+func (l *Loader) Load(sitemaps ...string) []Entry {
+
+	entries := make([]Entry, 0)
+
+	for _, uri := range sitemaps {
+		entries = append(entries, l.load(uri, 0)...)
+	}
+
+	return entries
+}
+
+// Discover returns sitemap URLs listed in robots.txt `Sitemap:` lines of the given site.
+func (l *Loader) Discover(siteURL string) ([]string, error) {
+
+	u, err := url.Parse(siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := l.fetch(u.Scheme + "://" + u.Host + "/robots.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRobots(body), nil
+}
+
+func (l *Loader) load(uri string, depth int) []Entry {
+
+	if depth > MaxIndexDepth {
+		slog.Warn("sitemap index too deep", slog.String("url", uri))
+		return nil
+	}
+
+	// avoid loops between sitemap indexes
+	if !l.once(uri) {
+		return nil
+	}
+
+	body, err := l.fetch(uri)
+	if err != nil {
+		slog.Warn("sitemap load", slog.String("url", uri), slog.String("error", err.Error()))
+		return nil
+	}
+
+	doc, err := Parse(body)
+	if err != nil {
+		slog.Warn("sitemap parse", slog.String("url", uri), slog.String("error", err.Error()))
+		return nil
+	}
+
+	entries := doc.entries()
+	for _, nested := range doc.Sitemaps {
+		entries = append(entries, l.load(strings.TrimSpace(nested.Loc), depth+1)...)
+	}
+
+	slog.Debug("sitemap loaded", slog.String("url", uri), slog.Int("entries", len(entries)))
+
+	return entries
+}
+
+func (l *Loader) once(uri string) bool {
+
+	l.mute.Lock()
+	defer l.mute.Unlock()
+
+	if l.loaded[uri] {
+		return false
+	}
+
+	l.loaded[uri] = true
+	return true
+}
+
+func (l *Loader) fetch(uri string) ([]byte, error) {
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l.userAgent) > 0 {
+		req.Header.Set("User-Agent", l.userAgent)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	return Decompress(io.LimitReader(resp.Body, MaxBodySize))
+}
+
+// Decompress reads the body and un-gzip it if the content is gzip compressed.
+// Compression is detected by magic bytes, since servers often send .xml.gz
+// as application/octet-stream or even application/xml.
+func Decompress(r io.Reader) ([]byte, error) {
+
+	buf := bufio.NewReader(r)
+
+	magic, err := buf.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return io.ReadAll(buf)
+	}
+
+	gz, err := gzip.NewReader(buf)
+	if err != nil {
+		return nil, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer gz.Close()
+
+	return io.ReadAll(io.LimitReader(gz, MaxBodySize))
+}
+
+// Parse the sitemap or sitemap index document
+func Parse(body []byte) (*Document, error) {
+
+	doc := &Document{}
+	if err := xml.Unmarshal(bytes.TrimSpace(body), doc); err != nil {
+		return nil, err
+	}
+
+	switch doc.XMLName.Local {
+	case "urlset", "sitemapindex":
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("unexpected sitemap root element <%s>", doc.XMLName.Local)
+}
+
+// ParseRobots returns `Sitemap:` values of the robots.txt
+func ParseRobots(body []byte) []string {
+
+	sitemaps := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(scanner.Text())
+
+		key, value, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(key), "sitemap") {
+			continue
+		}
+
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			sitemaps = append(sitemaps, value)
+		}
+	}
+
+	return sitemaps
+}
+
+// Entries of the <urlset>
+func (doc *Document) entries() []Entry {
+
+	entries := make([]Entry, 0, len(doc.URLs))
+
+	for _, u := range doc.URLs {
+
+		loc := strings.TrimSpace(u.Loc)
+		if len(loc) == 0 {
+			continue
+		}
+
+		entry := Entry{
+			Loc:             loc,
+			LastMod:         strings.TrimSpace(u.LastMod),
+			PublicationDate: strings.TrimSpace(u.News.PublicationDate),
+			NewsTitle:       strings.TrimSpace(u.News.Title),
+		}
+
+		for _, img := range u.Images {
+			if src := strings.TrimSpace(img.Loc); len(src) > 0 {
+				entry.Images = append(entry.Images, src)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// Context values of the entry, empty values are skipped
+func (e Entry) Context() map[string]string {
+
+	ctx := map[string]string{}
+
+	if len(e.LastMod) > 0 {
+		ctx[LastModCtx] = e.LastMod
+	}
+
+	if len(e.PublicationDate) > 0 {
+		ctx[PublicationDateCtx] = e.PublicationDate
+	}
+
+	if len(e.NewsTitle) > 0 {
+		ctx[NewsTitleCtx] = e.NewsTitle
+	}
+
+	if len(e.Images) > 0 {
+		ctx[ImagesCtx] = strings.Join(e.Images, ",")
+	}
+
+	return ctx
+}
@@ -0,0 +1,173 @@
+package sitemap_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/editorpost/spider/collect/sitemap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	sitemapIndex = `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<sitemap><loc>{host}/sitemap-news.xml.gz</loc></sitemap>
+	<sitemap><loc>{host}/sitemap-pages.xml</loc></sitemap>
+	<sitemap><loc>{host}/sitemap.xml</loc></sitemap>
+</sitemapindex>`
+
+	sitemapNews = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+	xmlns:news="http://www.google.com/schemas/sitemap-news/0.9"
+	xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+	<url>
+		<loc>{host}/news/article-1.html</loc>
+		<lastmod>2024-05-01T10:00:00Z</lastmod>
+		<news:news>
+			<news:publication>
+				<news:name>Example</news:name>
+				<news:language>en</news:language>
+			</news:publication>
+			<news:publication_date>2024-05-01T09:00:00Z</news:publication_date>
+			<news:title>Article One</news:title>
+		</news:news>
+		<image:image><image:loc>{host}/img/1.jpg</image:loc></image:image>
+		<image:image><image:loc>{host}/img/2.jpg</image:loc></image:image>
+	</url>
+</urlset>`
+
+	sitemapPages = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<url><loc>{host}/about.html</loc><lastmod>2023-01-01</lastmod></url>
+	<url><loc> </loc></url>
+</urlset>`
+
+	robots = `User-agent: *
+Disallow: /private/
+sitemap: {host}/sitemap.xml
+Sitemap:   {host}/sitemap-news.xml.gz
+`
+)
+
+func TestLoader_Load(t *testing.T) {
+
+	srv := NewSitemapServer(t)
+	defer srv.Close()
+
+	entries := sitemap.NewLoader(srv.Client(), "test-agent").Load(srv.URL + "/sitemap.xml")
+	require.Len(t, entries, 2)
+
+	news := entries[0]
+	assert.Equal(t, srv.URL+"/news/article-1.html", news.Loc)
+	assert.Equal(t, "2024-05-01T10:00:00Z", news.LastMod)
+	assert.Equal(t, "2024-05-01T09:00:00Z", news.PublicationDate)
+	assert.Equal(t, "Article One", news.NewsTitle)
+	assert.Equal(t, []string{srv.URL + "/img/1.jpg", srv.URL + "/img/2.jpg"}, news.Images)
+
+	ctx := news.Context()
+	assert.Equal(t, "2024-05-01T10:00:00Z", ctx[sitemap.LastModCtx])
+	assert.Equal(t, "2024-05-01T09:00:00Z", ctx[sitemap.PublicationDateCtx])
+
+	page := entries[1]
+	assert.Equal(t, srv.URL+"/about.html", page.Loc)
+	assert.NotContains(t, page.Context(), sitemap.PublicationDateCtx)
+}
+
+func TestLoader_LoadBroken(t *testing.T) {
+
+	srv := NewSitemapServer(t)
+	defer srv.Close()
+
+	entries := sitemap.NewLoader(srv.Client(), "").Load(srv.URL+"/not-found.xml", srv.URL+"/robots.txt")
+	assert.Empty(t, entries)
+}
+
+func TestLoader_Discover(t *testing.T) {
+
+	srv := NewSitemapServer(t)
+	defer srv.Close()
+
+	sitemaps, err := sitemap.NewLoader(srv.Client(), "").Discover(srv.URL + "/some/page.html")
+	require.NoError(t, err)
+	assert.Equal(t, []string{srv.URL + "/sitemap.xml", srv.URL + "/sitemap-news.xml.gz"}, sitemaps)
+}
+
+func TestDecompress(t *testing.T) {
+
+	plain := []byte("<urlset></urlset>")
+
+	got, err := sitemap.Decompress(bytes.NewReader(plain))
+	require.NoError(t, err)
+	assert.Equal(t, plain, got)
+
+	got, err = sitemap.Decompress(bytes.NewReader(Gzip(t, plain)))
+	require.NoError(t, err)
+	assert.Equal(t, plain, got)
+
+	got, err = sitemap.Decompress(bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestParse(t *testing.T) {
+
+	_, err := sitemap.Parse([]byte(`<html><body></body></html>`))
+	assert.Error(t, err)
+
+	_, err = sitemap.Parse([]byte(`not xml`))
+	assert.Error(t, err)
+
+	doc, err := sitemap.Parse([]byte(sitemapPages))
+	require.NoError(t, err)
+	assert.Len(t, doc.URLs, 2)
+}
+
+func NewSitemapServer(t *testing.T) *httptest.Server {
+
+	t.Helper()
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		replace := func(s string) []byte {
+			return bytes.ReplaceAll([]byte(s), []byte("{host}"), []byte(srv.URL))
+		}
+
+		var body []byte
+
+		switch r.URL.Path {
+		case "/robots.txt":
+			body = replace(robots)
+		case "/sitemap.xml":
+			body = replace(sitemapIndex)
+		case "/sitemap-news.xml.gz":
+			body = Gzip(t, replace(sitemapNews))
+		case "/sitemap-pages.xml":
+			body = replace(sitemapPages)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write(body)
+	}))
+
+	return srv
+}
+
+func Gzip(t *testing.T, data []byte) []byte {
+
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
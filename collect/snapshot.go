@@ -0,0 +1,76 @@
+package collect
+
+import (
+	"context"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/gocolly/colly/v2"
+	"log/slog"
+)
+
+// snapshot of the entity page rendered by the tab, the full-page PNG and the optional PDF
+// are kept until the response is passed to the extraction
+func (crawler *Crawler) snapshot(ctx context.Context, reqURL string) {
+
+	if !crawler.snapshotEnabled() {
+		return
+	}
+
+	// the page is extracted if ExtractURLs is not set
+	if len(crawler.args.ExtractURLs) > 0 && !crawler.entities.Match(reqURL) {
+		return
+	}
+
+	snap := &pipe.Snapshot{}
+
+	tasks := chromedp.Tasks{
+		// quality 100 is png
+		chromedp.FullScreenshot(&snap.PNG, 100),
+	}
+
+	if crawler.deps.Snapshot.PDF {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) (err error) {
+			snap.PDF, _, err = page.PrintToPDF().WithPrintBackground(true).Do(ctx)
+			return err
+		}))
+	}
+
+	// the page is extracted without the snapshot
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		slog.Warn("browser snapshot failed",
+			slog.String("url", reqURL),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	crawler.snapshots.Store(reqURL, snap)
+}
+
+// withSnapshots passes the snapshots captured by the transport to the request context of the extraction
+func (crawler *Crawler) withSnapshots() {
+
+	if !crawler.snapshotEnabled() {
+		return
+	}
+
+	// validated by the dispatcher
+	crawler.entities, _ = config.NewPatterns(crawler.args.ExtractURLs...)
+
+	crawler.collect.OnResponse(func(r *colly.Response) {
+		if snap, ok := crawler.snapshots.LoadAndDelete(r.Request.URL.String()); ok {
+			r.Ctx.Put(pipe.SnapshotCtx, snap)
+		}
+	})
+
+	crawler.collect.OnError(func(r *colly.Response, _ error) {
+		crawler.snapshots.Delete(r.Request.URL.String())
+	})
+}
+
+// snapshotEnabled in browser mode only
+func (crawler *Crawler) snapshotEnabled() bool {
+	return crawler.args.UseBrowser && crawler.deps.Snapshot != nil && crawler.deps.Snapshot.Enabled
+}
@@ -0,0 +1,244 @@
+package collect
+
+import (
+	"context"
+	"errors"
+	"github.com/chromedp/cdproto/inspector"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/proxy"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+)
+
+const (
+	// TabOpen is the metrics event of the new tab
+	TabOpen = "open"
+	// TabAcquire is the metrics event of the tab taken for the page
+	TabAcquire = "acquire"
+	// TabRecycle is the metrics event of the tab closed after the failure, crash or deadline
+	TabRecycle = "recycle"
+	// TabMaxUses is the number of pages after which the tab is closed to free the memory
+	TabMaxUses = 100
+)
+
+// ErrTabCrashed is the error of the page browsed by the crashed tab
+var ErrTabCrashed = errors.New("browser tab crashed")
+
+type (
+	// TabPool is the bounded pool of reusable browser tabs on one allocator
+	TabPool struct {
+		alloc   context.Context
+		block   *config.BrowserBlock
+		proxies *proxy.Pool
+		monitor config.Metrics
+		// headers of the host added to the tab requests, e.g. auth token
+		headers func(host string) http.Header
+		// idle tabs
+		idle chan *Tab
+		// slots limits the number of open tabs
+		slots chan struct{}
+		busy  atomic.Int32
+	}
+
+	// Tab is the browser tab with the network monitor and the requests blocker
+	Tab struct {
+		ctx     context.Context
+		cancel  context.CancelFunc
+		net     *networkMonitor
+		block   *blocker
+		proxy   *proxy.Proxy
+		uses    int
+		crashed atomic.Bool
+	}
+)
+
+// NewTabPool of the given size on the chromedp browser context,
+// requests of the tabs are blocked by the block config.
+// If proxies are set, each tab is opened in the own browser context with the next proxy of the pool,
+// so the proxy is changed with the tab recycled on failure.
+func NewTabPool(alloc context.Context, size int, block *config.BrowserBlock, proxies *proxy.Pool, monitor config.Metrics) *TabPool {
+
+	if size < 1 {
+		size = 1
+	}
+
+	return &TabPool{
+		alloc:   alloc,
+		block:   block,
+		proxies: proxies,
+		monitor: monitor,
+		idle:    make(chan *Tab, size),
+		slots:   make(chan struct{}, size),
+	}
+}
+
+// WithHeaders of the host added to the requests of the new tabs
+func (p *TabPool) WithHeaders(headers func(host string) http.Header) *TabPool {
+	p.headers = headers
+	return p
+}
+
+// Acquire the idle tab or open the new one, blocks if all tabs are busy
+func (p *TabPool) Acquire(ctx context.Context) (*Tab, error) {
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tab, err := p.tab()
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+
+	p.monitor.OnBrowserTab(TabAcquire, int(p.busy.Add(1)))
+
+	return tab, nil
+}
+
+// Release the tab to the pool, the tab is closed if the page failed, e.g. deadline exceeded
+func (p *TabPool) Release(tab *Tab, err error) {
+
+	defer func() { <-p.slots }()
+
+	busy := int(p.busy.Add(-1))
+	tab.uses++
+
+	if err != nil || tab.crashed.Load() || tab.ctx.Err() != nil || tab.uses >= TabMaxUses {
+
+		tab.cancel()
+
+		if err != nil || tab.crashed.Load() {
+			slog.Debug("browser tab recycled", slog.Bool("crashed", tab.crashed.Load()))
+			p.monitor.OnBrowserTab(TabRecycle, busy)
+		}
+
+		return
+	}
+
+	p.idle <- tab
+}
+
+// Close the idle tabs
+func (p *TabPool) Close() {
+	for {
+		select {
+		case tab := <-p.idle:
+			tab.cancel()
+		default:
+			return
+		}
+	}
+}
+
+// tab from the idle ones or the new one
+func (p *TabPool) tab() (*Tab, error) {
+
+	select {
+	case tab := <-p.idle:
+		return tab, nil
+	default:
+	}
+
+	opts, next, err := p.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := chromedp.NewContext(p.alloc, opts...)
+
+	tab := &Tab{
+		ctx:    ctx,
+		cancel: cancel,
+		proxy:  next,
+		// listeners live with the tab, so the monitor is reused
+		net:   listenNetwork(ctx),
+		block: newBlocker(p.block, proxyAuth(next), p.headers),
+	}
+
+	tab.block.listen(ctx)
+
+	chromedp.ListenTarget(ctx, func(ev any) {
+		if _, ok := ev.(*inspector.EventTargetCrashed); ok {
+			tab.crashed.Store(true)
+		}
+	})
+
+	// open the tab
+	if err := chromedp.Run(ctx, tab.block.enable()); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	p.monitor.OnBrowserTab(TabOpen, int(p.busy.Load()))
+
+	return tab, nil
+}
+
+// proxy of the new tab, the proxy server is set per browser context
+func (p *TabPool) proxy() ([]chromedp.ContextOption, *proxy.Proxy, error) {
+
+	if p.proxies == nil {
+		return nil, nil, nil
+	}
+
+	next, err := p.proxies.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opt := chromedp.WithNewBrowserContext(func(params *target.CreateBrowserContextParams) *target.CreateBrowserContextParams {
+		// credentials are answered by the blocker on the auth challenge
+		return params.WithProxyServer(next.String())
+	})
+
+	return []chromedp.ContextOption{opt}, next, nil
+}
+
+// Feedback of the tab navigation to the proxy pool, returns proxy.ErrBadProxy if the proxy failed
+func (p *TabPool) Feedback(tab *Tab, success bool) error {
+
+	if p.proxies == nil || tab.proxy == nil {
+		return nil
+	}
+
+	return p.proxies.Feedback(tab.proxy, success)
+}
+
+// Context of the tab cancelled with the ctx, the cancellation doesn't close the tab
+func (tab *Tab) Context(ctx context.Context) (context.Context, context.CancelFunc) {
+
+	// derived context keeps the chromedp target
+	tabCtx, cancel := context.WithCancel(tab.ctx)
+	stop := context.AfterFunc(ctx, cancel)
+
+	return tabCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// Err is ErrTabCrashed if the tab crashed or the err as is
+func (tab *Tab) Err(err error) error {
+
+	if tab.crashed.Load() {
+		return ErrTabCrashed
+	}
+
+	return err
+}
+
+// ProxyURL of the tab, empty if the tab is not proxied
+func (tab *Tab) ProxyURL() string {
+
+	if tab.proxy == nil {
+		return ""
+	}
+
+	return tab.proxy.String()
+}
@@ -0,0 +1,224 @@
+// Package trap detects the crawler traps of the queued urls: infinite calendars,
+// repeated path segments, session ids and parameter explosions.
+package trap
+
+import (
+	"errors"
+	"fmt"
+	"github.com/editorpost/spider/collect/config"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// Template trap is the path template over the distinct urls cap, e.g. infinite calendar
+	Template = "template"
+	// Repeat trap is the path segment repeated, e.g. /a/b/a/b/a
+	Repeat = "repeat"
+	// Query trap is the path over the query strings cap, e.g. session ids or filters
+	Query = "query"
+)
+
+// ErrTrap is the error of the url not queued by the detector
+var ErrTrap = errors.New("crawler trap")
+
+var (
+	// digits is the numeric path segment, e.g. year or id
+	digits = regexp.MustCompile(`^\d+$`)
+	// date is the numeric date segment, e.g. 2024-01-01
+	date = regexp.MustCompile(`^\d+([-_.]\d+)+$`)
+	// hex is the hash or uuid segment
+	hex = regexp.MustCompile(`^[0-9a-fA-F]{8,}(-[0-9a-fA-F]{4,})*$`)
+	// session is the generated token without separators, e.g. session id
+	session = regexp.MustCompile(`^[A-Za-z0-9]{24,}$`)
+	// letter of the session token
+	letter = regexp.MustCompile(`[A-Za-z]`)
+)
+
+// Detector of the crawler traps, safe for concurrent use.
+// The offending pattern is quarantined and logged once with the suggested DisallowedURLs rule.
+type Detector struct {
+	args     *config.Traps
+	entities config.Patterns
+	monitor  config.Metrics
+	mute     *sync.Mutex
+	// templates are the distinct urls per path template
+	templates map[string]map[string]bool
+	// queries are the distinct query strings per path
+	queries map[string]map[string]bool
+	// quarantined patterns by the template or the path
+	quarantined map[string]bool
+	// suggested rules logged
+	suggested map[string]bool
+}
+
+// NewDetector of the traps, the entity urls matching the patterns are not capped by the template and the query
+func NewDetector(args *config.Traps, entities config.Patterns, monitor config.Metrics) *Detector {
+
+	return &Detector{
+		args:        args,
+		entities:    entities,
+		monitor:     monitor,
+		mute:        &sync.Mutex{},
+		templates:   make(map[string]map[string]bool),
+		queries:     make(map[string]map[string]bool),
+		quarantined: make(map[string]bool),
+		suggested:   make(map[string]bool),
+	}
+}
+
+// Check the url before queueing, returns ErrTrap if the url is the trap or the pattern is quarantined
+func (d *Detector) Check(u *url.URL) error {
+
+	uri := u.String()
+
+	d.mute.Lock()
+	defer d.mute.Unlock()
+
+	if rule, found := d.repeat(u); found {
+		return d.reject(uri, Repeat, rule)
+	}
+
+	// entities are not capped, e.g. /article?id=N
+	if d.entities.Match(uri) {
+		return nil
+	}
+
+	path := PathKey(u)
+	if len(u.RawQuery) > 0 {
+		if d.quarantined[path] || d.over(d.queries, path, u.RawQuery, d.args.MaxQueryCombinations) {
+			d.quarantined[path] = true
+			return d.reject(uri, Query, literal(path+"?")+"{any}")
+		}
+	}
+
+	template := PathTemplate(u)
+	if d.quarantined[template] || d.over(d.templates, template, path, d.args.MaxTemplateURLs) {
+		d.quarantined[template] = true
+		return d.reject(uri, Template, template+"{any}")
+	}
+
+	return nil
+}
+
+// over returns true if the value is the next distinct one of the key over the limit
+func (d *Detector) over(sets map[string]map[string]bool, key, value string, limit int) bool {
+
+	set, ok := sets[key]
+	if !ok {
+		set = make(map[string]bool)
+		sets[key] = set
+	}
+
+	if set[value] {
+		return false
+	}
+
+	if len(set) >= limit {
+		// the values are not needed after the quarantine
+		delete(sets, key)
+		return true
+	}
+
+	set[value] = true
+
+	return false
+}
+
+// repeat returns the rule of the path segment found the max repeats times,
+// the rule disallows the path after the previous repeat, e.g. https://example.com/a/b/a/{any}
+func (d *Detector) repeat(u *url.URL) (string, bool) {
+
+	counts := make(map[string]int)
+	// previous is the path prefix of the last occurrence of the segment
+	previous := make(map[string]string)
+	prefix := u.Scheme + "://" + u.Host
+
+	for _, segment := range strings.Split(u.EscapedPath(), "/") {
+
+		if len(segment) == 0 {
+			continue
+		}
+
+		prefix += "/" + segment
+		counts[segment]++
+
+		if counts[segment] >= d.args.MaxSegmentRepeats {
+			return literal(previous[segment]+"/") + "{any}", true
+		}
+
+		previous[segment] = prefix
+	}
+
+	return "", false
+}
+
+// reject the url of the trap, the rule is logged once
+func (d *Detector) reject(uri, reason, rule string) error {
+
+	d.monitor.OnTrap(uri, reason)
+
+	if !d.suggested[rule] {
+		d.suggested[rule] = true
+		slog.Warn("trap: pattern quarantined",
+			slog.String("reason", reason),
+			slog.String("url", uri),
+			slog.String("suggest", fmt.Sprintf("add %q to DisallowedURLs", rule)),
+		)
+	} else {
+		slog.Debug("trap: skipped", slog.String("reason", reason), slog.String("url", uri))
+	}
+
+	return fmt.Errorf("%w %s: %s", ErrTrap, reason, uri)
+}
+
+// PathKey of the url: scheme, host and path without the query
+func PathKey(u *url.URL) string {
+	return u.Scheme + "://" + u.Host + u.EscapedPath()
+}
+
+// PathTemplate of the url in the RegexPattern placeholders without the query:
+// numeric segments are {num} and generated tokens, e.g. dates or session ids, are {dir},
+// e.g. https://example.com/archive/{num}/{num}/{num} for https://example.com/archive/2024/01/01
+func PathTemplate(u *url.URL) string {
+
+	segments := strings.Split(u.EscapedPath(), "/")
+
+	for i, segment := range segments {
+		switch {
+		case len(segment) == 0:
+		case digits.MatchString(segment):
+			segments[i] = "{num}"
+		case IsToken(segment):
+			segments[i] = "{dir}"
+		default:
+			segments[i] = literal(segment)
+		}
+	}
+
+	return literal(u.Scheme+"://"+u.Host) + strings.Join(segments, "/")
+}
+
+// IsToken returns true for the generated path segment: date, hash, uuid or session id.
+// Slugs with numbers, e.g. iphone-15-review, are not tokens.
+func IsToken(segment string) bool {
+
+	if date.MatchString(segment) {
+		return true
+	}
+
+	// the hash has digits, e.g. not the "facade" word
+	if hex.MatchString(segment) && strings.ContainsAny(segment, "0123456789") {
+		return true
+	}
+
+	return session.MatchString(segment) && strings.ContainsAny(segment, "0123456789") && letter.MatchString(segment)
+}
+
+// literal of the RegexPattern: regex characters are escaped, the dots are escaped by the RegexPattern
+func literal(s string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(s), `\.`, ".")
+}
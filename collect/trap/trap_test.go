@@ -0,0 +1,119 @@
+package trap_test
+
+import (
+	"fmt"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/trap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestPathTemplate(t *testing.T) {
+
+	tests := []struct {
+		uri      string
+		template string
+	}{
+		{"https://example.com/archive/2024/01/01", "https://example.com/archive/{num}/{num}/{num}"},
+		{"https://example.com/s/0a1b2c3d4e5f6a7b/news", "https://example.com/s/{dir}/news"},
+		{"https://example.com/news/2024-01-01?page=2", "https://example.com/news/{dir}"},
+		{"https://example.com/about", "https://example.com/about"},
+		{"https://example.com/reviews/iphone-15-review", "https://example.com/reviews/iphone-15-review"},
+		{"https://example.com/files/3f2504e0-4f89-11d3-9a0c-0305e82c3301", "https://example.com/files/{dir}"},
+		{"https://example.com/sid/a8Kx72mQp1Zr9TbW4nYc6VdL", "https://example.com/sid/{dir}"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.template, trap.PathTemplate(mustURL(t, tt.uri)), tt.uri)
+	}
+}
+
+func TestDetector(t *testing.T) {
+
+	args := &config.Traps{MaxTemplateURLs: 3, MaxSegmentRepeats: 3, MaxQueryCombinations: 2}
+	require.NoError(t, args.Normalize())
+
+	entities, err := config.NewPatterns("https://example.com/news/{num}.html", "https://example.com/article{any}")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		urls   []string
+		trap   string
+		reason string
+		rule   string
+	}{
+		{
+			name:   "calendar",
+			urls:   []string{"/archive/2024/01/01", "/archive/2024/01/02", "/archive/2024/01/03", "/archive/2024/01/02"},
+			trap:   "/archive/2024/01/04",
+			reason: trap.Template,
+			rule:   "https://example.com/archive/{num}/{num}/{num}{any}",
+		},
+		{
+			name:   "repeated segments",
+			urls:   []string{"/a/b", "/a/b/a/b"},
+			trap:   "/a/b/a/b/a",
+			reason: trap.Repeat,
+			rule:   "https://example.com/a/b/a/{any}",
+		},
+		{
+			name:   "session ids",
+			urls:   []string{"/list?sid=1", "/list?sid=2", "/list?sid=1"},
+			trap:   "/list?sid=3",
+			reason: trap.Query,
+			rule:   `https://example.com/list\?{any}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			detector := trap.NewDetector(args, entities, &config.MetricsFallback{})
+
+			for _, uri := range tt.urls {
+				require.NoError(t, detector.Check(mustURL(t, "https://example.com"+uri)), uri)
+			}
+
+			err := detector.Check(mustURL(t, "https://example.com"+tt.trap))
+			require.ErrorIs(t, err, trap.ErrTrap)
+			assert.Contains(t, err.Error(), tt.reason)
+
+			// the suggested rule disallows the trap
+			assert.Regexp(t, regexp.MustCompile(config.RegexPattern(tt.rule)), "https://example.com"+tt.trap)
+			assert.NotRegexp(t, regexp.MustCompile(config.RegexPattern(tt.rule)), "https://example.com/")
+		})
+	}
+
+	t.Run("quarantined", func(t *testing.T) {
+
+		detector := trap.NewDetector(args, entities, &config.MetricsFallback{})
+
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, detector.Check(mustURL(t, fmt.Sprintf("https://example.com/archive/%d", i))))
+		}
+		require.ErrorIs(t, detector.Check(mustURL(t, "https://example.com/archive/4")), trap.ErrTrap)
+
+		// the urls of the template queued before are skipped as well
+		require.ErrorIs(t, detector.Check(mustURL(t, "https://example.com/archive/1")), trap.ErrTrap)
+	})
+
+	t.Run("entities", func(t *testing.T) {
+
+		detector := trap.NewDetector(args, entities, &config.MetricsFallback{})
+
+		for i := 1; i <= 10; i++ {
+			require.NoError(t, detector.Check(mustURL(t, fmt.Sprintf("https://example.com/news/%d.html", i))))
+			require.NoError(t, detector.Check(mustURL(t, fmt.Sprintf("https://example.com/article?id=%d", i))))
+		}
+	})
+}
+
+func mustURL(t *testing.T, uri string) *url.URL {
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	return u
+}
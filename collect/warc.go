@@ -0,0 +1,128 @@
+package collect
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/gocolly/colly/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WARCHeader passes the record of the response from the transport to the collector, removed by the collector
+const WARCHeader = "X-Spider-Warc"
+
+// WARCTransport writes the raw http exchange of the next transport to the WARC file:
+// the request with the headers sent, e.g. the cookies of the jar, and the response body as received.
+// The page rendered by the browser is not the http response, it is written as the resource record.
+type WARCTransport struct {
+	writer   config.WARCWriter
+	next     http.RoundTripper
+	rendered bool
+}
+
+// NewWARCTransport of the writer, rendered is true for the browser transport
+func NewWARCTransport(writer config.WARCWriter, next http.RoundTripper, rendered bool) *WARCTransport {
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &WARCTransport{
+		writer:   writer,
+		next:     next,
+		rendered: rendered,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *WARCTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	// the body is archived compressed as received, the collector decodes gzip
+	if !t.rendered && len(req.Header.Get("Accept-Encoding")) == 0 {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var ref *pipe.WARCRef
+	if t.rendered {
+		ref, err = t.writer.Resource(req.URL.String(), resp.Header.Get("Content-Type"), body)
+	} else {
+		ref, err = t.writer.Write(req, resp, body)
+	}
+
+	// the crawl goes on, the page is not archived
+	if err != nil {
+		slog.Warn("warc: write failed",
+			slog.String("url", req.URL.String()),
+			slog.String("error", err.Error()),
+		)
+		return resp, nil
+	}
+
+	resp.Header.Set(WARCHeader, fmt.Sprintf("%d %s", ref.Offset, ref.File))
+
+	return resp, nil
+}
+
+// withWARC writes every fetched response to the WARC file by the transport,
+// the record of the page is kept in the request context for the payload
+func (crawler *Crawler) withWARC() {
+
+	if crawler.deps.WARC == nil {
+		return
+	}
+
+	crawler.collect.WithTransport(NewWARCTransport(crawler.deps.WARC, crawler.cached(crawler.transport()), crawler.args.UseBrowser))
+
+	crawler.collect.OnResponse(crawler.warc)
+
+	// error statuses are fetched responses as well
+	crawler.collect.OnError(func(r *colly.Response, _ error) {
+		if r.StatusCode > 0 {
+			crawler.warc(r)
+		}
+	})
+}
+
+// warc record of the response set by the transport
+func (crawler *Crawler) warc(r *colly.Response) {
+
+	if r.Headers == nil {
+		return
+	}
+
+	value := r.Headers.Get(WARCHeader)
+	r.Headers.Del(WARCHeader)
+
+	offset, file, found := strings.Cut(value, " ")
+	if !found {
+		return
+	}
+
+	ref := &pipe.WARCRef{File: file}
+
+	var err error
+	if ref.Offset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+		return
+	}
+
+	r.Ctx.Put(pipe.WARCCtx, ref)
+}
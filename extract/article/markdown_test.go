@@ -36,7 +36,7 @@ func TestHTMLToMarkdownImage(t *testing.T) {
 func TestHTMLToMarkdownImageFromMany(t *testing.T) {
 
 	doc := tester.GetDocument(t, "../../tester/fixtures/cases/must_article_title.html")
-	selections := events.Selections(doc, ".node-article--full", nil)
+	selections := events.Selections(doc, ".node-article--full")
 	require.Greater(t, len(selections), 0)
 
 	in, err := selections[0].Html()
@@ -3,6 +3,7 @@ package extract
 import (
 	"github.com/editorpost/spider/extract/fields"
 	"github.com/editorpost/spider/extract/media"
+	"github.com/editorpost/spider/extract/pipe"
 )
 
 type Config struct {
@@ -16,6 +17,9 @@ type Config struct {
 	// If true, then existing payloads urls loaded from db
 	// If false, payloads are extracted from the page and stored in db without unique check
 	ExtractOnce bool `json:"ExtractOnce"`
+	// Snapshot captures the screenshot and PDF of the extracted page in browser mode,
+	// files are saved next to the payload
+	Snapshot pipe.SnapshotConfig `json:"Snapshot"`
 }
 
 func (c *Config) Normalize() error {
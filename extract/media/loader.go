@@ -2,6 +2,7 @@ package media
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"hash/fnv"
@@ -22,6 +23,7 @@ type (
 		pool         sync.Pool
 		store        Store
 		client       *http.Client
+		header       func() http.Header
 		skipLessThan int
 	}
 )
@@ -45,12 +47,19 @@ func (dl *Loader) SetClient(client *http.Client) {
 	dl.client = client
 }
 
+// SetHeader sets the function returning the headers of the media request,
+// e.g. the user agent and Accept-Language of the crawler.
+func (dl *Loader) SetHeader(header func() http.Header) {
+	dl.header = header
+}
+
 // Download fetches the media from the specified Endpoint and uploads it to the store.
 // Return http.ErrShortBody if the media is less than defined size in bytes.
-func (dl *Loader) Download(src, dst string) error {
+// The download is cancelled with the ctx.
+func (dl *Loader) Download(ctx context.Context, src, dst string) error {
 
 	// download
-	buf, err := dl.Fetch(src)
+	buf, err := dl.Fetch(ctx, src)
 	if err != nil {
 		return err
 	}
@@ -66,15 +75,24 @@ func (dl *Loader) Download(src, dst string) error {
 }
 
 // Fetch data from the specified Endpoint and return a buffer with the data.
-func (dl *Loader) Fetch(imageURL string) (*bytes.Buffer, error) {
+func (dl *Loader) Fetch(ctx context.Context, imageURL string) (*bytes.Buffer, error) {
 	// Parse the Endpoint to ensure it's valid.
 	parsedURL, err := url.Parse(imageURL)
 	if err != nil {
 		return nil, err
 	}
 
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if dl.header != nil {
+		req.Header = dl.header()
+	}
+
 	// Send the GET request.
-	resp, err := dl.client.Get(parsedURL.String())
+	resp, err := dl.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
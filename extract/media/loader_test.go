@@ -1,6 +1,7 @@
 package media_test
 
 import (
+	"context"
 	"fmt"
 	"github.com/editorpost/spider/extract/media"
 	"github.com/stretchr/testify/assert"
@@ -45,7 +46,7 @@ func TestDownloader_Download(t *testing.T) {
 	downloader := media.NewLoader(nil)
 	downloader.SetClient(ts.Client())
 
-	buf, err := downloader.Fetch(ts.URL)
+	buf, err := downloader.Fetch(context.Background(), ts.URL)
 	require.NoError(t, err)
 	defer downloader.ReleaseBuffer(buf)
 	DataAssert(t, buf.Bytes())
@@ -57,7 +58,32 @@ func TestDownloader_SetClient(t *testing.T) {
 	downloader := media.NewLoader(nil)
 	downloader.SetClient(server.Client())
 
-	buf, err := downloader.Fetch(server.URL)
+	buf, err := downloader.Fetch(context.Background(), server.URL)
+	require.NoError(t, err)
+	defer downloader.ReleaseBuffer(buf)
+	DataAssert(t, buf.Bytes())
+}
+
+func TestDownloader_SetHeader(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "spider" || r.Header.Get("Referer") != "https://example.com/" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(DataExpected())
+	}))
+	defer ts.Close()
+
+	downloader := media.NewLoader(nil)
+	_, err := downloader.Fetch(context.Background(), ts.URL)
+	require.ErrorIs(t, err, http.ErrMissingFile)
+
+	downloader.SetHeader(func() http.Header {
+		return http.Header{"User-Agent": {"spider"}, "Referer": {"https://example.com/"}}
+	})
+
+	buf, err := downloader.Fetch(context.Background(), ts.URL)
 	require.NoError(t, err)
 	defer downloader.ReleaseBuffer(buf)
 	DataAssert(t, buf.Bytes())
@@ -76,7 +102,7 @@ func BenchmarkDownloader_Download(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := downloader.Fetch(ts.URL)
+		_, err := downloader.Fetch(context.Background(), ts.URL)
 		require.NoError(b, err)
 	}
 }
@@ -89,7 +115,7 @@ func TestDownloader_Copy(t *testing.T) {
 
 	// Perform the download and upload.
 	path := "static/media/test.jpg"
-	require.NoError(t, downloader.Download(server.URL, path))
+	require.NoError(t, downloader.Download(context.Background(), server.URL, path))
 
 	// Assert the data was uploaded correctly.
 	uploadedData, exists := storage.data[path]
@@ -21,7 +21,7 @@ type (
 	}
 
 	Downloader interface {
-		Download(src, dst string) error
+		Download(ctx context.Context, src, dst string) error
 	}
 )
 
@@ -56,11 +56,16 @@ func (m *Media) Upload(payload *pipe.Payload) error {
 	// download source and upload to destination
 	for _, claim := range claims.All() {
 
+		// the run is cancelled, the rest of the claims is not uploaded
+		if err := payload.Ctx.Err(); err != nil {
+			return err
+		}
+
 		// trim base URL from the full chunked path
 		// note claim.DstPath doesn't have chunk part, it keeps path for {payloadID}/media/{imageID}.ext
 		dst := strings.TrimPrefix(claim.Dst, m.publicURL+"/")
 
-		if err := m.loader.Download(claim.Src, dst); err != nil {
+		if err := m.loader.Download(payload.Ctx, claim.Src, dst); err != nil {
 
 			if !errors.Is(err, http.ErrShortBody) {
 				slog.Info("skip small image", slog.String("claim.Src", claim.Src))
@@ -1,6 +1,7 @@
 package media_test
 
 import (
+	"context"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/editorpost/spider/extract/media"
@@ -24,7 +25,7 @@ func NewLoader() *Loader {
 }
 
 // Upload fetches the media from the specified Endpoint and uploads it to the store.
-func (dl *Loader) Download(src, dst string) error {
+func (dl *Loader) Download(_ context.Context, src, dst string) error {
 	dl.uploads.Store(dst, src)
 	return nil
 }
@@ -17,6 +17,8 @@ type (
 	History struct {
 		visitedURLs map[uint64]uint32
 		mute        *sync.RWMutex
+		// once is set by Init, the extracted payloads are recorded only if ExtractOnce
+		once bool
 	}
 )
 
@@ -31,13 +33,24 @@ func NewPayloadHistory() *History {
 }
 
 func (s *History) Init(loader func() []string) {
+	s.mute.Lock()
+	s.once = true
+	s.mute.Unlock()
 	for _, u := range loader() {
 		s.extracted(0, u)
 	}
 }
 
+// Extracted records the payload url, if the history is initialized
 func (s *History) Extracted(p *Payload) {
-	s.extracted(p.Doc.Request.ID, p.URL.String())
+
+	s.mute.RLock()
+	once := s.once
+	s.mute.RUnlock()
+
+	if once {
+		s.extracted(p.Doc.Request.ID, p.URL.String())
+	}
 }
 
 // IsExtracted used as starter pipeline processor
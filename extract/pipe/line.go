@@ -55,9 +55,12 @@ func (p *Pipeline) Extract(doc *colly.HTMLElement, s *goquery.Selection) (extrac
 		return false, fmt.Errorf("payload creation error: %w", err)
 	}
 
-	// check if payload is already extracted
-	if extracted, err = p.history.IsExtracted(payload); err != nil || extracted {
-		return
+	// check if payload is already extracted,
+	// the changed page is extracted again
+	if !payload.Revised {
+		if extracted, err = p.history.IsExtracted(payload); err != nil || extracted {
+			return
+		}
 	}
 
 	// set job id and provider
@@ -80,6 +83,14 @@ func (p *Pipeline) Extract(doc *colly.HTMLElement, s *goquery.Selection) (extrac
 		return
 	}
 
+	// skip the same canonical url on next requests, if ExtractOnce
+	p.history.Extracted(payload)
+
+	// the collector links the next revision of the page to the payload
+	if payload.Doc.Request.Ctx != nil {
+		payload.Doc.Request.Ctx.Put(PayloadIDCtx, payload.ID)
+	}
+
 	return true, nil
 }
 
@@ -87,6 +98,11 @@ func (p *Pipeline) exec(payload *Payload, extractors ...Extractor) error {
 
 	for _, extractor := range extractors {
 
+		// the run is cancelled, the payload is not finished
+		if err := payload.Ctx.Err(); err != nil {
+			return err
+		}
+
 		err := extractor(payload)
 
 		// stop the extractor chain if required data is missing
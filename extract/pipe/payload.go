@@ -15,8 +15,24 @@ const (
 	SpiderIDField = "spider__id"
 	HtmlField     = "spider__html"
 	UrlField      = "spider__url"
-	HostField     = "spider__host"
-	DateField     = "spider__date"
+	// OriginalUrlField is the requested url, UrlField is the canonical url
+	OriginalUrlField = "spider__original_url"
+	HostField        = "spider__host"
+	DateField        = "spider__date"
+	// PreviousIDField is the payload id extracted from the previous revision of the page
+	PreviousIDField = "spider__previous_id"
+
+	// CanonicalURLCtx is the request context key of the page canonical url
+	CanonicalURLCtx = "CanonicalURL"
+	// RevisedCtx is the request context key of the page changed since the previous run,
+	// the page is extracted again regardless of the extraction history if set.
+	RevisedCtx = "Revised"
+	// PreviousIDCtx is the request context key of the payload id extracted from the previous revision
+	PreviousIDCtx = "PreviousPayloadID"
+	// PayloadIDCtx is the request context key of the last payload id extracted from the page
+	PayloadIDCtx = "PayloadID"
+	// RunCtx is the request context key of the run context, the payload extractors are cancelled with it
+	RunCtx = "RunContext"
 )
 
 var (
@@ -29,7 +45,11 @@ type (
 	//goland:noinspection GoNameStartsWithPackageName
 	Payload struct {
 		// ID is document url hash
-		ID          string          `json:"ID"`
+		ID string `json:"ID"`
+		// PreviousID is the payload id extracted from the previous revision of the page
+		PreviousID string `json:"PreviousID"`
+		// Revised is true if the page changed since the previous run
+		Revised     bool            `json:"-"`
 		JobProvider string          `json:"JobProvider"`
 		JobID       string          `json:"JobID"`
 		Ctx         context.Context `json:"-"`
@@ -37,8 +57,14 @@ type (
 		Doc *colly.HTMLElement `json:"-"`
 		// Selection of entity in document
 		Selection *goquery.Selection `json:"-"`
-		// URL of the document
+		// URL of the document, canonical if provided by the collector
 		URL *url.URL `json:"-"`
+		// OriginalURL is the requested url of the document
+		OriginalURL *url.URL `json:"-"`
+		// Snapshot of the page captured by the browser, nil if not enabled
+		Snapshot *Snapshot `json:"-"`
+		// WARC is the response record of the page, nil if not written
+		WARC *WARCRef `json:"-"`
 		// Data is a map of extracted data
 		Data map[string]any `json:"Data"`
 	}
@@ -56,18 +82,81 @@ func NewPayload(doc *colly.HTMLElement, s *goquery.Selection) (*Payload, error)
 		return nil, fmt.Errorf("url FNV hash error: %w", err)
 	}
 
-	return &Payload{
-		ID:        id.String(),
-		Ctx:       context.Background(),
-		Doc:       doc,
-		Selection: s,
-		URL:       doc.Request.URL,
+	uri := CanonicalURL(doc.Request)
+
+	payload := &Payload{
+		ID:          id.String(),
+		PreviousID:  PreviousID(doc.Request),
+		Revised:     doc.Request.Ctx != nil && len(doc.Request.Ctx.Get(RevisedCtx)) > 0,
+		Ctx:         RunContext(doc.Request),
+		Doc:         doc,
+		Selection:   s,
+		URL:         uri,
+		OriginalURL: doc.Request.URL,
+		Snapshot:    SnapshotOf(doc.Request),
+		WARC:        WARCRefOf(doc.Request),
 		Data: map[string]any{
-			SpiderIDField: id,
-			DateField:     time.Now().UTC().String(),
-			HostField:     doc.Request.URL.Host,
-			UrlField:      doc.Request.URL.String(),
+			SpiderIDField:    id,
+			DateField:        time.Now().UTC().String(),
+			HostField:        uri.Host,
+			UrlField:         uri.String(),
+			OriginalUrlField: doc.Request.URL.String(),
 		},
 		// @todo: entity types, processors tags or ids
-	}, nil
+	}
+
+	if len(payload.PreviousID) > 0 {
+		payload.Data[PreviousIDField] = payload.PreviousID
+	}
+
+	if payload.WARC != nil {
+		payload.Data[WARCFileField] = payload.WARC.File
+		payload.Data[WARCOffsetField] = payload.WARC.Offset
+	}
+
+	return payload, nil
+}
+
+// RunContext of the request set by the collector, or the background one
+func RunContext(req *colly.Request) context.Context {
+
+	if req.Ctx == nil {
+		return context.Background()
+	}
+
+	if ctx, ok := req.Ctx.GetAny(RunCtx).(context.Context); ok {
+		return ctx
+	}
+
+	return context.Background()
+}
+
+// PreviousID of the payload extracted from the previous revision of the page
+func PreviousID(req *colly.Request) string {
+
+	if req.Ctx == nil {
+		return ""
+	}
+
+	return req.Ctx.Get(PreviousIDCtx)
+}
+
+// CanonicalURL of the request set by the collector, or the request url
+func CanonicalURL(req *colly.Request) *url.URL {
+
+	if req.Ctx == nil {
+		return req.URL
+	}
+
+	canonical := req.Ctx.Get(CanonicalURLCtx)
+	if len(canonical) == 0 {
+		return req.URL
+	}
+
+	u, err := url.Parse(canonical)
+	if err != nil {
+		return req.URL
+	}
+
+	return u
 }
@@ -19,7 +19,7 @@ func TestNewPayload(t *testing.T) {
 	doc.Request.URL, _ = url.Parse(gofakeit.URL())
 
 	// get selections
-	selections := events.Selections(doc, ".node-article--full", nil)
+	selections := events.Selections(doc, ".node-article--full")
 	require.Greater(t, len(selections), 0)
 
 	// create payload
@@ -30,3 +30,23 @@ func TestNewPayload(t *testing.T) {
 	assert.NoError(t, article.Article(pay))
 	assert.Greater(t, len(pay.Data), 0)
 }
+
+func TestNewPayload_Canonical(t *testing.T) {
+
+	doc := tester.GetDocument(t, "../../tester/fixtures/cases/must_article_title.html")
+	doc.Request.URL, _ = url.Parse("https://example.com/amp/1.html?utm_source=x")
+
+	// original url without canonical
+	pay, err := pipe.NewPayload(doc, doc.DOM)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/amp/1.html?utm_source=x", pay.URL.String())
+
+	doc.Request.Ctx.Put(pipe.CanonicalURLCtx, "https://example.com/news/1.html")
+
+	pay, err = pipe.NewPayload(doc, doc.DOM)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/news/1.html", pay.URL.String())
+	assert.Equal(t, "https://example.com/amp/1.html?utm_source=x", pay.OriginalURL.String())
+	assert.Equal(t, "https://example.com/news/1.html", pay.Data[pipe.UrlField])
+	assert.Equal(t, "https://example.com/amp/1.html?utm_source=x", pay.Data[pipe.OriginalUrlField])
+}
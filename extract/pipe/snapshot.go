@@ -0,0 +1,49 @@
+package pipe
+
+import (
+	"github.com/gocolly/colly/v2"
+)
+
+const (
+	// ScreenshotField is the storage path of the page screenshot
+	ScreenshotField = "spider__screenshot"
+	// PDFField is the storage path of the page PDF
+	PDFField = "spider__pdf"
+
+	// SnapshotCtx is the request context key of the page snapshot captured by the browser
+	SnapshotCtx = "Snapshot"
+)
+
+type (
+	// SnapshotConfig of the extracted pages, captured in browser mode only.
+	//
+	// JSON representation:
+	//
+	//	{"Enabled": true, "PDF": true}
+	SnapshotConfig struct {
+		// Enabled captures the full-page PNG screenshot of the extracted page
+		Enabled bool `json:"Enabled"`
+		// PDF captures the page PDF as well
+		PDF bool `json:"PDF"`
+	}
+
+	// Snapshot of the page rendered by the browser for provenance
+	Snapshot struct {
+		// PNG is the full-page screenshot
+		PNG []byte
+		// PDF is the printed page, empty if not enabled
+		PDF []byte
+	}
+)
+
+// SnapshotOf the request captured by the browser, nil if not captured
+func SnapshotOf(req *colly.Request) *Snapshot {
+
+	if req.Ctx == nil {
+		return nil
+	}
+
+	snap, _ := req.Ctx.GetAny(SnapshotCtx).(*Snapshot)
+
+	return snap
+}
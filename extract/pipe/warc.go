@@ -0,0 +1,35 @@
+package pipe
+
+import (
+	"github.com/gocolly/colly/v2"
+)
+
+const (
+	// WARCFileField is the WARC file of the page response record
+	WARCFileField = "spider__warc_file"
+	// WARCOffsetField is the offset of the page response record in the WARC file
+	WARCOffsetField = "spider__warc_offset"
+
+	// WARCCtx is the request context key of the page response record written to the WARC file
+	WARCCtx = "WARC"
+)
+
+// WARCRef is the response record of the page in the WARC file
+type WARCRef struct {
+	// File is the name of the WARC file in the storage
+	File string
+	// Offset of the response record in the file
+	Offset int64
+}
+
+// WARCRefOf the request written to the WARC file, nil if not written
+func WARCRefOf(req *colly.Request) *WARCRef {
+
+	if req.Ctx == nil {
+		return nil
+	}
+
+	ref, _ := req.Ctx.GetAny(WARCCtx).(*WARCRef)
+
+	return ref
+}
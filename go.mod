@@ -35,6 +35,7 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/samber/lo v1.47.0
 	github.com/stretchr/testify v1.10.0
+	github.com/temoto/robotstxt v1.1.2
 )
 
 require (
@@ -99,7 +100,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rs/zerolog v1.33.0 // indirect
 	github.com/saintfish/chardet v0.0.0-20230101081208-5e3ef4b5456d // indirect
-	github.com/temoto/robotstxt v1.1.2 // indirect
 	github.com/valyala/fastrand v1.1.0 // indirect
 	github.com/valyala/histogram v1.2.0 // indirect
 	github.com/windmill-labs/windmill-go-client v1.430.2 // indirect
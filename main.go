@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"github.com/editorpost/spider/manage/provider/windmill"
@@ -8,12 +9,15 @@ import (
 	_ "github.com/lib/pq"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 var (
 	fCmd    = flag.String("cmd", "", "Available commands: start, trial")
 	fSpider = flag.String("spider", "", "Spider arguments as JSON string")
 	fDeploy = flag.String("deploy", "", "Deploy arguments as JSON string")
+	fFresh  = flag.Bool("fresh", false, "Drop unfinished run of the spider instead of resuming it")
 )
 
 func main() {
@@ -25,7 +29,13 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = windmill.Command(cmd, spider); err != nil {
+	// the crawler drains the in-flight work on the first signal,
+	// the next signal kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	if err = windmill.Command(ctx, cmd, spider, *fFresh); err != nil {
 		slog.Error("cmd:"+cmd, slog.String("error", err.Error()))
 		return
 	}
@@ -1,13 +1,14 @@
 package console
 
 import (
+	"context"
 	"github.com/editorpost/spider/manage/setup"
 	"github.com/editorpost/spider/store"
 )
 
 // Check runs spider as usual, but with limited extract limit and storage paths.
 // It is used for testing spider configuration and extractors from the console, api or clients.
-func Check(spider *setup.Spider) (map[string]any, error) {
+func Check(ctx context.Context, spider *setup.Spider) (map[string]any, error) {
 
 	// force low hard-limit for check runs
 	if spider.Collect.ExtractLimit == 0 || spider.Collect.ExtractLimit > 30 {
@@ -17,9 +18,16 @@ func Check(spider *setup.Spider) (map[string]any, error) {
 	// replace actual storage paths with check storage paths
 	spider.Deploy.Paths = store.CheckStoragePaths()
 
-	// return check UUID and run the spider
+	// the check starts from the start urls
+	result, err := run(ctx, spider, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// return check UUID and the result of the run: the budget ended it and the link graph summary
 	return map[string]any{
 		"CheckID": spider.ID,
 		"Paths":   spider.Deploy.Paths,
-	}, Start(spider)
+		"Result":  result,
+	}, nil
 }
@@ -1,6 +1,7 @@
 package console_test
 
 import (
+	"context"
 	"github.com/editorpost/spider/manage/console"
 	"github.com/editorpost/spider/tester"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,6 @@ func TestCheck(t *testing.T) {
 	s := tester.NewSpiderWith(t, srv)
 	require.NotNil(t, s)
 
-	_, err := console.Check(s)
+	_, err := console.Check(context.Background(), s)
 	require.NoError(t, err)
 }
@@ -25,6 +25,13 @@ func Reset(spiderID string, deploy *setup.Deploy) error {
 		return err
 	}
 
+	// ResetQueue drops the crawl frontier of unfinished runs
+	if len(deploy.Database.Host) > 0 {
+		if err := store.DropQueueStorage(spiderID, deploy.Database.DSN()); err != nil {
+			return err
+		}
+	}
+
 	// ResetExtractor drops the extractor store
 	// Extracted data will be erased. All temporary data/images will be lost.
 	return store.DropExtractStorage(paths.PayloadRoot(spiderID), deploy.Storage)
@@ -1,21 +1,47 @@
 package console
 
 import (
+	"context"
+	"github.com/editorpost/spider/collect"
 	"github.com/editorpost/spider/manage/setup"
+	"log/slog"
 )
 
 // Start is a code for running spider
-// as Windmill Script with extract.Article
-func Start(s *setup.Spider) error {
+// as Windmill Script with extract.Article.
+// The unfinished run is continued from the persistent queue, the fresh flag drops it.
+// The run is stopped gracefully once the ctx is done, e.g. by SIGTERM.
+func Start(ctx context.Context, s *setup.Spider, fresh bool) error {
+	_, err := run(ctx, s, fresh)
+	return err
+}
+
+// run the spider, returns the budget result and the link graph summary of the run
+func run(ctx context.Context, s *setup.Spider, fresh bool) (*collect.Result, error) {
+
+	s.Fresh = fresh
+
+	// shutdown required by stores
+	// to finish writing queued data,
+	// run even if the crawler setup failed after some stores started
+	defer s.Shutdown()
 
 	crawler, err := s.NewCrawler()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// shutdown required by stores
-	// to finish writing queued data
-	defer s.Shutdown()
+	if err = crawler.Run(ctx); err != nil {
+		return nil, err
+	}
+
+	result := crawler.Result()
+	slog.Info("crawler finished",
+		slog.String("stopped", result.Stopped),
+		slog.Int64("requests", result.Requests),
+		slog.Int64("bytes", result.Bytes),
+		slog.String("duration", result.Duration.String()),
+	)
 
-	return crawler.Run()
+	return result, nil
 }
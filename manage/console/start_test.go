@@ -1,6 +1,7 @@
 package console_test
 
 import (
+	"context"
 	"github.com/editorpost/spider/manage/console"
 	"github.com/editorpost/spider/tester"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,6 @@ func TestStart(t *testing.T) {
 	require.NotNil(t, spider)
 
 	spider.Deploy = tester.TestDeploy(t)
-	err := console.Start(spider)
+	err := console.Start(context.Background(), spider, false)
 	require.NoError(t, err)
 }
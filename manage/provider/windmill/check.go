@@ -1,6 +1,7 @@
 package windmill
 
 import (
+	"context"
 	"github.com/editorpost/donq/pkg/vars"
 	"github.com/editorpost/spider/manage/console"
 	"github.com/editorpost/spider/manage/setup"
@@ -10,10 +11,10 @@ const JobResultFile = "./result.json"
 
 // Check spider against limited and return extracted data
 // It does not store the data, but uses proxy pool for requests.
-func Check(spider *setup.Spider) error {
+func Check(ctx context.Context, spider *setup.Spider) error {
 
 	// result contains check ID and storage paths
-	result, err := console.Check(spider)
+	result, err := console.Check(ctx, spider)
 	if err != nil {
 		return err
 	}
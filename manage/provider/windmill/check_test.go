@@ -1,6 +1,7 @@
 package windmill_test
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/editorpost/spider/manage/provider/windmill"
 	"github.com/editorpost/spider/tester"
@@ -18,7 +19,7 @@ func TestTrial(t *testing.T) {
 	s := tester.NewSpiderWith(t, srv)
 	require.NotNil(t, s)
 
-	require.NoError(t, windmill.Check(s))
+	require.NoError(t, windmill.Check(context.Background(), s))
 
 	// read results.json
 	f, err := os.ReadFile(windmill.JobResultFile)
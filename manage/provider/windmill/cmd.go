@@ -1,20 +1,21 @@
 package windmill
 
 import (
+	"context"
 	"github.com/editorpost/spider/manage/console"
 	"github.com/editorpost/spider/manage/setup"
 )
 
-func Command(cmd string, s *setup.Spider) (err error) {
+func Command(ctx context.Context, cmd string, s *setup.Spider, fresh bool) (err error) {
 
 	switch cmd {
 
 	case "start":
-		return console.Start(s)
+		return console.Start(ctx, s, fresh)
 	case "validate":
 		return console.Validate(s)
 	case "check":
-		return Check(s)
+		return Check(ctx, s)
 	}
 
 	return nil
@@ -9,16 +9,25 @@ import (
 	"log/slog"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const (
-	RequestEvent    = "request"
-	RetryEvent      = "retry"
-	ErrorEvent      = "error"
-	ScrapedEvent    = "scraped"
-	ExtractionEvent = "extracted"
-	ResponseEvent   = "response"
+	RequestEvent      = "request"
+	RetryEvent        = "retry"
+	ErrorEvent        = "error"
+	ScrapedEvent      = "scraped"
+	ExtractionEvent   = "extracted"
+	ResponseEvent     = "response"
+	RobotsEvent       = "robots_skip"
+	DisallowedEvent   = "disallowed"
+	NotModifiedEvent  = "not_modified"
+	BrowserTabEvent   = "browser_tab"
+	BlockedEvent      = "browser_blocked"
+	RetryOutcomeEvent = "retry_outcome"
+	BudgetEvent       = "budget"
+	TrapEvent         = "trap"
 
 	StartTimeCtx = "metrics-request-start-time"
 )
@@ -102,6 +111,43 @@ func (m *VictoriaMetrics) OnExtract(resp *colly.Response) {
 	m.SetLatency(ExtractionEvent, resp.Request)
 }
 
+func (m *VictoriaMetrics) OnRobots(_ *colly.Request, rule string) {
+	m.CounterLabel(RobotsEvent, "rule", rule).Inc()
+}
+
+func (m *VictoriaMetrics) OnDisallowed(_, pattern string) {
+	// escape quotes and backslashes of the regex for the label value
+	m.CounterLabel(DisallowedEvent, "pattern", strings.Trim(strconv.Quote(pattern), `"`)).Inc()
+}
+
+func (m *VictoriaMetrics) OnNotModified(resp *colly.Response) {
+	m.Counter(NotModifiedEvent).Inc()
+	m.SetLatency(NotModifiedEvent, resp.Request)
+}
+
+func (m *VictoriaMetrics) OnBrowserTab(event string, busy int) {
+	m.CounterLabel(BrowserTabEvent, "event", event).Inc()
+	m.Gauge(BrowserTabEvent + "_busy").Set(float64(busy))
+}
+
+func (m *VictoriaMetrics) OnBrowserBlocked(_ string, requests int, estimatedBytes int64) {
+	m.Counter(BlockedEvent).Add(requests)
+	m.Counter(BlockedEvent + "_estimated_bytes").AddInt64(estimatedBytes)
+}
+
+func (m *VictoriaMetrics) OnRetryOutcome(_, outcome string, attempts int) {
+	m.CounterLabel(RetryOutcomeEvent, "outcome", outcome).Inc()
+	m.Counter(RetryOutcomeEvent + "_attempts").Add(attempts)
+}
+
+func (m *VictoriaMetrics) OnBudget(budget string) {
+	m.CounterLabel(BudgetEvent, "budget", budget).Inc()
+}
+
+func (m *VictoriaMetrics) OnTrap(_, reason string) {
+	m.CounterLabel(TrapEvent, "reason", reason).Inc()
+}
+
 func (m *VictoriaMetrics) SetLatency(event string, req *colly.Request) {
 
 	startTime := req.Ctx.Get(StartTimeCtx)
@@ -131,6 +177,11 @@ func (m *VictoriaMetrics) Counter(event string) *metrics.Counter {
 	return metrics.GetOrCreateCounter(fmt.Sprintf(format, event, m.jobID, m.spiderID))
 }
 
+func (m *VictoriaMetrics) CounterLabel(event, label, value string) *metrics.Counter {
+	format := `spider_%s_count{job="%s", spider="%s", %s="%s"}`
+	return metrics.GetOrCreateCounter(fmt.Sprintf(format, event, m.jobID, m.spiderID, label, value))
+}
+
 func (m *VictoriaMetrics) CounterUrl(event, url string) *metrics.Counter {
 	format := `spider_%s_count{url="%s"}`
 	return metrics.GetOrCreateCounter(fmt.Sprintf(format, event, url))
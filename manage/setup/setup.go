@@ -22,14 +22,18 @@ type Deploy struct {
 	Database res.Postgresql `json:"Database"`
 	Metrics  res.Metrics    `json:"Metrics"`
 	Logs     res.Logs       `json:"Logs"`
+	// Secrets are the named credentials, e.g. of the Collect.Auth login
+	Secrets map[string]string `json:"Secrets"`
 }
 
 // Spider aggregates configs and create collect.Crawler.
 type Spider struct {
-	ID       string          `json:"ID"`
-	Collect  *config.Config  `json:"Collect"`
-	Extract  *extract.Config `json:"Extract"`
-	Deploy   *Deploy         `json:"Deploy"`
+	ID      string          `json:"ID"`
+	Collect *config.Config  `json:"Collect"`
+	Extract *extract.Config `json:"Extract"`
+	Deploy  *Deploy         `json:"Deploy"`
+	// Fresh drops the unfinished run of the persistent queue, it is resumed by default
+	Fresh    bool `json:"-"`
 	pipe     *pipe.Pipeline
 	shutdown []func() error
 }
@@ -107,7 +111,12 @@ func (s *Spider) NewDeps() (*config.Deps, error) {
 		deps,
 		s.withVictoriaMetrics,
 		s.withProxy,
+		s.withAuth,
 		s.withStorage,
+		s.withCache,
+		s.withWARC,
+		s.withGraph,
+		s.withQueueStorage,
 	)
 
 	if err != nil {
@@ -117,6 +126,11 @@ func (s *Spider) NewDeps() (*config.Deps, error) {
 	// set the extractor function to the deps
 	deps.Extractor = s.pipe
 
+	// screenshots and PDFs of the extracted pages
+	if s.Extract != nil && s.Extract.Snapshot.Enabled {
+		deps.Snapshot = &s.Extract.Snapshot
+	}
+
 	return deps, nil
 }
 
@@ -139,13 +153,24 @@ func (s *Spider) withVictoriaMetrics(deps *config.Deps) (err error) {
 	return err
 }
 
+// withAuth resolves the credentials of the Collect.Auth by the Deploy secrets
+func (s *Spider) withAuth(_ *config.Deps) error {
+
+	if s.Collect.Auth == nil {
+		return nil
+	}
+
+	return s.Collect.Auth.Secrets(s.Deploy.Secrets)
+}
+
 func (s *Spider) withProxy(deps *config.Deps) error {
 
-	if !s.Collect.ProxyEnabled {
+	// replayed crawl has no network
+	if !s.Collect.ProxyEnabled || s.Collect.Cache.Replay() {
 		return nil
 	}
 
-	proxies, err := proxy.StartPool(s.Collect.StartURL, s.Collect.ProxySources...)
+	proxies, err := proxy.StartPool(s.Collect.StartURL, s.Collect.RequestHeader, s.Collect.ProxySources...)
 	if err != nil {
 		return err
 	}
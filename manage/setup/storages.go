@@ -5,7 +5,9 @@ import (
 	"github.com/editorpost/spider/collect/config"
 	"github.com/editorpost/spider/extract/media"
 	"github.com/editorpost/spider/store"
+	"github.com/editorpost/spider/store/warc"
 	"log/slog"
+	"net/http"
 )
 
 func (s *Spider) withStorage(deps *config.Deps) error {
@@ -32,9 +34,9 @@ func (s *Spider) withStorage(deps *config.Deps) error {
 
 func (s *Spider) withVisitedHistory(deps *config.Deps) error {
 
-	// visit once,
-	// stores collector history in S3 between runs
-	if !s.Collect.VisitOnce {
+	// visit once, revalidate or auth,
+	// stores collector history, page revisions and session cookies in S3 between runs
+	if !s.Collect.VisitOnce && !s.Collect.Revalidate && s.Collect.Auth == nil {
 		return nil
 	}
 
@@ -46,9 +48,136 @@ func (s *Spider) withVisitedHistory(deps *config.Deps) error {
 
 	// upload visited urls to S3
 	s.onShutdown(upload)
-	deps.Storage = storage
 
-	return err
+	if s.Collect.Revalidate {
+		deps.Revisions = storage.Revalidate()
+	}
+
+	// session of the login is kept between runs
+	if s.Collect.Auth != nil {
+		deps.Cookies = storage.Jar()
+	}
+
+	// initialized by colly
+	if s.Collect.VisitOnce {
+		deps.Storage = storage
+		return nil
+	}
+
+	return storage.Init()
+}
+
+// withCache records and replays the responses in the collect folder of the storage,
+// the local Collect.Cache.Dir is used by the crawler if set
+func (s *Spider) withCache(deps *config.Deps) error {
+
+	if !s.Collect.Cache.Enabled() || len(s.Collect.Cache.Dir) > 0 || s.Deploy.Storage.Bucket == "" {
+		return nil
+	}
+
+	storage, err := store.NewStorage(s.Deploy.Storage, s.Deploy.Paths.CollectRoot(s.ID)+"/"+store.CacheFolder)
+	if err != nil {
+		return fmt.Errorf("failed to create cache storage: %w", err)
+	}
+
+	deps.Cache = storage
+
+	return nil
+}
+
+// withWARC writes the fetched responses to the WARC files in the collect folder of the storage
+func (s *Spider) withWARC(deps *config.Deps) error {
+
+	if !s.Collect.WARC.Enabled {
+		return nil
+	}
+
+	if s.Deploy.Storage.Bucket == "" {
+		slog.Warn("warc: storage bucket is not set, responses are not archived")
+		return nil
+	}
+
+	storage, err := store.NewStorage(s.Deploy.Storage, s.Deploy.Paths.CollectRoot(s.ID)+"/"+store.WARCFolder)
+	if err != nil {
+		return fmt.Errorf("failed to create warc storage: %w", err)
+	}
+
+	writer := warc.NewWriter(storage, s.ID, s.Collect.WARC.MaxBytes(), s.Collect.WARC.FlushInterval.Duration())
+
+	// save the last file
+	s.onShutdown(writer.Close)
+	deps.WARC = writer
+
+	return nil
+}
+
+// withGraph exports the link graph of the run to the graph folder of the collect storage
+func (s *Spider) withGraph(deps *config.Deps) error {
+
+	if !s.Collect.Graph.Enabled || s.Deploy.Storage.Bucket == "" {
+		return nil
+	}
+
+	storage, err := store.NewStorage(s.Deploy.Storage, s.Deploy.Paths.CollectRoot(s.ID)+"/"+store.GraphFolder)
+	if err != nil {
+		return fmt.Errorf("failed to create graph storage: %w", err)
+	}
+
+	deps.Graph = storage
+
+	return nil
+}
+
+// withQueueStorage persists the crawl frontier in the database,
+// so the killed run is resumed by the next one unless the Fresh start is forced.
+func (s *Spider) withQueueStorage(deps *config.Deps) error {
+
+	if len(s.Deploy.Database.Host) == 0 {
+		return nil
+	}
+
+	// check and regular runs have separate queues
+	queue, err := store.NewQueueStorage(s.ID, s.Deploy.Paths.CollectRoot(s.ID), s.Deploy.Database.DSN(), s.Collect.Scheduling)
+	if err != nil {
+		return fmt.Errorf("failed to create queue storage: %w", err)
+	}
+
+	if err = s.resume(queue); err != nil {
+		return fmt.Errorf("failed to load queue storage: %w", err)
+	}
+
+	queue.Start(store.QueueCheckpointInterval)
+
+	// write the final checkpoint
+	s.onShutdown(queue.Close)
+	deps.QueueStorage = queue
+
+	return nil
+}
+
+// resume the pending and active requests of the unfinished run,
+// the frontier of the previous run is dropped if nothing is resumed or the Fresh start is forced
+func (s *Spider) resume(queue *store.QueueStorage) error {
+
+	if s.Fresh {
+		slog.Info("queue: fresh start, the unfinished run is dropped", slog.String("spider", s.ID))
+		return queue.Reset()
+	}
+
+	resumed, err := queue.Resume()
+	if err != nil {
+		return err
+	}
+
+	if resumed > 0 {
+		slog.Info("queue: unfinished run resumed", slog.String("spider", s.ID), slog.Int("requests", resumed))
+		return nil
+	}
+
+	// e.g. the requests over the max attempts
+	slog.Info("queue: no unfinished run, fresh start", slog.String("spider", s.ID))
+
+	return queue.Reset()
 }
 
 func (s *Spider) withExtractStore() error {
@@ -121,7 +250,13 @@ func (s *Spider) withMedia() error {
 	}
 
 	proxyURL := s.Deploy.Paths.MediaURL(s.ID, s.Deploy.Media.PublicURL)
-	uploader := media.NewMedia(proxyURL, media.NewLoader(storage))
+	loader := media.NewLoader(storage)
+	// same headers and user agent as the crawler
+	loader.SetHeader(func() http.Header {
+		return s.Collect.RequestHeader("")
+	})
+
+	uploader := media.NewMedia(proxyURL, loader)
 
 	s.pipe.Starter(uploader.Claims)
 	s.pipe.Finisher(uploader.Upload)
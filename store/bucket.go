@@ -18,7 +18,14 @@ import (
 const (
 	PayloadFile     = "payload.json"
 	VisitedFile     = "visited.json"
+	RevisionsFile   = "revisions.json"
+	CookiesFile     = "cookies.json"
 	HTMLSourceFile  = "index.html"
+	ScreenshotFile  = "screenshot.png"
+	PDFFile         = "page.pdf"
+	CacheFolder     = "cache"
+	WARCFolder      = "warc"
+	GraphFolder     = "graph"
 	ChunkTimeFormat = "06-01"
 )
 
@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/editorpost/donq/res"
+	"github.com/editorpost/spider/collect/config"
 	"github.com/gocolly/colly/v2/storage"
-	"net/http/cookiejar"
 	"net/url"
 	"sync"
 )
@@ -16,6 +16,11 @@ type CollectStore interface {
 	storage.Storage
 }
 
+// sessionCookies is the CookiesFile content
+type sessionCookies struct {
+	Cookies []*JarCookie `json:"Cookies"`
+}
+
 // CollectStorage in-memory colly storage backed by S3
 // @see CollectHistory.Init and CollectStorage.Shutdown
 type CollectStorage struct {
@@ -26,13 +31,17 @@ type CollectStorage struct {
 	// @source github.com/gocolly/colly/v2@v2.1.1-0.20240327170223-5224b972e22b/storage/storage.go
 	visitedURLs map[uint64]bool
 	lock        *sync.RWMutex
-	jar         *cookiejar.Jar
+	// cookies of the session kept between runs
+	jar *CookieJar
+	// revisions of the pages for conditional re-crawl
+	revisions map[uint64]*config.Revision
+	// visited by the current run
+	current    map[uint64]bool
+	revalidate bool
 }
 
 func NewCollectStorage(folder string, b res.S3) (*CollectStorage, func() error, error) {
 
-	jar, _ := cookiejar.New(nil)
-
 	store, err := NewStorage(b, folder)
 	if err != nil {
 		return nil, nil, fmt.Errorf("extract store s3 client: %w", err)
@@ -44,7 +53,9 @@ func NewCollectStorage(folder string, b res.S3) (*CollectStorage, func() error,
 		filepath:    VisitedFile,
 		visitedURLs: make(map[uint64]bool),
 		lock:        &sync.RWMutex{},
-		jar:         jar,
+		jar:         NewCookieJar(),
+		revisions:   make(map[uint64]*config.Revision),
+		current:     make(map[uint64]bool),
 	}
 
 	return s, s.shutdown, nil
@@ -63,22 +74,76 @@ func DropCollectStorage(folder string, b res.S3) error {
 
 func (s *CollectStorage) shutdown() error {
 
-	if len(s.visitedURLs) == 0 {
-		return nil
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if err := s.save(s.visitedURLs, s.filepath); err != nil {
+		return err
+	}
+
+	if err := s.save(s.revisions, RevisionsFile); err != nil {
+		return err
 	}
 
-	b, err := json.Marshal(s.visitedURLs)
+	return s.saveCookies()
+}
+
+func (s *CollectStorage) save(v any, filepath string) error {
+
+	b, err := json.Marshal(v)
 	if err != nil {
 		return err
 	}
 
-	return s.store.Save(b, s.filepath)
+	// nothing to save
+	if string(b) == "{}" {
+		return nil
+	}
+
+	return s.store.Save(b, filepath)
 }
 
 // Init initializes CollectStorage
 func (s *CollectStorage) Init() error {
 
-	b, err := s.store.Load(s.filepath)
+	if err := s.load(s.filepath, &s.visitedURLs); err != nil {
+		return err
+	}
+
+	if err := s.load(RevisionsFile, &s.revisions); err != nil {
+		return err
+	}
+
+	return s.loadCookies()
+}
+
+// saveCookies of the session, skipped if the jar is empty
+func (s *CollectStorage) saveCookies() error {
+
+	cookies := &sessionCookies{Cookies: s.jar.Entries()}
+	if len(cookies.Cookies) == 0 {
+		return nil
+	}
+
+	return s.save(cookies, CookiesFile)
+}
+
+// loadCookies of the previous run session
+func (s *CollectStorage) loadCookies() error {
+
+	cookies := &sessionCookies{}
+	if err := s.load(CookiesFile, cookies); err != nil {
+		return err
+	}
+
+	s.jar.Restore(cookies.Cookies)
+
+	return nil
+}
+
+func (s *CollectStorage) load(filepath string, v any) error {
+
+	b, err := s.store.Load(filepath)
 
 	// Check for the "Not Found" error
 	var awsErr *types.NoSuchKey
@@ -91,17 +156,34 @@ func (s *CollectStorage) Init() error {
 		return fmt.Errorf("error to connect to collect storage, %w", err)
 	}
 
-	return json.Unmarshal(b, &s.visitedURLs)
+	return json.Unmarshal(b, v)
 }
 
 func (s *CollectStorage) Reset() error {
-	return s.store.Delete(s.filepath)
+
+	if err := s.store.Delete(s.filepath); err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(RevisionsFile); err != nil {
+		return err
+	}
+
+	return s.store.Delete(CookiesFile)
+}
+
+// Revalidate allows visiting pages with revision again,
+// if they are visited by previous runs only.
+func (s *CollectStorage) Revalidate() *CollectStorage {
+	s.revalidate = true
+	return s
 }
 
 // Visited implements Storage.Visited()
 func (s *CollectStorage) Visited(requestID uint64) error {
 	s.lock.Lock()
 	s.visitedURLs[requestID] = true
+	s.current[requestID] = true
 	s.lock.Unlock()
 	return nil
 }
@@ -109,9 +191,46 @@ func (s *CollectStorage) Visited(requestID uint64) error {
 // IsVisited implements Storage.IsVisited()
 func (s *CollectStorage) IsVisited(requestID uint64) (bool, error) {
 	s.lock.RLock()
-	visited := s.visitedURLs[requestID]
-	s.lock.RUnlock()
-	return visited, nil
+	defer s.lock.RUnlock()
+
+	// the page of previous runs is requested conditionally
+	if s.revalidate && !s.current[requestID] && s.revisions[requestID] != nil {
+		return false, nil
+	}
+
+	return s.visitedURLs[requestID], nil
+}
+
+// Revision implements config.RevisionStorage
+func (s *CollectStorage) Revision(uri string) *config.Revision {
+
+	hash, err := FNVHash(uri)
+	if err != nil {
+		return nil
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.revisions[hash]
+}
+
+// SetRevision implements config.RevisionStorage
+func (s *CollectStorage) SetRevision(uri string, rev *config.Revision) {
+
+	hash, err := FNVHash(uri)
+	if err != nil {
+		return
+	}
+
+	s.lock.Lock()
+	s.revisions[hash] = rev
+	s.lock.Unlock()
+}
+
+// Jar of the session cookies saved on shutdown
+func (s *CollectStorage) Jar() *CookieJar {
+	return s.jar
 }
 
 // Cookies implements Storage.Cookies()
@@ -0,0 +1,85 @@
+package store_test
+
+import (
+	"github.com/editorpost/donq/res"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCollectStorage_Revisions(t *testing.T) {
+
+	bucket := res.S3{Bucket: store.LocalBucket, EndPoint: t.TempDir()}
+	uri := "https://example.com/news/1.html"
+	hash, err := store.FNVHash(uri)
+	require.NoError(t, err)
+
+	s, shutdown, err := store.NewCollectStorage("collect", bucket)
+	require.NoError(t, err)
+	require.NoError(t, s.Init())
+
+	require.NoError(t, s.Visited(hash))
+	s.SetRevision(uri, &config.Revision{ETag: `"v1"`, Hash: "abc", PayloadID: "payload-1"})
+	require.NoError(t, shutdown())
+
+	// next run without revalidation
+	s, _, err = store.NewCollectStorage("collect", bucket)
+	require.NoError(t, err)
+	require.NoError(t, s.Init())
+
+	visited, err := s.IsVisited(hash)
+	require.NoError(t, err)
+	assert.True(t, visited)
+	assert.Equal(t, &config.Revision{ETag: `"v1"`, Hash: "abc", PayloadID: "payload-1"}, s.Revision(uri))
+
+	// page of the previous run is visited again once
+	s, _, err = store.NewCollectStorage("collect", bucket)
+	require.NoError(t, err)
+	require.NoError(t, s.Revalidate().Init())
+
+	visited, err = s.IsVisited(hash)
+	require.NoError(t, err)
+	assert.False(t, visited)
+
+	require.NoError(t, s.Visited(hash))
+	visited, err = s.IsVisited(hash)
+	require.NoError(t, err)
+	assert.True(t, visited)
+
+	require.NoError(t, s.Reset())
+	assert.Nil(t, s.Revision("https://example.com/news/2.html"))
+}
+
+func TestCollectStorage_Cookies(t *testing.T) {
+
+	bucket := res.S3{Bucket: store.LocalBucket, EndPoint: t.TempDir()}
+	u, err := url.Parse("https://example.com/news/1.html")
+	require.NoError(t, err)
+
+	s, shutdown, err := store.NewCollectStorage("collect", bucket)
+	require.NoError(t, err)
+	require.NoError(t, s.Init())
+
+	s.Jar().SetCookies(u, []*http.Cookie{
+		{Name: "sid", Value: "session", Path: "/"},
+		{Name: "pref", Value: "dark", Path: "/", MaxAge: 3600},
+		{Name: "gone", Value: "expired", Path: "/", MaxAge: -1},
+	})
+	require.NoError(t, shutdown())
+
+	// the session is restored by the next run
+	s, _, err = store.NewCollectStorage("collect", bucket)
+	require.NoError(t, err)
+	require.NoError(t, s.Init())
+
+	assert.ElementsMatch(t, []*http.Cookie{
+		{Name: "sid", Value: "session"},
+		{Name: "pref", Value: "dark"},
+	}, s.Jar().Cookies(u))
+
+	require.NoError(t, s.Reset())
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// CookieJar is the http.CookieJar keeping the cookies set by the responses,
+// so the session of the crawl is saved to the collect storage and restored by the next run.
+// Session cookies without expiration are kept as well, the session of the site usually relies on them.
+type CookieJar struct {
+	jar     *cookiejar.Jar
+	lock    *sync.Mutex
+	cookies map[string]*JarCookie
+}
+
+// JarCookie is the cookie with the URL it is set by
+type JarCookie struct {
+	URL    string       `json:"URL"`
+	Cookie *http.Cookie `json:"Cookie"`
+}
+
+// NewCookieJar creates the empty jar
+func NewCookieJar() *CookieJar {
+
+	jar, _ := cookiejar.New(nil)
+
+	return &CookieJar{
+		jar:     jar,
+		lock:    &sync.Mutex{},
+		cookies: make(map[string]*JarCookie),
+	}
+}
+
+// Cookies implements http.CookieJar
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// SetCookies implements http.CookieJar
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+
+	j.jar.SetCookies(u, cookies)
+
+	origin := (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+	now := time.Now()
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	for _, cookie := range cookies {
+
+		key := origin + "|" + cookie.Domain + "|" + cookie.Path + "|" + cookie.Name
+
+		// max-age is relative to the response, stored as the expiration
+		c := *cookie
+		if c.MaxAge > 0 {
+			c.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+			c.MaxAge = 0
+		}
+
+		// deleted by the site
+		if c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(now)) {
+			delete(j.cookies, key)
+			continue
+		}
+
+		j.cookies[key] = &JarCookie{URL: origin, Cookie: &c}
+	}
+}
+
+// Entries of the jar not expired yet
+func (j *CookieJar) Entries() []*JarCookie {
+
+	now := time.Now()
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	entries := make([]*JarCookie, 0, len(j.cookies))
+	for _, entry := range j.cookies {
+		if !entry.Cookie.Expires.IsZero() && entry.Cookie.Expires.Before(now) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// Restore the entries saved by the previous run
+func (j *CookieJar) Restore(entries []*JarCookie) {
+
+	for _, entry := range entries {
+
+		if entry.Cookie == nil {
+			continue
+		}
+
+		u, err := url.Parse(entry.URL)
+		if err != nil {
+			continue
+		}
+
+		j.SetCookies(u, []*http.Cookie{entry.Cookie})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
 	"github.com/editorpost/spider/store/ent/spiderpayload"
 )
 
@@ -23,6 +24,8 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// CrawlQueue is the client for interacting with the CrawlQueue builders.
+	CrawlQueue *CrawlQueueClient
 	// SpiderPayload is the client for interacting with the SpiderPayload builders.
 	SpiderPayload *SpiderPayloadClient
 }
@@ -36,6 +39,7 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.CrawlQueue = NewCrawlQueueClient(c.config)
 	c.SpiderPayload = NewSpiderPayloadClient(c.config)
 }
 
@@ -129,6 +133,7 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	return &Tx{
 		ctx:           ctx,
 		config:        cfg,
+		CrawlQueue:    NewCrawlQueueClient(cfg),
 		SpiderPayload: NewSpiderPayloadClient(cfg),
 	}, nil
 }
@@ -149,6 +154,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	return &Tx{
 		ctx:           ctx,
 		config:        cfg,
+		CrawlQueue:    NewCrawlQueueClient(cfg),
 		SpiderPayload: NewSpiderPayloadClient(cfg),
 	}, nil
 }
@@ -156,7 +162,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		SpiderPayload.
+//		CrawlQueue.
 //		Query().
 //		Count(ctx)
 func (c *Client) Debug() *Client {
@@ -178,18 +184,22 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
+	c.CrawlQueue.Use(hooks...)
 	c.SpiderPayload.Use(hooks...)
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
+	c.CrawlQueue.Intercept(interceptors...)
 	c.SpiderPayload.Intercept(interceptors...)
 }
 
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
+	case *CrawlQueueMutation:
+		return c.CrawlQueue.mutate(ctx, m)
 	case *SpiderPayloadMutation:
 		return c.SpiderPayload.mutate(ctx, m)
 	default:
@@ -197,6 +207,139 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	}
 }
 
+// CrawlQueueClient is a client for the CrawlQueue schema.
+type CrawlQueueClient struct {
+	config
+}
+
+// NewCrawlQueueClient returns a client for the CrawlQueue from the given config.
+func NewCrawlQueueClient(c config) *CrawlQueueClient {
+	return &CrawlQueueClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `crawlqueue.Hooks(f(g(h())))`.
+func (c *CrawlQueueClient) Use(hooks ...Hook) {
+	c.hooks.CrawlQueue = append(c.hooks.CrawlQueue, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `crawlqueue.Intercept(f(g(h())))`.
+func (c *CrawlQueueClient) Intercept(interceptors ...Interceptor) {
+	c.inters.CrawlQueue = append(c.inters.CrawlQueue, interceptors...)
+}
+
+// Create returns a builder for creating a CrawlQueue entity.
+func (c *CrawlQueueClient) Create() *CrawlQueueCreate {
+	mutation := newCrawlQueueMutation(c.config, OpCreate)
+	return &CrawlQueueCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of CrawlQueue entities.
+func (c *CrawlQueueClient) CreateBulk(builders ...*CrawlQueueCreate) *CrawlQueueCreateBulk {
+	return &CrawlQueueCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *CrawlQueueClient) MapCreateBulk(slice any, setFunc func(*CrawlQueueCreate, int)) *CrawlQueueCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &CrawlQueueCreateBulk{err: fmt.Errorf("calling to CrawlQueueClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*CrawlQueueCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &CrawlQueueCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for CrawlQueue.
+func (c *CrawlQueueClient) Update() *CrawlQueueUpdate {
+	mutation := newCrawlQueueMutation(c.config, OpUpdate)
+	return &CrawlQueueUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *CrawlQueueClient) UpdateOne(cq *CrawlQueue) *CrawlQueueUpdateOne {
+	mutation := newCrawlQueueMutation(c.config, OpUpdateOne, withCrawlQueue(cq))
+	return &CrawlQueueUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *CrawlQueueClient) UpdateOneID(id uuid.UUID) *CrawlQueueUpdateOne {
+	mutation := newCrawlQueueMutation(c.config, OpUpdateOne, withCrawlQueueID(id))
+	return &CrawlQueueUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for CrawlQueue.
+func (c *CrawlQueueClient) Delete() *CrawlQueueDelete {
+	mutation := newCrawlQueueMutation(c.config, OpDelete)
+	return &CrawlQueueDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *CrawlQueueClient) DeleteOne(cq *CrawlQueue) *CrawlQueueDeleteOne {
+	return c.DeleteOneID(cq.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *CrawlQueueClient) DeleteOneID(id uuid.UUID) *CrawlQueueDeleteOne {
+	builder := c.Delete().Where(crawlqueue.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &CrawlQueueDeleteOne{builder}
+}
+
+// Query returns a query builder for CrawlQueue.
+func (c *CrawlQueueClient) Query() *CrawlQueueQuery {
+	return &CrawlQueueQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeCrawlQueue},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a CrawlQueue entity by its id.
+func (c *CrawlQueueClient) Get(ctx context.Context, id uuid.UUID) (*CrawlQueue, error) {
+	return c.Query().Where(crawlqueue.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *CrawlQueueClient) GetX(ctx context.Context, id uuid.UUID) *CrawlQueue {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *CrawlQueueClient) Hooks() []Hook {
+	return c.hooks.CrawlQueue
+}
+
+// Interceptors returns the client interceptors.
+func (c *CrawlQueueClient) Interceptors() []Interceptor {
+	return c.inters.CrawlQueue
+}
+
+func (c *CrawlQueueClient) mutate(ctx context.Context, m *CrawlQueueMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&CrawlQueueCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&CrawlQueueUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&CrawlQueueUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&CrawlQueueDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown CrawlQueue mutation op: %q", m.Op())
+	}
+}
+
 // SpiderPayloadClient is a client for the SpiderPayload schema.
 type SpiderPayloadClient struct {
 	config
@@ -333,9 +476,9 @@ func (c *SpiderPayloadClient) mutate(ctx context.Context, m *SpiderPayloadMutati
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		SpiderPayload []ent.Hook
+		CrawlQueue, SpiderPayload []ent.Hook
 	}
 	inters struct {
-		SpiderPayload []ent.Interceptor
+		CrawlQueue, SpiderPayload []ent.Interceptor
 	}
 )
@@ -0,0 +1,210 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
+	"github.com/google/uuid"
+)
+
+// CrawlQueue is the model entity for the CrawlQueue schema.
+type CrawlQueue struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// SpiderID holds the value of the "spider_id" field.
+	SpiderID uuid.UUID `json:"spider_id,omitempty"`
+	// Queue holds the value of the "queue" field.
+	Queue string `json:"queue,omitempty"`
+	// URL holds the value of the "url" field.
+	URL string `json:"url,omitempty"`
+	// Depth holds the value of the "depth" field.
+	Depth int `json:"depth,omitempty"`
+	// Priority holds the value of the "priority" field.
+	Priority int `json:"priority,omitempty"`
+	// State holds the value of the "state" field.
+	State uint8 `json:"state,omitempty"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts int `json:"attempts,omitempty"`
+	// Request holds the value of the "request" field.
+	Request []byte `json:"request,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*CrawlQueue) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case crawlqueue.FieldRequest:
+			values[i] = new([]byte)
+		case crawlqueue.FieldDepth, crawlqueue.FieldPriority, crawlqueue.FieldState, crawlqueue.FieldAttempts:
+			values[i] = new(sql.NullInt64)
+		case crawlqueue.FieldQueue, crawlqueue.FieldURL:
+			values[i] = new(sql.NullString)
+		case crawlqueue.FieldCreatedAt, crawlqueue.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case crawlqueue.FieldID, crawlqueue.FieldSpiderID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the CrawlQueue fields.
+func (cq *CrawlQueue) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case crawlqueue.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				cq.ID = *value
+			}
+		case crawlqueue.FieldSpiderID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field spider_id", values[i])
+			} else if value != nil {
+				cq.SpiderID = *value
+			}
+		case crawlqueue.FieldQueue:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field queue", values[i])
+			} else if value.Valid {
+				cq.Queue = value.String
+			}
+		case crawlqueue.FieldURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field url", values[i])
+			} else if value.Valid {
+				cq.URL = value.String
+			}
+		case crawlqueue.FieldDepth:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field depth", values[i])
+			} else if value.Valid {
+				cq.Depth = int(value.Int64)
+			}
+		case crawlqueue.FieldPriority:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field priority", values[i])
+			} else if value.Valid {
+				cq.Priority = int(value.Int64)
+			}
+		case crawlqueue.FieldState:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field state", values[i])
+			} else if value.Valid {
+				cq.State = uint8(value.Int64)
+			}
+		case crawlqueue.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				cq.Attempts = int(value.Int64)
+			}
+		case crawlqueue.FieldRequest:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field request", values[i])
+			} else if value != nil {
+				cq.Request = *value
+			}
+		case crawlqueue.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				cq.CreatedAt = value.Time
+			}
+		case crawlqueue.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				cq.UpdatedAt = value.Time
+			}
+		default:
+			cq.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the CrawlQueue.
+// This includes values selected through modifiers, order, etc.
+func (cq *CrawlQueue) Value(name string) (ent.Value, error) {
+	return cq.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this CrawlQueue.
+// Note that you need to call CrawlQueue.Unwrap() before calling this method if this CrawlQueue
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (cq *CrawlQueue) Update() *CrawlQueueUpdateOne {
+	return NewCrawlQueueClient(cq.config).UpdateOne(cq)
+}
+
+// Unwrap unwraps the CrawlQueue entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (cq *CrawlQueue) Unwrap() *CrawlQueue {
+	_tx, ok := cq.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: CrawlQueue is not a transactional entity")
+	}
+	cq.config.driver = _tx.drv
+	return cq
+}
+
+// String implements the fmt.Stringer.
+func (cq *CrawlQueue) String() string {
+	var builder strings.Builder
+	builder.WriteString("CrawlQueue(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", cq.ID))
+	builder.WriteString("spider_id=")
+	builder.WriteString(fmt.Sprintf("%v", cq.SpiderID))
+	builder.WriteString(", ")
+	builder.WriteString("queue=")
+	builder.WriteString(cq.Queue)
+	builder.WriteString(", ")
+	builder.WriteString("url=")
+	builder.WriteString(cq.URL)
+	builder.WriteString(", ")
+	builder.WriteString("depth=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Depth))
+	builder.WriteString(", ")
+	builder.WriteString("priority=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Priority))
+	builder.WriteString(", ")
+	builder.WriteString("state=")
+	builder.WriteString(fmt.Sprintf("%v", cq.State))
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("request=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Request))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(cq.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(cq.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// CrawlQueues is a parsable slice of CrawlQueue.
+type CrawlQueues []*CrawlQueue
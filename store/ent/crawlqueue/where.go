@@ -0,0 +1,571 @@
+// Code generated by ent, DO NOT EDIT.
+
+package crawlqueue
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/editorpost/spider/store/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldID, id))
+}
+
+// SpiderID applies equality check predicate on the "spider_id" field. It's identical to SpiderIDEQ.
+func SpiderID(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldSpiderID, v))
+}
+
+// Queue applies equality check predicate on the "queue" field. It's identical to QueueEQ.
+func Queue(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldQueue, v))
+}
+
+// URL applies equality check predicate on the "url" field. It's identical to URLEQ.
+func URL(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldURL, v))
+}
+
+// Depth applies equality check predicate on the "depth" field. It's identical to DepthEQ.
+func Depth(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldDepth, v))
+}
+
+// Priority applies equality check predicate on the "priority" field. It's identical to PriorityEQ.
+func Priority(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldPriority, v))
+}
+
+// State applies equality check predicate on the "state" field. It's identical to StateEQ.
+func State(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldState, v))
+}
+
+// Attempts applies equality check predicate on the "attempts" field. It's identical to AttemptsEQ.
+func Attempts(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldAttempts, v))
+}
+
+// Request applies equality check predicate on the "request" field. It's identical to RequestEQ.
+func Request(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldRequest, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// SpiderIDEQ applies the EQ predicate on the "spider_id" field.
+func SpiderIDEQ(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldSpiderID, v))
+}
+
+// SpiderIDNEQ applies the NEQ predicate on the "spider_id" field.
+func SpiderIDNEQ(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldSpiderID, v))
+}
+
+// SpiderIDIn applies the In predicate on the "spider_id" field.
+func SpiderIDIn(vs ...uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldSpiderID, vs...))
+}
+
+// SpiderIDNotIn applies the NotIn predicate on the "spider_id" field.
+func SpiderIDNotIn(vs ...uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldSpiderID, vs...))
+}
+
+// SpiderIDGT applies the GT predicate on the "spider_id" field.
+func SpiderIDGT(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldSpiderID, v))
+}
+
+// SpiderIDGTE applies the GTE predicate on the "spider_id" field.
+func SpiderIDGTE(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldSpiderID, v))
+}
+
+// SpiderIDLT applies the LT predicate on the "spider_id" field.
+func SpiderIDLT(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldSpiderID, v))
+}
+
+// SpiderIDLTE applies the LTE predicate on the "spider_id" field.
+func SpiderIDLTE(v uuid.UUID) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldSpiderID, v))
+}
+
+// QueueEQ applies the EQ predicate on the "queue" field.
+func QueueEQ(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldQueue, v))
+}
+
+// QueueNEQ applies the NEQ predicate on the "queue" field.
+func QueueNEQ(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldQueue, v))
+}
+
+// QueueIn applies the In predicate on the "queue" field.
+func QueueIn(vs ...string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldQueue, vs...))
+}
+
+// QueueNotIn applies the NotIn predicate on the "queue" field.
+func QueueNotIn(vs ...string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldQueue, vs...))
+}
+
+// QueueGT applies the GT predicate on the "queue" field.
+func QueueGT(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldQueue, v))
+}
+
+// QueueGTE applies the GTE predicate on the "queue" field.
+func QueueGTE(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldQueue, v))
+}
+
+// QueueLT applies the LT predicate on the "queue" field.
+func QueueLT(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldQueue, v))
+}
+
+// QueueLTE applies the LTE predicate on the "queue" field.
+func QueueLTE(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldQueue, v))
+}
+
+// QueueContains applies the Contains predicate on the "queue" field.
+func QueueContains(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldContains(FieldQueue, v))
+}
+
+// QueueHasPrefix applies the HasPrefix predicate on the "queue" field.
+func QueueHasPrefix(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldHasPrefix(FieldQueue, v))
+}
+
+// QueueHasSuffix applies the HasSuffix predicate on the "queue" field.
+func QueueHasSuffix(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldHasSuffix(FieldQueue, v))
+}
+
+// QueueEqualFold applies the EqualFold predicate on the "queue" field.
+func QueueEqualFold(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEqualFold(FieldQueue, v))
+}
+
+// QueueContainsFold applies the ContainsFold predicate on the "queue" field.
+func QueueContainsFold(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldContainsFold(FieldQueue, v))
+}
+
+// URLEQ applies the EQ predicate on the "url" field.
+func URLEQ(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldURL, v))
+}
+
+// URLNEQ applies the NEQ predicate on the "url" field.
+func URLNEQ(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldURL, v))
+}
+
+// URLIn applies the In predicate on the "url" field.
+func URLIn(vs ...string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldURL, vs...))
+}
+
+// URLNotIn applies the NotIn predicate on the "url" field.
+func URLNotIn(vs ...string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldURL, vs...))
+}
+
+// URLGT applies the GT predicate on the "url" field.
+func URLGT(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldURL, v))
+}
+
+// URLGTE applies the GTE predicate on the "url" field.
+func URLGTE(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldURL, v))
+}
+
+// URLLT applies the LT predicate on the "url" field.
+func URLLT(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldURL, v))
+}
+
+// URLLTE applies the LTE predicate on the "url" field.
+func URLLTE(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldURL, v))
+}
+
+// URLContains applies the Contains predicate on the "url" field.
+func URLContains(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldContains(FieldURL, v))
+}
+
+// URLHasPrefix applies the HasPrefix predicate on the "url" field.
+func URLHasPrefix(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldHasPrefix(FieldURL, v))
+}
+
+// URLHasSuffix applies the HasSuffix predicate on the "url" field.
+func URLHasSuffix(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldHasSuffix(FieldURL, v))
+}
+
+// URLEqualFold applies the EqualFold predicate on the "url" field.
+func URLEqualFold(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEqualFold(FieldURL, v))
+}
+
+// URLContainsFold applies the ContainsFold predicate on the "url" field.
+func URLContainsFold(v string) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldContainsFold(FieldURL, v))
+}
+
+// DepthEQ applies the EQ predicate on the "depth" field.
+func DepthEQ(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldDepth, v))
+}
+
+// DepthNEQ applies the NEQ predicate on the "depth" field.
+func DepthNEQ(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldDepth, v))
+}
+
+// DepthIn applies the In predicate on the "depth" field.
+func DepthIn(vs ...int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldDepth, vs...))
+}
+
+// DepthNotIn applies the NotIn predicate on the "depth" field.
+func DepthNotIn(vs ...int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldDepth, vs...))
+}
+
+// DepthGT applies the GT predicate on the "depth" field.
+func DepthGT(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldDepth, v))
+}
+
+// DepthGTE applies the GTE predicate on the "depth" field.
+func DepthGTE(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldDepth, v))
+}
+
+// DepthLT applies the LT predicate on the "depth" field.
+func DepthLT(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldDepth, v))
+}
+
+// DepthLTE applies the LTE predicate on the "depth" field.
+func DepthLTE(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldDepth, v))
+}
+
+// PriorityEQ applies the EQ predicate on the "priority" field.
+func PriorityEQ(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldPriority, v))
+}
+
+// PriorityNEQ applies the NEQ predicate on the "priority" field.
+func PriorityNEQ(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldPriority, v))
+}
+
+// PriorityIn applies the In predicate on the "priority" field.
+func PriorityIn(vs ...int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldPriority, vs...))
+}
+
+// PriorityNotIn applies the NotIn predicate on the "priority" field.
+func PriorityNotIn(vs ...int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldPriority, vs...))
+}
+
+// PriorityGT applies the GT predicate on the "priority" field.
+func PriorityGT(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldPriority, v))
+}
+
+// PriorityGTE applies the GTE predicate on the "priority" field.
+func PriorityGTE(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldPriority, v))
+}
+
+// PriorityLT applies the LT predicate on the "priority" field.
+func PriorityLT(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldPriority, v))
+}
+
+// PriorityLTE applies the LTE predicate on the "priority" field.
+func PriorityLTE(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldPriority, v))
+}
+
+// StateEQ applies the EQ predicate on the "state" field.
+func StateEQ(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldState, v))
+}
+
+// StateNEQ applies the NEQ predicate on the "state" field.
+func StateNEQ(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldState, v))
+}
+
+// StateIn applies the In predicate on the "state" field.
+func StateIn(vs ...uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldState, vs...))
+}
+
+// StateNotIn applies the NotIn predicate on the "state" field.
+func StateNotIn(vs ...uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldState, vs...))
+}
+
+// StateGT applies the GT predicate on the "state" field.
+func StateGT(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldState, v))
+}
+
+// StateGTE applies the GTE predicate on the "state" field.
+func StateGTE(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldState, v))
+}
+
+// StateLT applies the LT predicate on the "state" field.
+func StateLT(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldState, v))
+}
+
+// StateLTE applies the LTE predicate on the "state" field.
+func StateLTE(v uint8) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldState, v))
+}
+
+// AttemptsEQ applies the EQ predicate on the "attempts" field.
+func AttemptsEQ(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldAttempts, v))
+}
+
+// AttemptsNEQ applies the NEQ predicate on the "attempts" field.
+func AttemptsNEQ(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldAttempts, v))
+}
+
+// AttemptsIn applies the In predicate on the "attempts" field.
+func AttemptsIn(vs ...int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldAttempts, vs...))
+}
+
+// AttemptsNotIn applies the NotIn predicate on the "attempts" field.
+func AttemptsNotIn(vs ...int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldAttempts, vs...))
+}
+
+// AttemptsGT applies the GT predicate on the "attempts" field.
+func AttemptsGT(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldAttempts, v))
+}
+
+// AttemptsGTE applies the GTE predicate on the "attempts" field.
+func AttemptsGTE(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldAttempts, v))
+}
+
+// AttemptsLT applies the LT predicate on the "attempts" field.
+func AttemptsLT(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldAttempts, v))
+}
+
+// AttemptsLTE applies the LTE predicate on the "attempts" field.
+func AttemptsLTE(v int) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldAttempts, v))
+}
+
+// RequestEQ applies the EQ predicate on the "request" field.
+func RequestEQ(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldRequest, v))
+}
+
+// RequestNEQ applies the NEQ predicate on the "request" field.
+func RequestNEQ(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldRequest, v))
+}
+
+// RequestIn applies the In predicate on the "request" field.
+func RequestIn(vs ...[]byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldRequest, vs...))
+}
+
+// RequestNotIn applies the NotIn predicate on the "request" field.
+func RequestNotIn(vs ...[]byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldRequest, vs...))
+}
+
+// RequestGT applies the GT predicate on the "request" field.
+func RequestGT(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldRequest, v))
+}
+
+// RequestGTE applies the GTE predicate on the "request" field.
+func RequestGTE(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldRequest, v))
+}
+
+// RequestLT applies the LT predicate on the "request" field.
+func RequestLT(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldRequest, v))
+}
+
+// RequestLTE applies the LTE predicate on the "request" field.
+func RequestLTE(v []byte) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldRequest, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.CrawlQueue) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.CrawlQueue) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.CrawlQueue) predicate.CrawlQueue {
+	return predicate.CrawlQueue(sql.NotPredicates(p))
+}
@@ -0,0 +1,403 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
+	"github.com/google/uuid"
+)
+
+// CrawlQueueCreate is the builder for creating a CrawlQueue entity.
+type CrawlQueueCreate struct {
+	config
+	mutation *CrawlQueueMutation
+	hooks    []Hook
+}
+
+// SetSpiderID sets the "spider_id" field.
+func (cqc *CrawlQueueCreate) SetSpiderID(u uuid.UUID) *CrawlQueueCreate {
+	cqc.mutation.SetSpiderID(u)
+	return cqc
+}
+
+// SetQueue sets the "queue" field.
+func (cqc *CrawlQueueCreate) SetQueue(s string) *CrawlQueueCreate {
+	cqc.mutation.SetQueue(s)
+	return cqc
+}
+
+// SetURL sets the "url" field.
+func (cqc *CrawlQueueCreate) SetURL(s string) *CrawlQueueCreate {
+	cqc.mutation.SetURL(s)
+	return cqc
+}
+
+// SetDepth sets the "depth" field.
+func (cqc *CrawlQueueCreate) SetDepth(i int) *CrawlQueueCreate {
+	cqc.mutation.SetDepth(i)
+	return cqc
+}
+
+// SetNillableDepth sets the "depth" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillableDepth(i *int) *CrawlQueueCreate {
+	if i != nil {
+		cqc.SetDepth(*i)
+	}
+	return cqc
+}
+
+// SetPriority sets the "priority" field.
+func (cqc *CrawlQueueCreate) SetPriority(i int) *CrawlQueueCreate {
+	cqc.mutation.SetPriority(i)
+	return cqc
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillablePriority(i *int) *CrawlQueueCreate {
+	if i != nil {
+		cqc.SetPriority(*i)
+	}
+	return cqc
+}
+
+// SetState sets the "state" field.
+func (cqc *CrawlQueueCreate) SetState(u uint8) *CrawlQueueCreate {
+	cqc.mutation.SetState(u)
+	return cqc
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillableState(u *uint8) *CrawlQueueCreate {
+	if u != nil {
+		cqc.SetState(*u)
+	}
+	return cqc
+}
+
+// SetAttempts sets the "attempts" field.
+func (cqc *CrawlQueueCreate) SetAttempts(i int) *CrawlQueueCreate {
+	cqc.mutation.SetAttempts(i)
+	return cqc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillableAttempts(i *int) *CrawlQueueCreate {
+	if i != nil {
+		cqc.SetAttempts(*i)
+	}
+	return cqc
+}
+
+// SetRequest sets the "request" field.
+func (cqc *CrawlQueueCreate) SetRequest(b []byte) *CrawlQueueCreate {
+	cqc.mutation.SetRequest(b)
+	return cqc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cqc *CrawlQueueCreate) SetCreatedAt(t time.Time) *CrawlQueueCreate {
+	cqc.mutation.SetCreatedAt(t)
+	return cqc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillableCreatedAt(t *time.Time) *CrawlQueueCreate {
+	if t != nil {
+		cqc.SetCreatedAt(*t)
+	}
+	return cqc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (cqc *CrawlQueueCreate) SetUpdatedAt(t time.Time) *CrawlQueueCreate {
+	cqc.mutation.SetUpdatedAt(t)
+	return cqc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillableUpdatedAt(t *time.Time) *CrawlQueueCreate {
+	if t != nil {
+		cqc.SetUpdatedAt(*t)
+	}
+	return cqc
+}
+
+// SetID sets the "id" field.
+func (cqc *CrawlQueueCreate) SetID(u uuid.UUID) *CrawlQueueCreate {
+	cqc.mutation.SetID(u)
+	return cqc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (cqc *CrawlQueueCreate) SetNillableID(u *uuid.UUID) *CrawlQueueCreate {
+	if u != nil {
+		cqc.SetID(*u)
+	}
+	return cqc
+}
+
+// Mutation returns the CrawlQueueMutation object of the builder.
+func (cqc *CrawlQueueCreate) Mutation() *CrawlQueueMutation {
+	return cqc.mutation
+}
+
+// Save creates the CrawlQueue in the database.
+func (cqc *CrawlQueueCreate) Save(ctx context.Context) (*CrawlQueue, error) {
+	cqc.defaults()
+	return withHooks(ctx, cqc.sqlSave, cqc.mutation, cqc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cqc *CrawlQueueCreate) SaveX(ctx context.Context) *CrawlQueue {
+	v, err := cqc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cqc *CrawlQueueCreate) Exec(ctx context.Context) error {
+	_, err := cqc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqc *CrawlQueueCreate) ExecX(ctx context.Context) {
+	if err := cqc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cqc *CrawlQueueCreate) defaults() {
+	if _, ok := cqc.mutation.Depth(); !ok {
+		v := crawlqueue.DefaultDepth
+		cqc.mutation.SetDepth(v)
+	}
+	if _, ok := cqc.mutation.Priority(); !ok {
+		v := crawlqueue.DefaultPriority
+		cqc.mutation.SetPriority(v)
+	}
+	if _, ok := cqc.mutation.State(); !ok {
+		v := crawlqueue.DefaultState
+		cqc.mutation.SetState(v)
+	}
+	if _, ok := cqc.mutation.Attempts(); !ok {
+		v := crawlqueue.DefaultAttempts
+		cqc.mutation.SetAttempts(v)
+	}
+	if _, ok := cqc.mutation.CreatedAt(); !ok {
+		v := crawlqueue.DefaultCreatedAt()
+		cqc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := cqc.mutation.UpdatedAt(); !ok {
+		v := crawlqueue.DefaultUpdatedAt()
+		cqc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := cqc.mutation.ID(); !ok {
+		v := crawlqueue.DefaultID()
+		cqc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cqc *CrawlQueueCreate) check() error {
+	if _, ok := cqc.mutation.SpiderID(); !ok {
+		return &ValidationError{Name: "spider_id", err: errors.New(`ent: missing required field "CrawlQueue.spider_id"`)}
+	}
+	if _, ok := cqc.mutation.Queue(); !ok {
+		return &ValidationError{Name: "queue", err: errors.New(`ent: missing required field "CrawlQueue.queue"`)}
+	}
+	if _, ok := cqc.mutation.URL(); !ok {
+		return &ValidationError{Name: "url", err: errors.New(`ent: missing required field "CrawlQueue.url"`)}
+	}
+	if _, ok := cqc.mutation.Depth(); !ok {
+		return &ValidationError{Name: "depth", err: errors.New(`ent: missing required field "CrawlQueue.depth"`)}
+	}
+	if _, ok := cqc.mutation.Priority(); !ok {
+		return &ValidationError{Name: "priority", err: errors.New(`ent: missing required field "CrawlQueue.priority"`)}
+	}
+	if _, ok := cqc.mutation.State(); !ok {
+		return &ValidationError{Name: "state", err: errors.New(`ent: missing required field "CrawlQueue.state"`)}
+	}
+	if _, ok := cqc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "CrawlQueue.attempts"`)}
+	}
+	if _, ok := cqc.mutation.Request(); !ok {
+		return &ValidationError{Name: "request", err: errors.New(`ent: missing required field "CrawlQueue.request"`)}
+	}
+	if _, ok := cqc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "CrawlQueue.created_at"`)}
+	}
+	if _, ok := cqc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "CrawlQueue.updated_at"`)}
+	}
+	return nil
+}
+
+func (cqc *CrawlQueueCreate) sqlSave(ctx context.Context) (*CrawlQueue, error) {
+	if err := cqc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cqc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cqc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	cqc.mutation.id = &_node.ID
+	cqc.mutation.done = true
+	return _node, nil
+}
+
+func (cqc *CrawlQueueCreate) createSpec() (*CrawlQueue, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CrawlQueue{config: cqc.config}
+		_spec = sqlgraph.NewCreateSpec(crawlqueue.Table, sqlgraph.NewFieldSpec(crawlqueue.FieldID, field.TypeUUID))
+	)
+	if id, ok := cqc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := cqc.mutation.SpiderID(); ok {
+		_spec.SetField(crawlqueue.FieldSpiderID, field.TypeUUID, value)
+		_node.SpiderID = value
+	}
+	if value, ok := cqc.mutation.Queue(); ok {
+		_spec.SetField(crawlqueue.FieldQueue, field.TypeString, value)
+		_node.Queue = value
+	}
+	if value, ok := cqc.mutation.URL(); ok {
+		_spec.SetField(crawlqueue.FieldURL, field.TypeString, value)
+		_node.URL = value
+	}
+	if value, ok := cqc.mutation.Depth(); ok {
+		_spec.SetField(crawlqueue.FieldDepth, field.TypeInt, value)
+		_node.Depth = value
+	}
+	if value, ok := cqc.mutation.Priority(); ok {
+		_spec.SetField(crawlqueue.FieldPriority, field.TypeInt, value)
+		_node.Priority = value
+	}
+	if value, ok := cqc.mutation.State(); ok {
+		_spec.SetField(crawlqueue.FieldState, field.TypeUint8, value)
+		_node.State = value
+	}
+	if value, ok := cqc.mutation.Attempts(); ok {
+		_spec.SetField(crawlqueue.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := cqc.mutation.Request(); ok {
+		_spec.SetField(crawlqueue.FieldRequest, field.TypeBytes, value)
+		_node.Request = value
+	}
+	if value, ok := cqc.mutation.CreatedAt(); ok {
+		_spec.SetField(crawlqueue.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := cqc.mutation.UpdatedAt(); ok {
+		_spec.SetField(crawlqueue.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// CrawlQueueCreateBulk is the builder for creating many CrawlQueue entities in bulk.
+type CrawlQueueCreateBulk struct {
+	config
+	err      error
+	builders []*CrawlQueueCreate
+}
+
+// Save creates the CrawlQueue entities in the database.
+func (cqcb *CrawlQueueCreateBulk) Save(ctx context.Context) ([]*CrawlQueue, error) {
+	if cqcb.err != nil {
+		return nil, cqcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(cqcb.builders))
+	nodes := make([]*CrawlQueue, len(cqcb.builders))
+	mutators := make([]Mutator, len(cqcb.builders))
+	for i := range cqcb.builders {
+		func(i int, root context.Context) {
+			builder := cqcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CrawlQueueMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, cqcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, cqcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, cqcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cqcb *CrawlQueueCreateBulk) SaveX(ctx context.Context) []*CrawlQueue {
+	v, err := cqcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cqcb *CrawlQueueCreateBulk) Exec(ctx context.Context) error {
+	_, err := cqcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqcb *CrawlQueueCreateBulk) ExecX(ctx context.Context) {
+	if err := cqcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
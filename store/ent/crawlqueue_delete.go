@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
+	"github.com/editorpost/spider/store/ent/predicate"
+)
+
+// CrawlQueueDelete is the builder for deleting a CrawlQueue entity.
+type CrawlQueueDelete struct {
+	config
+	hooks    []Hook
+	mutation *CrawlQueueMutation
+}
+
+// Where appends a list predicates to the CrawlQueueDelete builder.
+func (cqd *CrawlQueueDelete) Where(ps ...predicate.CrawlQueue) *CrawlQueueDelete {
+	cqd.mutation.Where(ps...)
+	return cqd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (cqd *CrawlQueueDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, cqd.sqlExec, cqd.mutation, cqd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqd *CrawlQueueDelete) ExecX(ctx context.Context) int {
+	n, err := cqd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (cqd *CrawlQueueDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(crawlqueue.Table, sqlgraph.NewFieldSpec(crawlqueue.FieldID, field.TypeUUID))
+	if ps := cqd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, cqd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	cqd.mutation.done = true
+	return affected, err
+}
+
+// CrawlQueueDeleteOne is the builder for deleting a single CrawlQueue entity.
+type CrawlQueueDeleteOne struct {
+	cqd *CrawlQueueDelete
+}
+
+// Where appends a list predicates to the CrawlQueueDelete builder.
+func (cqdo *CrawlQueueDeleteOne) Where(ps ...predicate.CrawlQueue) *CrawlQueueDeleteOne {
+	cqdo.cqd.mutation.Where(ps...)
+	return cqdo
+}
+
+// Exec executes the deletion query.
+func (cqdo *CrawlQueueDeleteOne) Exec(ctx context.Context) error {
+	n, err := cqdo.cqd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{crawlqueue.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqdo *CrawlQueueDeleteOne) ExecX(ctx context.Context) {
+	if err := cqdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
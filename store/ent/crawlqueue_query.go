@@ -0,0 +1,528 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
+	"github.com/editorpost/spider/store/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// CrawlQueueQuery is the builder for querying CrawlQueue entities.
+type CrawlQueueQuery struct {
+	config
+	ctx        *QueryContext
+	order      []crawlqueue.OrderOption
+	inters     []Interceptor
+	predicates []predicate.CrawlQueue
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the CrawlQueueQuery builder.
+func (cqq *CrawlQueueQuery) Where(ps ...predicate.CrawlQueue) *CrawlQueueQuery {
+	cqq.predicates = append(cqq.predicates, ps...)
+	return cqq
+}
+
+// Limit the number of records to be returned by this query.
+func (cqq *CrawlQueueQuery) Limit(limit int) *CrawlQueueQuery {
+	cqq.ctx.Limit = &limit
+	return cqq
+}
+
+// Offset to start from.
+func (cqq *CrawlQueueQuery) Offset(offset int) *CrawlQueueQuery {
+	cqq.ctx.Offset = &offset
+	return cqq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (cqq *CrawlQueueQuery) Unique(unique bool) *CrawlQueueQuery {
+	cqq.ctx.Unique = &unique
+	return cqq
+}
+
+// Order specifies how the records should be ordered.
+func (cqq *CrawlQueueQuery) Order(o ...crawlqueue.OrderOption) *CrawlQueueQuery {
+	cqq.order = append(cqq.order, o...)
+	return cqq
+}
+
+// First returns the first CrawlQueue entity from the query.
+// Returns a *NotFoundError when no CrawlQueue was found.
+func (cqq *CrawlQueueQuery) First(ctx context.Context) (*CrawlQueue, error) {
+	nodes, err := cqq.Limit(1).All(setContextOp(ctx, cqq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{crawlqueue.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) FirstX(ctx context.Context) *CrawlQueue {
+	node, err := cqq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first CrawlQueue ID from the query.
+// Returns a *NotFoundError when no CrawlQueue ID was found.
+func (cqq *CrawlQueueQuery) FirstID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = cqq.Limit(1).IDs(setContextOp(ctx, cqq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{crawlqueue.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) FirstIDX(ctx context.Context) uuid.UUID {
+	id, err := cqq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single CrawlQueue entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one CrawlQueue entity is found.
+// Returns a *NotFoundError when no CrawlQueue entities are found.
+func (cqq *CrawlQueueQuery) Only(ctx context.Context) (*CrawlQueue, error) {
+	nodes, err := cqq.Limit(2).All(setContextOp(ctx, cqq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{crawlqueue.Label}
+	default:
+		return nil, &NotSingularError{crawlqueue.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) OnlyX(ctx context.Context) *CrawlQueue {
+	node, err := cqq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only CrawlQueue ID in the query.
+// Returns a *NotSingularError when more than one CrawlQueue ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (cqq *CrawlQueueQuery) OnlyID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = cqq.Limit(2).IDs(setContextOp(ctx, cqq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{crawlqueue.Label}
+	default:
+		err = &NotSingularError{crawlqueue.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) OnlyIDX(ctx context.Context) uuid.UUID {
+	id, err := cqq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of CrawlQueues.
+func (cqq *CrawlQueueQuery) All(ctx context.Context) ([]*CrawlQueue, error) {
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryAll)
+	if err := cqq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*CrawlQueue, *CrawlQueueQuery]()
+	return withInterceptors[[]*CrawlQueue](ctx, cqq, qr, cqq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) AllX(ctx context.Context) []*CrawlQueue {
+	nodes, err := cqq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of CrawlQueue IDs.
+func (cqq *CrawlQueueQuery) IDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	if cqq.ctx.Unique == nil && cqq.path != nil {
+		cqq.Unique(true)
+	}
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryIDs)
+	if err = cqq.Select(crawlqueue.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) IDsX(ctx context.Context) []uuid.UUID {
+	ids, err := cqq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (cqq *CrawlQueueQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryCount)
+	if err := cqq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, cqq, querierCount[*CrawlQueueQuery](), cqq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) CountX(ctx context.Context) int {
+	count, err := cqq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (cqq *CrawlQueueQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryExist)
+	switch _, err := cqq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (cqq *CrawlQueueQuery) ExistX(ctx context.Context) bool {
+	exist, err := cqq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the CrawlQueueQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (cqq *CrawlQueueQuery) Clone() *CrawlQueueQuery {
+	if cqq == nil {
+		return nil
+	}
+	return &CrawlQueueQuery{
+		config:     cqq.config,
+		ctx:        cqq.ctx.Clone(),
+		order:      append([]crawlqueue.OrderOption{}, cqq.order...),
+		inters:     append([]Interceptor{}, cqq.inters...),
+		predicates: append([]predicate.CrawlQueue{}, cqq.predicates...),
+		// clone intermediate query.
+		sql:  cqq.sql.Clone(),
+		path: cqq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		SpiderID uuid.UUID `json:"spider_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.CrawlQueue.Query().
+//		GroupBy(crawlqueue.FieldSpiderID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (cqq *CrawlQueueQuery) GroupBy(field string, fields ...string) *CrawlQueueGroupBy {
+	cqq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &CrawlQueueGroupBy{build: cqq}
+	grbuild.flds = &cqq.ctx.Fields
+	grbuild.label = crawlqueue.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		SpiderID uuid.UUID `json:"spider_id,omitempty"`
+//	}
+//
+//	client.CrawlQueue.Query().
+//		Select(crawlqueue.FieldSpiderID).
+//		Scan(ctx, &v)
+func (cqq *CrawlQueueQuery) Select(fields ...string) *CrawlQueueSelect {
+	cqq.ctx.Fields = append(cqq.ctx.Fields, fields...)
+	sbuild := &CrawlQueueSelect{CrawlQueueQuery: cqq}
+	sbuild.label = crawlqueue.Label
+	sbuild.flds, sbuild.scan = &cqq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a CrawlQueueSelect configured with the given aggregations.
+func (cqq *CrawlQueueQuery) Aggregate(fns ...AggregateFunc) *CrawlQueueSelect {
+	return cqq.Select().Aggregate(fns...)
+}
+
+func (cqq *CrawlQueueQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range cqq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, cqq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range cqq.ctx.Fields {
+		if !crawlqueue.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if cqq.path != nil {
+		prev, err := cqq.path(ctx)
+		if err != nil {
+			return err
+		}
+		cqq.sql = prev
+	}
+	return nil
+}
+
+func (cqq *CrawlQueueQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*CrawlQueue, error) {
+	var (
+		nodes = []*CrawlQueue{}
+		_spec = cqq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*CrawlQueue).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &CrawlQueue{config: cqq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, cqq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (cqq *CrawlQueueQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := cqq.querySpec()
+	_spec.Node.Columns = cqq.ctx.Fields
+	if len(cqq.ctx.Fields) > 0 {
+		_spec.Unique = cqq.ctx.Unique != nil && *cqq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, cqq.driver, _spec)
+}
+
+func (cqq *CrawlQueueQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(crawlqueue.Table, crawlqueue.Columns, sqlgraph.NewFieldSpec(crawlqueue.FieldID, field.TypeUUID))
+	_spec.From = cqq.sql
+	if unique := cqq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if cqq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := cqq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, crawlqueue.FieldID)
+		for i := range fields {
+			if fields[i] != crawlqueue.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := cqq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := cqq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := cqq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := cqq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (cqq *CrawlQueueQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(cqq.driver.Dialect())
+	t1 := builder.Table(crawlqueue.Table)
+	columns := cqq.ctx.Fields
+	if len(columns) == 0 {
+		columns = crawlqueue.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if cqq.sql != nil {
+		selector = cqq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if cqq.ctx.Unique != nil && *cqq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range cqq.predicates {
+		p(selector)
+	}
+	for _, p := range cqq.order {
+		p(selector)
+	}
+	if offset := cqq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := cqq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// CrawlQueueGroupBy is the group-by builder for CrawlQueue entities.
+type CrawlQueueGroupBy struct {
+	selector
+	build *CrawlQueueQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (cqgb *CrawlQueueGroupBy) Aggregate(fns ...AggregateFunc) *CrawlQueueGroupBy {
+	cqgb.fns = append(cqgb.fns, fns...)
+	return cqgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cqgb *CrawlQueueGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cqgb.build.ctx, ent.OpQueryGroupBy)
+	if err := cqgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*CrawlQueueQuery, *CrawlQueueGroupBy](ctx, cqgb.build, cqgb, cqgb.build.inters, v)
+}
+
+func (cqgb *CrawlQueueGroupBy) sqlScan(ctx context.Context, root *CrawlQueueQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(cqgb.fns))
+	for _, fn := range cqgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*cqgb.flds)+len(cqgb.fns))
+		for _, f := range *cqgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*cqgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cqgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// CrawlQueueSelect is the builder for selecting fields of CrawlQueue entities.
+type CrawlQueueSelect struct {
+	*CrawlQueueQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (cqs *CrawlQueueSelect) Aggregate(fns ...AggregateFunc) *CrawlQueueSelect {
+	cqs.fns = append(cqs.fns, fns...)
+	return cqs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cqs *CrawlQueueSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cqs.ctx, ent.OpQuerySelect)
+	if err := cqs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*CrawlQueueQuery, *CrawlQueueSelect](ctx, cqs.CrawlQueueQuery, cqs, cqs.inters, v)
+}
+
+func (cqs *CrawlQueueSelect) sqlScan(ctx context.Context, root *CrawlQueueQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(cqs.fns))
+	for _, fn := range cqs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*cqs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cqs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,583 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
+	"github.com/editorpost/spider/store/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// CrawlQueueUpdate is the builder for updating CrawlQueue entities.
+type CrawlQueueUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CrawlQueueMutation
+}
+
+// Where appends a list predicates to the CrawlQueueUpdate builder.
+func (cqu *CrawlQueueUpdate) Where(ps ...predicate.CrawlQueue) *CrawlQueueUpdate {
+	cqu.mutation.Where(ps...)
+	return cqu
+}
+
+// SetSpiderID sets the "spider_id" field.
+func (cqu *CrawlQueueUpdate) SetSpiderID(u uuid.UUID) *CrawlQueueUpdate {
+	cqu.mutation.SetSpiderID(u)
+	return cqu
+}
+
+// SetNillableSpiderID sets the "spider_id" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableSpiderID(u *uuid.UUID) *CrawlQueueUpdate {
+	if u != nil {
+		cqu.SetSpiderID(*u)
+	}
+	return cqu
+}
+
+// SetQueue sets the "queue" field.
+func (cqu *CrawlQueueUpdate) SetQueue(s string) *CrawlQueueUpdate {
+	cqu.mutation.SetQueue(s)
+	return cqu
+}
+
+// SetNillableQueue sets the "queue" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableQueue(s *string) *CrawlQueueUpdate {
+	if s != nil {
+		cqu.SetQueue(*s)
+	}
+	return cqu
+}
+
+// SetURL sets the "url" field.
+func (cqu *CrawlQueueUpdate) SetURL(s string) *CrawlQueueUpdate {
+	cqu.mutation.SetURL(s)
+	return cqu
+}
+
+// SetNillableURL sets the "url" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableURL(s *string) *CrawlQueueUpdate {
+	if s != nil {
+		cqu.SetURL(*s)
+	}
+	return cqu
+}
+
+// SetDepth sets the "depth" field.
+func (cqu *CrawlQueueUpdate) SetDepth(i int) *CrawlQueueUpdate {
+	cqu.mutation.ResetDepth()
+	cqu.mutation.SetDepth(i)
+	return cqu
+}
+
+// SetNillableDepth sets the "depth" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableDepth(i *int) *CrawlQueueUpdate {
+	if i != nil {
+		cqu.SetDepth(*i)
+	}
+	return cqu
+}
+
+// AddDepth adds i to the "depth" field.
+func (cqu *CrawlQueueUpdate) AddDepth(i int) *CrawlQueueUpdate {
+	cqu.mutation.AddDepth(i)
+	return cqu
+}
+
+// SetPriority sets the "priority" field.
+func (cqu *CrawlQueueUpdate) SetPriority(i int) *CrawlQueueUpdate {
+	cqu.mutation.ResetPriority()
+	cqu.mutation.SetPriority(i)
+	return cqu
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillablePriority(i *int) *CrawlQueueUpdate {
+	if i != nil {
+		cqu.SetPriority(*i)
+	}
+	return cqu
+}
+
+// AddPriority adds i to the "priority" field.
+func (cqu *CrawlQueueUpdate) AddPriority(i int) *CrawlQueueUpdate {
+	cqu.mutation.AddPriority(i)
+	return cqu
+}
+
+// SetState sets the "state" field.
+func (cqu *CrawlQueueUpdate) SetState(u uint8) *CrawlQueueUpdate {
+	cqu.mutation.ResetState()
+	cqu.mutation.SetState(u)
+	return cqu
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableState(u *uint8) *CrawlQueueUpdate {
+	if u != nil {
+		cqu.SetState(*u)
+	}
+	return cqu
+}
+
+// AddState adds u to the "state" field.
+func (cqu *CrawlQueueUpdate) AddState(u int8) *CrawlQueueUpdate {
+	cqu.mutation.AddState(u)
+	return cqu
+}
+
+// SetAttempts sets the "attempts" field.
+func (cqu *CrawlQueueUpdate) SetAttempts(i int) *CrawlQueueUpdate {
+	cqu.mutation.ResetAttempts()
+	cqu.mutation.SetAttempts(i)
+	return cqu
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableAttempts(i *int) *CrawlQueueUpdate {
+	if i != nil {
+		cqu.SetAttempts(*i)
+	}
+	return cqu
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (cqu *CrawlQueueUpdate) AddAttempts(i int) *CrawlQueueUpdate {
+	cqu.mutation.AddAttempts(i)
+	return cqu
+}
+
+// SetRequest sets the "request" field.
+func (cqu *CrawlQueueUpdate) SetRequest(b []byte) *CrawlQueueUpdate {
+	cqu.mutation.SetRequest(b)
+	return cqu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cqu *CrawlQueueUpdate) SetCreatedAt(t time.Time) *CrawlQueueUpdate {
+	cqu.mutation.SetCreatedAt(t)
+	return cqu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cqu *CrawlQueueUpdate) SetNillableCreatedAt(t *time.Time) *CrawlQueueUpdate {
+	if t != nil {
+		cqu.SetCreatedAt(*t)
+	}
+	return cqu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (cqu *CrawlQueueUpdate) SetUpdatedAt(t time.Time) *CrawlQueueUpdate {
+	cqu.mutation.SetUpdatedAt(t)
+	return cqu
+}
+
+// Mutation returns the CrawlQueueMutation object of the builder.
+func (cqu *CrawlQueueUpdate) Mutation() *CrawlQueueMutation {
+	return cqu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cqu *CrawlQueueUpdate) Save(ctx context.Context) (int, error) {
+	cqu.defaults()
+	return withHooks(ctx, cqu.sqlSave, cqu.mutation, cqu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cqu *CrawlQueueUpdate) SaveX(ctx context.Context) int {
+	affected, err := cqu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cqu *CrawlQueueUpdate) Exec(ctx context.Context) error {
+	_, err := cqu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqu *CrawlQueueUpdate) ExecX(ctx context.Context) {
+	if err := cqu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cqu *CrawlQueueUpdate) defaults() {
+	if _, ok := cqu.mutation.UpdatedAt(); !ok {
+		v := crawlqueue.UpdateDefaultUpdatedAt()
+		cqu.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (cqu *CrawlQueueUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(crawlqueue.Table, crawlqueue.Columns, sqlgraph.NewFieldSpec(crawlqueue.FieldID, field.TypeUUID))
+	if ps := cqu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cqu.mutation.SpiderID(); ok {
+		_spec.SetField(crawlqueue.FieldSpiderID, field.TypeUUID, value)
+	}
+	if value, ok := cqu.mutation.Queue(); ok {
+		_spec.SetField(crawlqueue.FieldQueue, field.TypeString, value)
+	}
+	if value, ok := cqu.mutation.URL(); ok {
+		_spec.SetField(crawlqueue.FieldURL, field.TypeString, value)
+	}
+	if value, ok := cqu.mutation.Depth(); ok {
+		_spec.SetField(crawlqueue.FieldDepth, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.AddedDepth(); ok {
+		_spec.AddField(crawlqueue.FieldDepth, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.Priority(); ok {
+		_spec.SetField(crawlqueue.FieldPriority, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.AddedPriority(); ok {
+		_spec.AddField(crawlqueue.FieldPriority, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.State(); ok {
+		_spec.SetField(crawlqueue.FieldState, field.TypeUint8, value)
+	}
+	if value, ok := cqu.mutation.AddedState(); ok {
+		_spec.AddField(crawlqueue.FieldState, field.TypeUint8, value)
+	}
+	if value, ok := cqu.mutation.Attempts(); ok {
+		_spec.SetField(crawlqueue.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.AddedAttempts(); ok {
+		_spec.AddField(crawlqueue.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.Request(); ok {
+		_spec.SetField(crawlqueue.FieldRequest, field.TypeBytes, value)
+	}
+	if value, ok := cqu.mutation.CreatedAt(); ok {
+		_spec.SetField(crawlqueue.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := cqu.mutation.UpdatedAt(); ok {
+		_spec.SetField(crawlqueue.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cqu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{crawlqueue.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cqu.mutation.done = true
+	return n, nil
+}
+
+// CrawlQueueUpdateOne is the builder for updating a single CrawlQueue entity.
+type CrawlQueueUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CrawlQueueMutation
+}
+
+// SetSpiderID sets the "spider_id" field.
+func (cquo *CrawlQueueUpdateOne) SetSpiderID(u uuid.UUID) *CrawlQueueUpdateOne {
+	cquo.mutation.SetSpiderID(u)
+	return cquo
+}
+
+// SetNillableSpiderID sets the "spider_id" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableSpiderID(u *uuid.UUID) *CrawlQueueUpdateOne {
+	if u != nil {
+		cquo.SetSpiderID(*u)
+	}
+	return cquo
+}
+
+// SetQueue sets the "queue" field.
+func (cquo *CrawlQueueUpdateOne) SetQueue(s string) *CrawlQueueUpdateOne {
+	cquo.mutation.SetQueue(s)
+	return cquo
+}
+
+// SetNillableQueue sets the "queue" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableQueue(s *string) *CrawlQueueUpdateOne {
+	if s != nil {
+		cquo.SetQueue(*s)
+	}
+	return cquo
+}
+
+// SetURL sets the "url" field.
+func (cquo *CrawlQueueUpdateOne) SetURL(s string) *CrawlQueueUpdateOne {
+	cquo.mutation.SetURL(s)
+	return cquo
+}
+
+// SetNillableURL sets the "url" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableURL(s *string) *CrawlQueueUpdateOne {
+	if s != nil {
+		cquo.SetURL(*s)
+	}
+	return cquo
+}
+
+// SetDepth sets the "depth" field.
+func (cquo *CrawlQueueUpdateOne) SetDepth(i int) *CrawlQueueUpdateOne {
+	cquo.mutation.ResetDepth()
+	cquo.mutation.SetDepth(i)
+	return cquo
+}
+
+// SetNillableDepth sets the "depth" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableDepth(i *int) *CrawlQueueUpdateOne {
+	if i != nil {
+		cquo.SetDepth(*i)
+	}
+	return cquo
+}
+
+// AddDepth adds i to the "depth" field.
+func (cquo *CrawlQueueUpdateOne) AddDepth(i int) *CrawlQueueUpdateOne {
+	cquo.mutation.AddDepth(i)
+	return cquo
+}
+
+// SetPriority sets the "priority" field.
+func (cquo *CrawlQueueUpdateOne) SetPriority(i int) *CrawlQueueUpdateOne {
+	cquo.mutation.ResetPriority()
+	cquo.mutation.SetPriority(i)
+	return cquo
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillablePriority(i *int) *CrawlQueueUpdateOne {
+	if i != nil {
+		cquo.SetPriority(*i)
+	}
+	return cquo
+}
+
+// AddPriority adds i to the "priority" field.
+func (cquo *CrawlQueueUpdateOne) AddPriority(i int) *CrawlQueueUpdateOne {
+	cquo.mutation.AddPriority(i)
+	return cquo
+}
+
+// SetState sets the "state" field.
+func (cquo *CrawlQueueUpdateOne) SetState(u uint8) *CrawlQueueUpdateOne {
+	cquo.mutation.ResetState()
+	cquo.mutation.SetState(u)
+	return cquo
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableState(u *uint8) *CrawlQueueUpdateOne {
+	if u != nil {
+		cquo.SetState(*u)
+	}
+	return cquo
+}
+
+// AddState adds u to the "state" field.
+func (cquo *CrawlQueueUpdateOne) AddState(u int8) *CrawlQueueUpdateOne {
+	cquo.mutation.AddState(u)
+	return cquo
+}
+
+// SetAttempts sets the "attempts" field.
+func (cquo *CrawlQueueUpdateOne) SetAttempts(i int) *CrawlQueueUpdateOne {
+	cquo.mutation.ResetAttempts()
+	cquo.mutation.SetAttempts(i)
+	return cquo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableAttempts(i *int) *CrawlQueueUpdateOne {
+	if i != nil {
+		cquo.SetAttempts(*i)
+	}
+	return cquo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (cquo *CrawlQueueUpdateOne) AddAttempts(i int) *CrawlQueueUpdateOne {
+	cquo.mutation.AddAttempts(i)
+	return cquo
+}
+
+// SetRequest sets the "request" field.
+func (cquo *CrawlQueueUpdateOne) SetRequest(b []byte) *CrawlQueueUpdateOne {
+	cquo.mutation.SetRequest(b)
+	return cquo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cquo *CrawlQueueUpdateOne) SetCreatedAt(t time.Time) *CrawlQueueUpdateOne {
+	cquo.mutation.SetCreatedAt(t)
+	return cquo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cquo *CrawlQueueUpdateOne) SetNillableCreatedAt(t *time.Time) *CrawlQueueUpdateOne {
+	if t != nil {
+		cquo.SetCreatedAt(*t)
+	}
+	return cquo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (cquo *CrawlQueueUpdateOne) SetUpdatedAt(t time.Time) *CrawlQueueUpdateOne {
+	cquo.mutation.SetUpdatedAt(t)
+	return cquo
+}
+
+// Mutation returns the CrawlQueueMutation object of the builder.
+func (cquo *CrawlQueueUpdateOne) Mutation() *CrawlQueueMutation {
+	return cquo.mutation
+}
+
+// Where appends a list predicates to the CrawlQueueUpdate builder.
+func (cquo *CrawlQueueUpdateOne) Where(ps ...predicate.CrawlQueue) *CrawlQueueUpdateOne {
+	cquo.mutation.Where(ps...)
+	return cquo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cquo *CrawlQueueUpdateOne) Select(field string, fields ...string) *CrawlQueueUpdateOne {
+	cquo.fields = append([]string{field}, fields...)
+	return cquo
+}
+
+// Save executes the query and returns the updated CrawlQueue entity.
+func (cquo *CrawlQueueUpdateOne) Save(ctx context.Context) (*CrawlQueue, error) {
+	cquo.defaults()
+	return withHooks(ctx, cquo.sqlSave, cquo.mutation, cquo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cquo *CrawlQueueUpdateOne) SaveX(ctx context.Context) *CrawlQueue {
+	node, err := cquo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cquo *CrawlQueueUpdateOne) Exec(ctx context.Context) error {
+	_, err := cquo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cquo *CrawlQueueUpdateOne) ExecX(ctx context.Context) {
+	if err := cquo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cquo *CrawlQueueUpdateOne) defaults() {
+	if _, ok := cquo.mutation.UpdatedAt(); !ok {
+		v := crawlqueue.UpdateDefaultUpdatedAt()
+		cquo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (cquo *CrawlQueueUpdateOne) sqlSave(ctx context.Context) (_node *CrawlQueue, err error) {
+	_spec := sqlgraph.NewUpdateSpec(crawlqueue.Table, crawlqueue.Columns, sqlgraph.NewFieldSpec(crawlqueue.FieldID, field.TypeUUID))
+	id, ok := cquo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "CrawlQueue.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cquo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, crawlqueue.FieldID)
+		for _, f := range fields {
+			if !crawlqueue.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != crawlqueue.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cquo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cquo.mutation.SpiderID(); ok {
+		_spec.SetField(crawlqueue.FieldSpiderID, field.TypeUUID, value)
+	}
+	if value, ok := cquo.mutation.Queue(); ok {
+		_spec.SetField(crawlqueue.FieldQueue, field.TypeString, value)
+	}
+	if value, ok := cquo.mutation.URL(); ok {
+		_spec.SetField(crawlqueue.FieldURL, field.TypeString, value)
+	}
+	if value, ok := cquo.mutation.Depth(); ok {
+		_spec.SetField(crawlqueue.FieldDepth, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.AddedDepth(); ok {
+		_spec.AddField(crawlqueue.FieldDepth, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.Priority(); ok {
+		_spec.SetField(crawlqueue.FieldPriority, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.AddedPriority(); ok {
+		_spec.AddField(crawlqueue.FieldPriority, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.State(); ok {
+		_spec.SetField(crawlqueue.FieldState, field.TypeUint8, value)
+	}
+	if value, ok := cquo.mutation.AddedState(); ok {
+		_spec.AddField(crawlqueue.FieldState, field.TypeUint8, value)
+	}
+	if value, ok := cquo.mutation.Attempts(); ok {
+		_spec.SetField(crawlqueue.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.AddedAttempts(); ok {
+		_spec.AddField(crawlqueue.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.Request(); ok {
+		_spec.SetField(crawlqueue.FieldRequest, field.TypeBytes, value)
+	}
+	if value, ok := cquo.mutation.CreatedAt(); ok {
+		_spec.SetField(crawlqueue.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := cquo.mutation.UpdatedAt(); ok {
+		_spec.SetField(crawlqueue.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &CrawlQueue{config: cquo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cquo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{crawlqueue.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cquo.mutation.done = true
+	return _node, nil
+}
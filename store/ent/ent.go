@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
 	"github.com/editorpost/spider/store/ent/spiderpayload"
 )
 
@@ -73,6 +74,7 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
+			crawlqueue.Table:    crawlqueue.ValidColumn,
 			spiderpayload.Table: spiderpayload.ValidColumn,
 		})
 	})
@@ -9,6 +9,18 @@ import (
 	"github.com/editorpost/spider/store/ent"
 )
 
+// The CrawlQueueFunc type is an adapter to allow the use of ordinary
+// function as CrawlQueue mutator.
+type CrawlQueueFunc func(context.Context, *ent.CrawlQueueMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f CrawlQueueFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.CrawlQueueMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.CrawlQueueMutation", m)
+}
+
 // The SpiderPayloadFunc type is an adapter to allow the use of ordinary
 // function as SpiderPayload mutator.
 type SpiderPayloadFunc func(context.Context, *ent.SpiderPayloadMutation) (ent.Value, error)
@@ -8,6 +8,33 @@ import (
 )
 
 var (
+	// CrawlQueuesColumns holds the columns for the "crawl_queues" table.
+	CrawlQueuesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "spider_id", Type: field.TypeUUID},
+		{Name: "queue", Type: field.TypeString},
+		{Name: "url", Type: field.TypeString, Size: 2147483647},
+		{Name: "depth", Type: field.TypeInt, Default: 0},
+		{Name: "priority", Type: field.TypeInt, Default: 0},
+		{Name: "state", Type: field.TypeUint8, Default: 1},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "request", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// CrawlQueuesTable holds the schema information for the "crawl_queues" table.
+	CrawlQueuesTable = &schema.Table{
+		Name:       "crawl_queues",
+		Columns:    CrawlQueuesColumns,
+		PrimaryKey: []*schema.Column{CrawlQueuesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "crawlqueue_spider_id_queue_state",
+				Unique:  false,
+				Columns: []*schema.Column{CrawlQueuesColumns[1], CrawlQueuesColumns[2], CrawlQueuesColumns[6]},
+			},
+		},
+	}
 	// SpiderPayloadsColumns holds the columns for the "spider_payloads" table.
 	SpiderPayloadsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -19,6 +46,8 @@ var (
 		{Name: "title", Type: field.TypeString},
 		{Name: "job_provider", Type: field.TypeString, Nullable: true},
 		{Name: "job_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "screenshot", Type: field.TypeString, Nullable: true},
+		{Name: "pdf", Type: field.TypeString, Nullable: true},
 	}
 	// SpiderPayloadsTable holds the schema information for the "spider_payloads" table.
 	SpiderPayloadsTable = &schema.Table{
@@ -35,6 +64,7 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		CrawlQueuesTable,
 		SpiderPayloadsTable,
 	}
 )
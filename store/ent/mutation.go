@@ -11,6 +11,7 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
 	"github.com/editorpost/spider/store/ent/predicate"
 	"github.com/editorpost/spider/store/ent/spiderpayload"
 	"github.com/google/uuid"
@@ -25,9 +26,963 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
+	TypeCrawlQueue    = "CrawlQueue"
 	TypeSpiderPayload = "SpiderPayload"
 )
 
+// CrawlQueueMutation represents an operation that mutates the CrawlQueue nodes in the graph.
+type CrawlQueueMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	spider_id     *uuid.UUID
+	queue         *string
+	url           *string
+	depth         *int
+	adddepth      *int
+	priority      *int
+	addpriority   *int
+	state         *uint8
+	addstate      *int8
+	attempts      *int
+	addattempts   *int
+	request       *[]byte
+	created_at    *time.Time
+	updated_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*CrawlQueue, error)
+	predicates    []predicate.CrawlQueue
+}
+
+var _ ent.Mutation = (*CrawlQueueMutation)(nil)
+
+// crawlqueueOption allows management of the mutation configuration using functional options.
+type crawlqueueOption func(*CrawlQueueMutation)
+
+// newCrawlQueueMutation creates new mutation for the CrawlQueue entity.
+func newCrawlQueueMutation(c config, op Op, opts ...crawlqueueOption) *CrawlQueueMutation {
+	m := &CrawlQueueMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeCrawlQueue,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withCrawlQueueID sets the ID field of the mutation.
+func withCrawlQueueID(id uuid.UUID) crawlqueueOption {
+	return func(m *CrawlQueueMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *CrawlQueue
+		)
+		m.oldValue = func(ctx context.Context) (*CrawlQueue, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().CrawlQueue.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withCrawlQueue sets the old CrawlQueue of the mutation.
+func withCrawlQueue(node *CrawlQueue) crawlqueueOption {
+	return func(m *CrawlQueueMutation) {
+		m.oldValue = func(context.Context) (*CrawlQueue, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m CrawlQueueMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m CrawlQueueMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of CrawlQueue entities.
+func (m *CrawlQueueMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *CrawlQueueMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *CrawlQueueMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().CrawlQueue.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetSpiderID sets the "spider_id" field.
+func (m *CrawlQueueMutation) SetSpiderID(u uuid.UUID) {
+	m.spider_id = &u
+}
+
+// SpiderID returns the value of the "spider_id" field in the mutation.
+func (m *CrawlQueueMutation) SpiderID() (r uuid.UUID, exists bool) {
+	v := m.spider_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSpiderID returns the old "spider_id" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldSpiderID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSpiderID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSpiderID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSpiderID: %w", err)
+	}
+	return oldValue.SpiderID, nil
+}
+
+// ResetSpiderID resets all changes to the "spider_id" field.
+func (m *CrawlQueueMutation) ResetSpiderID() {
+	m.spider_id = nil
+}
+
+// SetQueue sets the "queue" field.
+func (m *CrawlQueueMutation) SetQueue(s string) {
+	m.queue = &s
+}
+
+// Queue returns the value of the "queue" field in the mutation.
+func (m *CrawlQueueMutation) Queue() (r string, exists bool) {
+	v := m.queue
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQueue returns the old "queue" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldQueue(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQueue is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQueue requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQueue: %w", err)
+	}
+	return oldValue.Queue, nil
+}
+
+// ResetQueue resets all changes to the "queue" field.
+func (m *CrawlQueueMutation) ResetQueue() {
+	m.queue = nil
+}
+
+// SetURL sets the "url" field.
+func (m *CrawlQueueMutation) SetURL(s string) {
+	m.url = &s
+}
+
+// URL returns the value of the "url" field in the mutation.
+func (m *CrawlQueueMutation) URL() (r string, exists bool) {
+	v := m.url
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldURL returns the old "url" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldURL(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldURL is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldURL requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldURL: %w", err)
+	}
+	return oldValue.URL, nil
+}
+
+// ResetURL resets all changes to the "url" field.
+func (m *CrawlQueueMutation) ResetURL() {
+	m.url = nil
+}
+
+// SetDepth sets the "depth" field.
+func (m *CrawlQueueMutation) SetDepth(i int) {
+	m.depth = &i
+	m.adddepth = nil
+}
+
+// Depth returns the value of the "depth" field in the mutation.
+func (m *CrawlQueueMutation) Depth() (r int, exists bool) {
+	v := m.depth
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDepth returns the old "depth" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldDepth(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDepth is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDepth requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDepth: %w", err)
+	}
+	return oldValue.Depth, nil
+}
+
+// AddDepth adds i to the "depth" field.
+func (m *CrawlQueueMutation) AddDepth(i int) {
+	if m.adddepth != nil {
+		*m.adddepth += i
+	} else {
+		m.adddepth = &i
+	}
+}
+
+// AddedDepth returns the value that was added to the "depth" field in this mutation.
+func (m *CrawlQueueMutation) AddedDepth() (r int, exists bool) {
+	v := m.adddepth
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetDepth resets all changes to the "depth" field.
+func (m *CrawlQueueMutation) ResetDepth() {
+	m.depth = nil
+	m.adddepth = nil
+}
+
+// SetPriority sets the "priority" field.
+func (m *CrawlQueueMutation) SetPriority(i int) {
+	m.priority = &i
+	m.addpriority = nil
+}
+
+// Priority returns the value of the "priority" field in the mutation.
+func (m *CrawlQueueMutation) Priority() (r int, exists bool) {
+	v := m.priority
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPriority returns the old "priority" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldPriority(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPriority is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPriority requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPriority: %w", err)
+	}
+	return oldValue.Priority, nil
+}
+
+// AddPriority adds i to the "priority" field.
+func (m *CrawlQueueMutation) AddPriority(i int) {
+	if m.addpriority != nil {
+		*m.addpriority += i
+	} else {
+		m.addpriority = &i
+	}
+}
+
+// AddedPriority returns the value that was added to the "priority" field in this mutation.
+func (m *CrawlQueueMutation) AddedPriority() (r int, exists bool) {
+	v := m.addpriority
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPriority resets all changes to the "priority" field.
+func (m *CrawlQueueMutation) ResetPriority() {
+	m.priority = nil
+	m.addpriority = nil
+}
+
+// SetState sets the "state" field.
+func (m *CrawlQueueMutation) SetState(u uint8) {
+	m.state = &u
+	m.addstate = nil
+}
+
+// State returns the value of the "state" field in the mutation.
+func (m *CrawlQueueMutation) State() (r uint8, exists bool) {
+	v := m.state
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldState returns the old "state" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldState(ctx context.Context) (v uint8, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldState is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldState requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldState: %w", err)
+	}
+	return oldValue.State, nil
+}
+
+// AddState adds u to the "state" field.
+func (m *CrawlQueueMutation) AddState(u int8) {
+	if m.addstate != nil {
+		*m.addstate += u
+	} else {
+		m.addstate = &u
+	}
+}
+
+// AddedState returns the value that was added to the "state" field in this mutation.
+func (m *CrawlQueueMutation) AddedState() (r int8, exists bool) {
+	v := m.addstate
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetState resets all changes to the "state" field.
+func (m *CrawlQueueMutation) ResetState() {
+	m.state = nil
+	m.addstate = nil
+}
+
+// SetAttempts sets the "attempts" field.
+func (m *CrawlQueueMutation) SetAttempts(i int) {
+	m.attempts = &i
+	m.addattempts = nil
+}
+
+// Attempts returns the value of the "attempts" field in the mutation.
+func (m *CrawlQueueMutation) Attempts() (r int, exists bool) {
+	v := m.attempts
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAttempts returns the old "attempts" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldAttempts(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAttempts is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAttempts requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAttempts: %w", err)
+	}
+	return oldValue.Attempts, nil
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (m *CrawlQueueMutation) AddAttempts(i int) {
+	if m.addattempts != nil {
+		*m.addattempts += i
+	} else {
+		m.addattempts = &i
+	}
+}
+
+// AddedAttempts returns the value that was added to the "attempts" field in this mutation.
+func (m *CrawlQueueMutation) AddedAttempts() (r int, exists bool) {
+	v := m.addattempts
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAttempts resets all changes to the "attempts" field.
+func (m *CrawlQueueMutation) ResetAttempts() {
+	m.attempts = nil
+	m.addattempts = nil
+}
+
+// SetRequest sets the "request" field.
+func (m *CrawlQueueMutation) SetRequest(b []byte) {
+	m.request = &b
+}
+
+// Request returns the value of the "request" field in the mutation.
+func (m *CrawlQueueMutation) Request() (r []byte, exists bool) {
+	v := m.request
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequest returns the old "request" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldRequest(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequest is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequest requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequest: %w", err)
+	}
+	return oldValue.Request, nil
+}
+
+// ResetRequest resets all changes to the "request" field.
+func (m *CrawlQueueMutation) ResetRequest() {
+	m.request = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *CrawlQueueMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *CrawlQueueMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *CrawlQueueMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *CrawlQueueMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *CrawlQueueMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the CrawlQueue entity.
+// If the CrawlQueue object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CrawlQueueMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *CrawlQueueMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// Where appends a list predicates to the CrawlQueueMutation builder.
+func (m *CrawlQueueMutation) Where(ps ...predicate.CrawlQueue) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the CrawlQueueMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *CrawlQueueMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.CrawlQueue, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *CrawlQueueMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *CrawlQueueMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (CrawlQueue).
+func (m *CrawlQueueMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *CrawlQueueMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.spider_id != nil {
+		fields = append(fields, crawlqueue.FieldSpiderID)
+	}
+	if m.queue != nil {
+		fields = append(fields, crawlqueue.FieldQueue)
+	}
+	if m.url != nil {
+		fields = append(fields, crawlqueue.FieldURL)
+	}
+	if m.depth != nil {
+		fields = append(fields, crawlqueue.FieldDepth)
+	}
+	if m.priority != nil {
+		fields = append(fields, crawlqueue.FieldPriority)
+	}
+	if m.state != nil {
+		fields = append(fields, crawlqueue.FieldState)
+	}
+	if m.attempts != nil {
+		fields = append(fields, crawlqueue.FieldAttempts)
+	}
+	if m.request != nil {
+		fields = append(fields, crawlqueue.FieldRequest)
+	}
+	if m.created_at != nil {
+		fields = append(fields, crawlqueue.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, crawlqueue.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *CrawlQueueMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case crawlqueue.FieldSpiderID:
+		return m.SpiderID()
+	case crawlqueue.FieldQueue:
+		return m.Queue()
+	case crawlqueue.FieldURL:
+		return m.URL()
+	case crawlqueue.FieldDepth:
+		return m.Depth()
+	case crawlqueue.FieldPriority:
+		return m.Priority()
+	case crawlqueue.FieldState:
+		return m.State()
+	case crawlqueue.FieldAttempts:
+		return m.Attempts()
+	case crawlqueue.FieldRequest:
+		return m.Request()
+	case crawlqueue.FieldCreatedAt:
+		return m.CreatedAt()
+	case crawlqueue.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *CrawlQueueMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case crawlqueue.FieldSpiderID:
+		return m.OldSpiderID(ctx)
+	case crawlqueue.FieldQueue:
+		return m.OldQueue(ctx)
+	case crawlqueue.FieldURL:
+		return m.OldURL(ctx)
+	case crawlqueue.FieldDepth:
+		return m.OldDepth(ctx)
+	case crawlqueue.FieldPriority:
+		return m.OldPriority(ctx)
+	case crawlqueue.FieldState:
+		return m.OldState(ctx)
+	case crawlqueue.FieldAttempts:
+		return m.OldAttempts(ctx)
+	case crawlqueue.FieldRequest:
+		return m.OldRequest(ctx)
+	case crawlqueue.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case crawlqueue.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown CrawlQueue field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *CrawlQueueMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case crawlqueue.FieldSpiderID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSpiderID(v)
+		return nil
+	case crawlqueue.FieldQueue:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQueue(v)
+		return nil
+	case crawlqueue.FieldURL:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetURL(v)
+		return nil
+	case crawlqueue.FieldDepth:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDepth(v)
+		return nil
+	case crawlqueue.FieldPriority:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPriority(v)
+		return nil
+	case crawlqueue.FieldState:
+		v, ok := value.(uint8)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetState(v)
+		return nil
+	case crawlqueue.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttempts(v)
+		return nil
+	case crawlqueue.FieldRequest:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequest(v)
+		return nil
+	case crawlqueue.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case crawlqueue.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown CrawlQueue field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *CrawlQueueMutation) AddedFields() []string {
+	var fields []string
+	if m.adddepth != nil {
+		fields = append(fields, crawlqueue.FieldDepth)
+	}
+	if m.addpriority != nil {
+		fields = append(fields, crawlqueue.FieldPriority)
+	}
+	if m.addstate != nil {
+		fields = append(fields, crawlqueue.FieldState)
+	}
+	if m.addattempts != nil {
+		fields = append(fields, crawlqueue.FieldAttempts)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *CrawlQueueMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case crawlqueue.FieldDepth:
+		return m.AddedDepth()
+	case crawlqueue.FieldPriority:
+		return m.AddedPriority()
+	case crawlqueue.FieldState:
+		return m.AddedState()
+	case crawlqueue.FieldAttempts:
+		return m.AddedAttempts()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *CrawlQueueMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case crawlqueue.FieldDepth:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDepth(v)
+		return nil
+	case crawlqueue.FieldPriority:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPriority(v)
+		return nil
+	case crawlqueue.FieldState:
+		v, ok := value.(int8)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddState(v)
+		return nil
+	case crawlqueue.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAttempts(v)
+		return nil
+	}
+	return fmt.Errorf("unknown CrawlQueue numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *CrawlQueueMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *CrawlQueueMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *CrawlQueueMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown CrawlQueue nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *CrawlQueueMutation) ResetField(name string) error {
+	switch name {
+	case crawlqueue.FieldSpiderID:
+		m.ResetSpiderID()
+		return nil
+	case crawlqueue.FieldQueue:
+		m.ResetQueue()
+		return nil
+	case crawlqueue.FieldURL:
+		m.ResetURL()
+		return nil
+	case crawlqueue.FieldDepth:
+		m.ResetDepth()
+		return nil
+	case crawlqueue.FieldPriority:
+		m.ResetPriority()
+		return nil
+	case crawlqueue.FieldState:
+		m.ResetState()
+		return nil
+	case crawlqueue.FieldAttempts:
+		m.ResetAttempts()
+		return nil
+	case crawlqueue.FieldRequest:
+		m.ResetRequest()
+		return nil
+	case crawlqueue.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case crawlqueue.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown CrawlQueue field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *CrawlQueueMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *CrawlQueueMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *CrawlQueueMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *CrawlQueueMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *CrawlQueueMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *CrawlQueueMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *CrawlQueueMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown CrawlQueue unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *CrawlQueueMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown CrawlQueue edge %s", name)
+}
+
 // SpiderPayloadMutation represents an operation that mutates the SpiderPayload nodes in the graph.
 type SpiderPayloadMutation struct {
 	config
@@ -43,6 +998,8 @@ type SpiderPayloadMutation struct {
 	title         *string
 	job_provider  *string
 	job_id        *uuid.UUID
+	screenshot    *string
+	pdf           *string
 	clearedFields map[string]struct{}
 	done          bool
 	oldValue      func(context.Context) (*SpiderPayload, error)
@@ -513,6 +1470,104 @@ func (m *SpiderPayloadMutation) ResetJobID() {
 	delete(m.clearedFields, spiderpayload.FieldJobID)
 }
 
+// SetScreenshot sets the "screenshot" field.
+func (m *SpiderPayloadMutation) SetScreenshot(s string) {
+	m.screenshot = &s
+}
+
+// Screenshot returns the value of the "screenshot" field in the mutation.
+func (m *SpiderPayloadMutation) Screenshot() (r string, exists bool) {
+	v := m.screenshot
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldScreenshot returns the old "screenshot" field's value of the SpiderPayload entity.
+// If the SpiderPayload object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SpiderPayloadMutation) OldScreenshot(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldScreenshot is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldScreenshot requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldScreenshot: %w", err)
+	}
+	return oldValue.Screenshot, nil
+}
+
+// ClearScreenshot clears the value of the "screenshot" field.
+func (m *SpiderPayloadMutation) ClearScreenshot() {
+	m.screenshot = nil
+	m.clearedFields[spiderpayload.FieldScreenshot] = struct{}{}
+}
+
+// ScreenshotCleared returns if the "screenshot" field was cleared in this mutation.
+func (m *SpiderPayloadMutation) ScreenshotCleared() bool {
+	_, ok := m.clearedFields[spiderpayload.FieldScreenshot]
+	return ok
+}
+
+// ResetScreenshot resets all changes to the "screenshot" field.
+func (m *SpiderPayloadMutation) ResetScreenshot() {
+	m.screenshot = nil
+	delete(m.clearedFields, spiderpayload.FieldScreenshot)
+}
+
+// SetPdf sets the "pdf" field.
+func (m *SpiderPayloadMutation) SetPdf(s string) {
+	m.pdf = &s
+}
+
+// Pdf returns the value of the "pdf" field in the mutation.
+func (m *SpiderPayloadMutation) Pdf() (r string, exists bool) {
+	v := m.pdf
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPdf returns the old "pdf" field's value of the SpiderPayload entity.
+// If the SpiderPayload object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SpiderPayloadMutation) OldPdf(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPdf is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPdf requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPdf: %w", err)
+	}
+	return oldValue.Pdf, nil
+}
+
+// ClearPdf clears the value of the "pdf" field.
+func (m *SpiderPayloadMutation) ClearPdf() {
+	m.pdf = nil
+	m.clearedFields[spiderpayload.FieldPdf] = struct{}{}
+}
+
+// PdfCleared returns if the "pdf" field was cleared in this mutation.
+func (m *SpiderPayloadMutation) PdfCleared() bool {
+	_, ok := m.clearedFields[spiderpayload.FieldPdf]
+	return ok
+}
+
+// ResetPdf resets all changes to the "pdf" field.
+func (m *SpiderPayloadMutation) ResetPdf() {
+	m.pdf = nil
+	delete(m.clearedFields, spiderpayload.FieldPdf)
+}
+
 // Where appends a list predicates to the SpiderPayloadMutation builder.
 func (m *SpiderPayloadMutation) Where(ps ...predicate.SpiderPayload) {
 	m.predicates = append(m.predicates, ps...)
@@ -547,7 +1602,7 @@ func (m *SpiderPayloadMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *SpiderPayloadMutation) Fields() []string {
-	fields := make([]string, 0, 8)
+	fields := make([]string, 0, 10)
 	if m.spider_id != nil {
 		fields = append(fields, spiderpayload.FieldSpiderID)
 	}
@@ -572,6 +1627,12 @@ func (m *SpiderPayloadMutation) Fields() []string {
 	if m.job_id != nil {
 		fields = append(fields, spiderpayload.FieldJobID)
 	}
+	if m.screenshot != nil {
+		fields = append(fields, spiderpayload.FieldScreenshot)
+	}
+	if m.pdf != nil {
+		fields = append(fields, spiderpayload.FieldPdf)
+	}
 	return fields
 }
 
@@ -596,6 +1657,10 @@ func (m *SpiderPayloadMutation) Field(name string) (ent.Value, bool) {
 		return m.JobProvider()
 	case spiderpayload.FieldJobID:
 		return m.JobID()
+	case spiderpayload.FieldScreenshot:
+		return m.Screenshot()
+	case spiderpayload.FieldPdf:
+		return m.Pdf()
 	}
 	return nil, false
 }
@@ -621,6 +1686,10 @@ func (m *SpiderPayloadMutation) OldField(ctx context.Context, name string) (ent.
 		return m.OldJobProvider(ctx)
 	case spiderpayload.FieldJobID:
 		return m.OldJobID(ctx)
+	case spiderpayload.FieldScreenshot:
+		return m.OldScreenshot(ctx)
+	case spiderpayload.FieldPdf:
+		return m.OldPdf(ctx)
 	}
 	return nil, fmt.Errorf("unknown SpiderPayload field %s", name)
 }
@@ -686,6 +1755,20 @@ func (m *SpiderPayloadMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetJobID(v)
 		return nil
+	case spiderpayload.FieldScreenshot:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScreenshot(v)
+		return nil
+	case spiderpayload.FieldPdf:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPdf(v)
+		return nil
 	}
 	return fmt.Errorf("unknown SpiderPayload field %s", name)
 }
@@ -743,6 +1826,12 @@ func (m *SpiderPayloadMutation) ClearedFields() []string {
 	if m.FieldCleared(spiderpayload.FieldJobID) {
 		fields = append(fields, spiderpayload.FieldJobID)
 	}
+	if m.FieldCleared(spiderpayload.FieldScreenshot) {
+		fields = append(fields, spiderpayload.FieldScreenshot)
+	}
+	if m.FieldCleared(spiderpayload.FieldPdf) {
+		fields = append(fields, spiderpayload.FieldPdf)
+	}
 	return fields
 }
 
@@ -769,6 +1858,12 @@ func (m *SpiderPayloadMutation) ClearField(name string) error {
 	case spiderpayload.FieldJobID:
 		m.ClearJobID()
 		return nil
+	case spiderpayload.FieldScreenshot:
+		m.ClearScreenshot()
+		return nil
+	case spiderpayload.FieldPdf:
+		m.ClearPdf()
+		return nil
 	}
 	return fmt.Errorf("unknown SpiderPayload nullable field %s", name)
 }
@@ -801,6 +1896,12 @@ func (m *SpiderPayloadMutation) ResetField(name string) error {
 	case spiderpayload.FieldJobID:
 		m.ResetJobID()
 		return nil
+	case spiderpayload.FieldScreenshot:
+		m.ResetScreenshot()
+		return nil
+	case spiderpayload.FieldPdf:
+		m.ResetPdf()
+		return nil
 	}
 	return fmt.Errorf("unknown SpiderPayload field %s", name)
 }
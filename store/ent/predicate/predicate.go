@@ -6,5 +6,8 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// CrawlQueue is the predicate function for crawlqueue builders.
+type CrawlQueue func(*sql.Selector)
+
 // SpiderPayload is the predicate function for spiderpayload builders.
 type SpiderPayload func(*sql.Selector)
@@ -5,6 +5,7 @@ package ent
 import (
 	"time"
 
+	"github.com/editorpost/spider/store/ent/crawlqueue"
 	"github.com/editorpost/spider/store/ent/schema"
 	"github.com/editorpost/spider/store/ent/spiderpayload"
 	"github.com/google/uuid"
@@ -14,6 +15,38 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	crawlqueueFields := schema.CrawlQueue{}.Fields()
+	_ = crawlqueueFields
+	// crawlqueueDescDepth is the schema descriptor for depth field.
+	crawlqueueDescDepth := crawlqueueFields[4].Descriptor()
+	// crawlqueue.DefaultDepth holds the default value on creation for the depth field.
+	crawlqueue.DefaultDepth = crawlqueueDescDepth.Default.(int)
+	// crawlqueueDescPriority is the schema descriptor for priority field.
+	crawlqueueDescPriority := crawlqueueFields[5].Descriptor()
+	// crawlqueue.DefaultPriority holds the default value on creation for the priority field.
+	crawlqueue.DefaultPriority = crawlqueueDescPriority.Default.(int)
+	// crawlqueueDescState is the schema descriptor for state field.
+	crawlqueueDescState := crawlqueueFields[6].Descriptor()
+	// crawlqueue.DefaultState holds the default value on creation for the state field.
+	crawlqueue.DefaultState = crawlqueueDescState.Default.(uint8)
+	// crawlqueueDescAttempts is the schema descriptor for attempts field.
+	crawlqueueDescAttempts := crawlqueueFields[7].Descriptor()
+	// crawlqueue.DefaultAttempts holds the default value on creation for the attempts field.
+	crawlqueue.DefaultAttempts = crawlqueueDescAttempts.Default.(int)
+	// crawlqueueDescCreatedAt is the schema descriptor for created_at field.
+	crawlqueueDescCreatedAt := crawlqueueFields[9].Descriptor()
+	// crawlqueue.DefaultCreatedAt holds the default value on creation for the created_at field.
+	crawlqueue.DefaultCreatedAt = crawlqueueDescCreatedAt.Default.(func() time.Time)
+	// crawlqueueDescUpdatedAt is the schema descriptor for updated_at field.
+	crawlqueueDescUpdatedAt := crawlqueueFields[10].Descriptor()
+	// crawlqueue.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	crawlqueue.DefaultUpdatedAt = crawlqueueDescUpdatedAt.Default.(func() time.Time)
+	// crawlqueue.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	crawlqueue.UpdateDefaultUpdatedAt = crawlqueueDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// crawlqueueDescID is the schema descriptor for id field.
+	crawlqueueDescID := crawlqueueFields[0].Descriptor()
+	// crawlqueue.DefaultID holds the default value on creation for the id field.
+	crawlqueue.DefaultID = crawlqueueDescID.Default.(func() uuid.UUID)
 	spiderpayloadFields := schema.SpiderPayload{}.Fields()
 	_ = spiderpayloadFields
 	// spiderpayloadDescExtractedAt is the schema descriptor for extracted_at field.
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CrawlQueue holds the schema definition for the CrawlQueue entity.
+// It is the checkpoint of the crawler frontier to resume unfinished runs.
+type CrawlQueue struct {
+	ent.Schema
+}
+
+// Fields of the CrawlQueue.
+func (CrawlQueue) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("spider_id", uuid.UUID{}),
+		// queue name, separates check and regular runs of the spider
+		field.String("queue"),
+		field.Text("url"),
+		field.Int("depth").Default(0),
+		// frontier class of the request, see frontier.Class
+		field.Int("priority").Default(0),
+		field.Uint8("state").Default(1),
+		field.Int("attempts").Default(0),
+		// serialized colly request
+		field.Bytes("request"),
+		field.Time("created_at").Default(time.Now),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the CrawlQueue.
+func (CrawlQueue) Edges() []ent.Edge {
+	return nil
+}
+
+func (CrawlQueue) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("spider_id", "queue", "state"),
+	}
+}
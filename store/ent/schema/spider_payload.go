@@ -25,6 +25,9 @@ func (SpiderPayload) Fields() []ent.Field {
 		field.String("title"),
 		field.String("job_provider").StructTag(`json:"JobProvider"`).Optional(),
 		field.UUID("job_id", uuid.UUID{}).StructTag(`json:"JobID"`).Optional(),
+		// snapshot files of the page, see pipe.Snapshot
+		field.String("screenshot").StructTag(`json:"Screenshot"`).Optional(),
+		field.String("pdf").StructTag(`json:"Pdf"`).Optional(),
 	}
 }
 
@@ -33,7 +33,11 @@ type SpiderPayload struct {
 	// JobProvider holds the value of the "job_provider" field.
 	JobProvider string `json:"JobProvider"`
 	// JobID holds the value of the "job_id" field.
-	JobID        uuid.UUID `json:"JobID"`
+	JobID uuid.UUID `json:"JobID"`
+	// Screenshot holds the value of the "screenshot" field.
+	Screenshot string `json:"Screenshot"`
+	// Pdf holds the value of the "pdf" field.
+	Pdf          string `json:"Pdf"`
 	selectValues sql.SelectValues
 }
 
@@ -44,7 +48,7 @@ func (*SpiderPayload) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case spiderpayload.FieldStatus:
 			values[i] = new(sql.NullInt64)
-		case spiderpayload.FieldURL, spiderpayload.FieldPath, spiderpayload.FieldTitle, spiderpayload.FieldJobProvider:
+		case spiderpayload.FieldURL, spiderpayload.FieldPath, spiderpayload.FieldTitle, spiderpayload.FieldJobProvider, spiderpayload.FieldScreenshot, spiderpayload.FieldPdf:
 			values[i] = new(sql.NullString)
 		case spiderpayload.FieldExtractedAt:
 			values[i] = new(sql.NullTime)
@@ -119,6 +123,18 @@ func (sp *SpiderPayload) assignValues(columns []string, values []any) error {
 			} else if value != nil {
 				sp.JobID = *value
 			}
+		case spiderpayload.FieldScreenshot:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field screenshot", values[i])
+			} else if value.Valid {
+				sp.Screenshot = value.String
+			}
+		case spiderpayload.FieldPdf:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field pdf", values[i])
+			} else if value.Valid {
+				sp.Pdf = value.String
+			}
 		default:
 			sp.selectValues.Set(columns[i], values[i])
 		}
@@ -178,6 +194,12 @@ func (sp *SpiderPayload) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("job_id=")
 	builder.WriteString(fmt.Sprintf("%v", sp.JobID))
+	builder.WriteString(", ")
+	builder.WriteString("screenshot=")
+	builder.WriteString(sp.Screenshot)
+	builder.WriteString(", ")
+	builder.WriteString("pdf=")
+	builder.WriteString(sp.Pdf)
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -30,6 +30,10 @@ const (
 	FieldJobProvider = "job_provider"
 	// FieldJobID holds the string denoting the job_id field in the database.
 	FieldJobID = "job_id"
+	// FieldScreenshot holds the string denoting the screenshot field in the database.
+	FieldScreenshot = "screenshot"
+	// FieldPdf holds the string denoting the pdf field in the database.
+	FieldPdf = "pdf"
 	// Table holds the table name of the spiderpayload in the database.
 	Table = "spider_payloads"
 )
@@ -45,6 +49,8 @@ var Columns = []string{
 	FieldTitle,
 	FieldJobProvider,
 	FieldJobID,
+	FieldScreenshot,
+	FieldPdf,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -113,3 +119,13 @@ func ByJobProvider(opts ...sql.OrderTermOption) OrderOption {
 func ByJobID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldJobID, opts...).ToFunc()
 }
+
+// ByScreenshot orders the results by the screenshot field.
+func ByScreenshot(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScreenshot, opts...).ToFunc()
+}
+
+// ByPdf orders the results by the pdf field.
+func ByPdf(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPdf, opts...).ToFunc()
+}
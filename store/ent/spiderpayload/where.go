@@ -95,6 +95,16 @@ func JobID(v uuid.UUID) predicate.SpiderPayload {
 	return predicate.SpiderPayload(sql.FieldEQ(FieldJobID, v))
 }
 
+// Screenshot applies equality check predicate on the "screenshot" field. It's identical to ScreenshotEQ.
+func Screenshot(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldEQ(FieldScreenshot, v))
+}
+
+// Pdf applies equality check predicate on the "pdf" field. It's identical to PdfEQ.
+func Pdf(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldEQ(FieldPdf, v))
+}
+
 // SpiderIDEQ applies the EQ predicate on the "spider_id" field.
 func SpiderIDEQ(v uuid.UUID) predicate.SpiderPayload {
 	return predicate.SpiderPayload(sql.FieldEQ(FieldSpiderID, v))
@@ -555,6 +565,156 @@ func JobIDNotNil() predicate.SpiderPayload {
 	return predicate.SpiderPayload(sql.FieldNotNull(FieldJobID))
 }
 
+// ScreenshotEQ applies the EQ predicate on the "screenshot" field.
+func ScreenshotEQ(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldEQ(FieldScreenshot, v))
+}
+
+// ScreenshotNEQ applies the NEQ predicate on the "screenshot" field.
+func ScreenshotNEQ(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldNEQ(FieldScreenshot, v))
+}
+
+// ScreenshotIn applies the In predicate on the "screenshot" field.
+func ScreenshotIn(vs ...string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldIn(FieldScreenshot, vs...))
+}
+
+// ScreenshotNotIn applies the NotIn predicate on the "screenshot" field.
+func ScreenshotNotIn(vs ...string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldNotIn(FieldScreenshot, vs...))
+}
+
+// ScreenshotGT applies the GT predicate on the "screenshot" field.
+func ScreenshotGT(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldGT(FieldScreenshot, v))
+}
+
+// ScreenshotGTE applies the GTE predicate on the "screenshot" field.
+func ScreenshotGTE(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldGTE(FieldScreenshot, v))
+}
+
+// ScreenshotLT applies the LT predicate on the "screenshot" field.
+func ScreenshotLT(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldLT(FieldScreenshot, v))
+}
+
+// ScreenshotLTE applies the LTE predicate on the "screenshot" field.
+func ScreenshotLTE(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldLTE(FieldScreenshot, v))
+}
+
+// ScreenshotContains applies the Contains predicate on the "screenshot" field.
+func ScreenshotContains(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldContains(FieldScreenshot, v))
+}
+
+// ScreenshotHasPrefix applies the HasPrefix predicate on the "screenshot" field.
+func ScreenshotHasPrefix(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldHasPrefix(FieldScreenshot, v))
+}
+
+// ScreenshotHasSuffix applies the HasSuffix predicate on the "screenshot" field.
+func ScreenshotHasSuffix(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldHasSuffix(FieldScreenshot, v))
+}
+
+// ScreenshotIsNil applies the IsNil predicate on the "screenshot" field.
+func ScreenshotIsNil() predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldIsNull(FieldScreenshot))
+}
+
+// ScreenshotNotNil applies the NotNil predicate on the "screenshot" field.
+func ScreenshotNotNil() predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldNotNull(FieldScreenshot))
+}
+
+// ScreenshotEqualFold applies the EqualFold predicate on the "screenshot" field.
+func ScreenshotEqualFold(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldEqualFold(FieldScreenshot, v))
+}
+
+// ScreenshotContainsFold applies the ContainsFold predicate on the "screenshot" field.
+func ScreenshotContainsFold(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldContainsFold(FieldScreenshot, v))
+}
+
+// PdfEQ applies the EQ predicate on the "pdf" field.
+func PdfEQ(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldEQ(FieldPdf, v))
+}
+
+// PdfNEQ applies the NEQ predicate on the "pdf" field.
+func PdfNEQ(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldNEQ(FieldPdf, v))
+}
+
+// PdfIn applies the In predicate on the "pdf" field.
+func PdfIn(vs ...string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldIn(FieldPdf, vs...))
+}
+
+// PdfNotIn applies the NotIn predicate on the "pdf" field.
+func PdfNotIn(vs ...string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldNotIn(FieldPdf, vs...))
+}
+
+// PdfGT applies the GT predicate on the "pdf" field.
+func PdfGT(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldGT(FieldPdf, v))
+}
+
+// PdfGTE applies the GTE predicate on the "pdf" field.
+func PdfGTE(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldGTE(FieldPdf, v))
+}
+
+// PdfLT applies the LT predicate on the "pdf" field.
+func PdfLT(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldLT(FieldPdf, v))
+}
+
+// PdfLTE applies the LTE predicate on the "pdf" field.
+func PdfLTE(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldLTE(FieldPdf, v))
+}
+
+// PdfContains applies the Contains predicate on the "pdf" field.
+func PdfContains(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldContains(FieldPdf, v))
+}
+
+// PdfHasPrefix applies the HasPrefix predicate on the "pdf" field.
+func PdfHasPrefix(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldHasPrefix(FieldPdf, v))
+}
+
+// PdfHasSuffix applies the HasSuffix predicate on the "pdf" field.
+func PdfHasSuffix(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldHasSuffix(FieldPdf, v))
+}
+
+// PdfIsNil applies the IsNil predicate on the "pdf" field.
+func PdfIsNil() predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldIsNull(FieldPdf))
+}
+
+// PdfNotNil applies the NotNil predicate on the "pdf" field.
+func PdfNotNil() predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldNotNull(FieldPdf))
+}
+
+// PdfEqualFold applies the EqualFold predicate on the "pdf" field.
+func PdfEqualFold(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldEqualFold(FieldPdf, v))
+}
+
+// PdfContainsFold applies the ContainsFold predicate on the "pdf" field.
+func PdfContainsFold(v string) predicate.SpiderPayload {
+	return predicate.SpiderPayload(sql.FieldContainsFold(FieldPdf, v))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.SpiderPayload) predicate.SpiderPayload {
 	return predicate.SpiderPayload(sql.AndPredicates(predicates...))
@@ -117,6 +117,34 @@ func (spc *SpiderPayloadCreate) SetNillableJobID(u *uuid.UUID) *SpiderPayloadCre
 	return spc
 }
 
+// SetScreenshot sets the "screenshot" field.
+func (spc *SpiderPayloadCreate) SetScreenshot(s string) *SpiderPayloadCreate {
+	spc.mutation.SetScreenshot(s)
+	return spc
+}
+
+// SetNillableScreenshot sets the "screenshot" field if the given value is not nil.
+func (spc *SpiderPayloadCreate) SetNillableScreenshot(s *string) *SpiderPayloadCreate {
+	if s != nil {
+		spc.SetScreenshot(*s)
+	}
+	return spc
+}
+
+// SetPdf sets the "pdf" field.
+func (spc *SpiderPayloadCreate) SetPdf(s string) *SpiderPayloadCreate {
+	spc.mutation.SetPdf(s)
+	return spc
+}
+
+// SetNillablePdf sets the "pdf" field if the given value is not nil.
+func (spc *SpiderPayloadCreate) SetNillablePdf(s *string) *SpiderPayloadCreate {
+	if s != nil {
+		spc.SetPdf(*s)
+	}
+	return spc
+}
+
 // SetID sets the "id" field.
 func (spc *SpiderPayloadCreate) SetID(u uuid.UUID) *SpiderPayloadCreate {
 	spc.mutation.SetID(u)
@@ -261,6 +289,14 @@ func (spc *SpiderPayloadCreate) createSpec() (*SpiderPayload, *sqlgraph.CreateSp
 		_spec.SetField(spiderpayload.FieldJobID, field.TypeUUID, value)
 		_node.JobID = value
 	}
+	if value, ok := spc.mutation.Screenshot(); ok {
+		_spec.SetField(spiderpayload.FieldScreenshot, field.TypeString, value)
+		_node.Screenshot = value
+	}
+	if value, ok := spc.mutation.Pdf(); ok {
+		_spec.SetField(spiderpayload.FieldPdf, field.TypeString, value)
+		_node.Pdf = value
+	}
 	return _node, _spec
 }
 
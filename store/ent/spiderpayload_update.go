@@ -172,6 +172,46 @@ func (spu *SpiderPayloadUpdate) ClearJobID() *SpiderPayloadUpdate {
 	return spu
 }
 
+// SetScreenshot sets the "screenshot" field.
+func (spu *SpiderPayloadUpdate) SetScreenshot(s string) *SpiderPayloadUpdate {
+	spu.mutation.SetScreenshot(s)
+	return spu
+}
+
+// SetNillableScreenshot sets the "screenshot" field if the given value is not nil.
+func (spu *SpiderPayloadUpdate) SetNillableScreenshot(s *string) *SpiderPayloadUpdate {
+	if s != nil {
+		spu.SetScreenshot(*s)
+	}
+	return spu
+}
+
+// ClearScreenshot clears the value of the "screenshot" field.
+func (spu *SpiderPayloadUpdate) ClearScreenshot() *SpiderPayloadUpdate {
+	spu.mutation.ClearScreenshot()
+	return spu
+}
+
+// SetPdf sets the "pdf" field.
+func (spu *SpiderPayloadUpdate) SetPdf(s string) *SpiderPayloadUpdate {
+	spu.mutation.SetPdf(s)
+	return spu
+}
+
+// SetNillablePdf sets the "pdf" field if the given value is not nil.
+func (spu *SpiderPayloadUpdate) SetNillablePdf(s *string) *SpiderPayloadUpdate {
+	if s != nil {
+		spu.SetPdf(*s)
+	}
+	return spu
+}
+
+// ClearPdf clears the value of the "pdf" field.
+func (spu *SpiderPayloadUpdate) ClearPdf() *SpiderPayloadUpdate {
+	spu.mutation.ClearPdf()
+	return spu
+}
+
 // Mutation returns the SpiderPayloadMutation object of the builder.
 func (spu *SpiderPayloadUpdate) Mutation() *SpiderPayloadMutation {
 	return spu.mutation
@@ -252,6 +292,18 @@ func (spu *SpiderPayloadUpdate) sqlSave(ctx context.Context) (n int, err error)
 	if spu.mutation.JobIDCleared() {
 		_spec.ClearField(spiderpayload.FieldJobID, field.TypeUUID)
 	}
+	if value, ok := spu.mutation.Screenshot(); ok {
+		_spec.SetField(spiderpayload.FieldScreenshot, field.TypeString, value)
+	}
+	if spu.mutation.ScreenshotCleared() {
+		_spec.ClearField(spiderpayload.FieldScreenshot, field.TypeString)
+	}
+	if value, ok := spu.mutation.Pdf(); ok {
+		_spec.SetField(spiderpayload.FieldPdf, field.TypeString, value)
+	}
+	if spu.mutation.PdfCleared() {
+		_spec.ClearField(spiderpayload.FieldPdf, field.TypeString)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, spu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{spiderpayload.Label}
@@ -415,6 +467,46 @@ func (spuo *SpiderPayloadUpdateOne) ClearJobID() *SpiderPayloadUpdateOne {
 	return spuo
 }
 
+// SetScreenshot sets the "screenshot" field.
+func (spuo *SpiderPayloadUpdateOne) SetScreenshot(s string) *SpiderPayloadUpdateOne {
+	spuo.mutation.SetScreenshot(s)
+	return spuo
+}
+
+// SetNillableScreenshot sets the "screenshot" field if the given value is not nil.
+func (spuo *SpiderPayloadUpdateOne) SetNillableScreenshot(s *string) *SpiderPayloadUpdateOne {
+	if s != nil {
+		spuo.SetScreenshot(*s)
+	}
+	return spuo
+}
+
+// ClearScreenshot clears the value of the "screenshot" field.
+func (spuo *SpiderPayloadUpdateOne) ClearScreenshot() *SpiderPayloadUpdateOne {
+	spuo.mutation.ClearScreenshot()
+	return spuo
+}
+
+// SetPdf sets the "pdf" field.
+func (spuo *SpiderPayloadUpdateOne) SetPdf(s string) *SpiderPayloadUpdateOne {
+	spuo.mutation.SetPdf(s)
+	return spuo
+}
+
+// SetNillablePdf sets the "pdf" field if the given value is not nil.
+func (spuo *SpiderPayloadUpdateOne) SetNillablePdf(s *string) *SpiderPayloadUpdateOne {
+	if s != nil {
+		spuo.SetPdf(*s)
+	}
+	return spuo
+}
+
+// ClearPdf clears the value of the "pdf" field.
+func (spuo *SpiderPayloadUpdateOne) ClearPdf() *SpiderPayloadUpdateOne {
+	spuo.mutation.ClearPdf()
+	return spuo
+}
+
 // Mutation returns the SpiderPayloadMutation object of the builder.
 func (spuo *SpiderPayloadUpdateOne) Mutation() *SpiderPayloadMutation {
 	return spuo.mutation
@@ -525,6 +617,18 @@ func (spuo *SpiderPayloadUpdateOne) sqlSave(ctx context.Context) (_node *SpiderP
 	if spuo.mutation.JobIDCleared() {
 		_spec.ClearField(spiderpayload.FieldJobID, field.TypeUUID)
 	}
+	if value, ok := spuo.mutation.Screenshot(); ok {
+		_spec.SetField(spiderpayload.FieldScreenshot, field.TypeString, value)
+	}
+	if spuo.mutation.ScreenshotCleared() {
+		_spec.ClearField(spiderpayload.FieldScreenshot, field.TypeString)
+	}
+	if value, ok := spuo.mutation.Pdf(); ok {
+		_spec.SetField(spiderpayload.FieldPdf, field.TypeString, value)
+	}
+	if spuo.mutation.PdfCleared() {
+		_spec.ClearField(spiderpayload.FieldPdf, field.TypeString)
+	}
 	_node = &SpiderPayload{config: spuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
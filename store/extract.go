@@ -6,6 +6,7 @@ import (
 	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/editorpost/donq/res"
 	"github.com/editorpost/spider/extract/pipe"
+	"strings"
 )
 
 type (
@@ -17,6 +18,7 @@ type (
 
 	ExtractStorage struct {
 		b         res.S3
+		folder    string
 		store     Storage
 		extracted *bloom.BloomFilter
 	}
@@ -33,6 +35,7 @@ func NewExtractStorage(folder string, b res.S3) (*ExtractStorage, error) {
 
 	return &ExtractStorage{
 		b:         b,
+		folder:    folder,
 		store:     store,
 		extracted: bloom.NewWithEstimates(100000, 0.01),
 	}, nil
@@ -69,6 +72,11 @@ func (s *ExtractStorage) Save(p *pipe.Payload) (err error) {
 
 func (s *ExtractStorage) save(p *pipe.Payload) error {
 
+	// snapshot paths are recorded in the payload
+	if err := s.saveSnapshot(p); err != nil {
+		return err
+	}
+
 	b, err := json.Marshal(p.Data)
 	if err != nil {
 		return err
@@ -89,6 +97,49 @@ func (s *ExtractStorage) save(p *pipe.Payload) error {
 	return s.store.Save([]byte(dom), fmt.Sprintf("%s/%s", p.ID, HTMLSourceFile))
 }
 
+// saveSnapshot files of the page captured by the browser next to the payload
+func (s *ExtractStorage) saveSnapshot(p *pipe.Payload) error {
+
+	if p.Snapshot == nil {
+		return nil
+	}
+
+	files := []struct {
+		data  []byte
+		name  string
+		field string
+	}{
+		{p.Snapshot.PNG, ScreenshotFile, pipe.ScreenshotField},
+		{p.Snapshot.PDF, PDFFile, pipe.PDFField},
+	}
+
+	for _, file := range files {
+
+		if len(file.data) == 0 {
+			continue
+		}
+
+		filename := fmt.Sprintf("%s/%s", p.ID, file.name)
+		if err := s.store.Save(file.data, filename); err != nil {
+			return err
+		}
+
+		p.Data[file.field] = SnapshotPath(s.folder, p.ID, file.name)
+	}
+
+	return nil
+}
+
+// SnapshotPath of the payload snapshot file in the storage
+func SnapshotPath(folder, payloadID, name string) string {
+
+	if len(folder) == 0 {
+		return fmt.Sprintf("%s/%s", payloadID, name)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", strings.TrimRight(folder, "/"), payloadID, name)
+}
+
 func (s *ExtractStorage) Reset() error {
 	return s.store.Reset()
 }
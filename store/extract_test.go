@@ -0,0 +1,37 @@
+package store_test
+
+import (
+	"encoding/json"
+	"github.com/editorpost/donq/res"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/editorpost/spider/store"
+	"github.com/editorpost/spider/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExtractStorage_Snapshot(t *testing.T) {
+
+	bucket := res.S3{Bucket: store.LocalBucket, EndPoint: t.TempDir()}
+	payload := tester.TestPayload(t, "../tester/fixtures/news/article-1.html")
+	payload.Snapshot = &pipe.Snapshot{PNG: []byte("png")}
+
+	s, err := store.NewExtractStorage("payload", bucket)
+	require.NoError(t, err)
+	require.NoError(t, s.Save(payload))
+
+	png, err := s.Load(payload.ID + "/" + store.ScreenshotFile)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), png)
+
+	// path is recorded in the payload
+	b, err := s.Load(payload.ID + "/" + store.PayloadFile)
+	require.NoError(t, err)
+
+	data := map[string]any{}
+	require.NoError(t, json.Unmarshal(b, &data))
+	assert.Equal(t, "payload/"+payload.ID+"/"+store.ScreenshotFile, data[pipe.ScreenshotField])
+	// pdf is not captured
+	assert.NotContains(t, data, pipe.PDFField)
+}
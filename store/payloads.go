@@ -22,6 +22,7 @@ type (
 	}
 
 	PayloadPaths interface {
+		PayloadRoot(spiderID string) string
 		PayloadFile(spiderID, payloadID string) string
 	}
 )
@@ -99,6 +100,17 @@ func (e *SpiderPayloads) Save(p *pipe.Payload) error {
 		q.SetJobProvider(p.JobProvider)
 	}
 
+	// snapshot files saved next to the payload
+	if p.Snapshot != nil {
+		folder := e.paths.PayloadRoot(e.spiderID)
+		if len(p.Snapshot.PNG) > 0 {
+			q.SetScreenshot(SnapshotPath(folder, p.ID, ScreenshotFile))
+		}
+		if len(p.Snapshot.PDF) > 0 {
+			q.SetPdf(SnapshotPath(folder, p.ID, PDFFile))
+		}
+	}
+
 	_, err := q.Save(context.Background())
 
 	return err
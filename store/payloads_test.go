@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"context"
+	"github.com/editorpost/spider/extract/pipe"
 	"github.com/editorpost/spider/store"
 	"github.com/editorpost/spider/store/ent"
 	"github.com/editorpost/spider/tester"
@@ -49,3 +50,24 @@ func TestSpiderPayloads_Save(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, payload.ID, row.ID.String())
 }
+
+func TestSpiderPayloads_SaveSnapshot(t *testing.T) {
+
+	spiderID := uuid.New().String()
+	payload := tester.TestPayload(t, "../tester/fixtures/news/article-1.html")
+	payload.Snapshot = &pipe.Snapshot{PNG: []byte("png"), PDF: []byte("pdf")}
+	deploy := tester.TestDeploy(t)
+
+	idx, err := store.NewSpiderPayloads(spiderID, "sqlite3://file:ent?mode=memory&cache=shared&_fk=1", deploy.Paths)
+	require.NoError(t, err)
+	defer func(idx *store.SpiderPayloads) {
+		_ = idx.Close()
+	}(idx)
+
+	require.NoError(t, idx.Save(payload))
+
+	row, err := idx.ByID(payload.ID)
+	require.NoError(t, err)
+	require.Equal(t, deploy.Paths.PayloadFile(spiderID, payload.ID)+"/"+store.ScreenshotFile, row.Screenshot)
+	require.Equal(t, deploy.Paths.PayloadFile(spiderID, payload.ID)+"/"+store.PDFFile, row.Pdf)
+}
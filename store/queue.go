@@ -0,0 +1,527 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/editorpost/spider/store/ent"
+	"github.com/editorpost/spider/store/ent/crawlqueue"
+	"github.com/gocolly/colly/v2"
+	"github.com/google/uuid"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	QueueStatePending = 1
+	QueueStateActive  = 2
+
+	// QueueItemCtx is the request context key of the queue item ID
+	QueueItemCtx = "QueueItemID"
+	// QueueMaxAttempts skips items failed (crashed the run) too many times on resume
+	QueueMaxAttempts = 3
+	// QueueCheckpointInterval is the default interval of the frontier checkpoint
+	QueueCheckpointInterval = 30 * time.Second
+	// queueBulkSize limits the number of rows per insert, sqlite has variables limit
+	queueBulkSize = 100
+)
+
+type (
+	// QueueStorage is the colly queue.Storage backed by the ent client (SQLite/Postgres).
+	// The frontier is kept in memory ordered by the frontier.Policy
+	// and periodically checkpointed to the database,
+	// so unfinished run of the spider might be resumed after the crash or kill.
+	QueueStorage struct {
+		db       *ent.Client
+		spiderID uuid.UUID
+		queue    string
+		mute     *sync.Mutex
+		pending  *frontier.Heap
+		items    map[uuid.UUID]*queueItem
+		resumed  bool
+		stop     chan struct{}
+		stopped  sync.Once
+	}
+
+	queueItem struct {
+		id        uuid.UUID
+		url       string
+		depth     int
+		class     frontier.Class
+		attempts  int
+		state     uint8
+		request   []byte
+		persisted bool
+		activated bool
+		reverted  bool
+		done      bool
+	}
+
+	// serializedRequest is the subset of colly serialized request fields,
+	// the rest fields are kept as is
+	serializedRequest struct {
+		URL   string
+		Depth int
+		Ctx   map[string]any
+	}
+)
+
+// NewQueueStorage creates the queue storage of the spider.
+// The queue name separates different kinds of runs, e.g. check and regular runs.
+func NewQueueStorage(spiderID, queue, dsn string, policy frontier.Policy) (*QueueStorage, error) {
+
+	id, err := uuid.Parse(spiderID)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := NewEntClient(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// migrate
+	if err = db.Schema.Create(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return &QueueStorage{
+		db:       db,
+		spiderID: id,
+		queue:    queue,
+		mute:     &sync.Mutex{},
+		pending:  frontier.NewHeap(policy),
+		items:    make(map[uuid.UUID]*queueItem),
+		stop:     make(chan struct{}),
+	}, nil
+}
+
+// DropQueueStorage drops all queues of the spider
+func DropQueueStorage(spiderID, dsn string) error {
+
+	id, err := uuid.Parse(spiderID)
+	if err != nil {
+		return err
+	}
+
+	db, err := NewEntClient(dsn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	_, err = db.CrawlQueue.Delete().Where(crawlqueue.SpiderID(id)).Exec(context.Background())
+	return err
+}
+
+// Init implements queue.Storage
+func (s *QueueStorage) Init() error {
+	return nil
+}
+
+// Resume loads unfinished frontier of the previous run.
+// Returns the number of resumed requests, zero if there is no unfinished run.
+func (s *QueueStorage) Resume() (int, error) {
+
+	rows, err := s.db.CrawlQueue.Query().
+		Where(
+			crawlqueue.SpiderID(s.spiderID),
+			crawlqueue.Queue(s.queue),
+			crawlqueue.StateIn(QueueStatePending, QueueStateActive),
+		).
+		Order(ent.Asc(crawlqueue.FieldCreatedAt), ent.Asc(crawlqueue.FieldID)).
+		All(context.Background())
+
+	if err != nil {
+		return 0, ent.MaskNotFound(err)
+	}
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	for _, row := range rows {
+
+		// request crashed or hung the spider too many times
+		if row.Attempts >= QueueMaxAttempts {
+			slog.Warn("queue: skip resumed request", slog.String("url", row.URL), slog.Int("attempts", row.Attempts))
+			continue
+		}
+
+		item := &queueItem{
+			id:        row.ID,
+			url:       row.URL,
+			depth:     row.Depth,
+			class:     frontier.Class(row.Priority),
+			attempts:  row.Attempts,
+			state:     QueueStatePending,
+			request:   row.Request,
+			persisted: true,
+		}
+
+		s.push(item)
+	}
+
+	s.resumed = s.pending.Len() > 0
+
+	slog.Info("queue: resumed", slog.String("queue", s.queue), slog.Int("requests", s.pending.Len()))
+
+	return s.pending.Len(), nil
+}
+
+// Resumed returns true if unfinished frontier of the previous run is loaded
+func (s *QueueStorage) Resumed() bool {
+	s.mute.Lock()
+	defer s.mute.Unlock()
+	return s.resumed
+}
+
+// Reset drops the frontier of the previous runs
+func (s *QueueStorage) Reset() error {
+
+	_, err := s.db.CrawlQueue.Delete().
+		Where(crawlqueue.SpiderID(s.spiderID), crawlqueue.Queue(s.queue)).
+		Exec(context.Background())
+
+	return err
+}
+
+// AddRequest implements queue.Storage
+func (s *QueueStorage) AddRequest(r []byte) error {
+
+	item, err := newQueueItem(r)
+	if err != nil {
+		return err
+	}
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	s.push(item)
+
+	return nil
+}
+
+// GetRequest implements queue.Storage
+func (s *QueueStorage) GetRequest() ([]byte, error) {
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	entry := s.pending.Pop()
+	if entry == nil {
+		return nil, nil
+	}
+
+	item := s.items[uuid.MustParse(entry.Key)]
+
+	item.state = QueueStateActive
+	item.attempts++
+
+	// taken again after the requeue, the row is still active in the database
+	if item.reverted {
+		item.reverted = false
+	} else {
+		item.activated = true
+	}
+
+	return item.request, nil
+}
+
+// QueueSize implements queue.Storage
+func (s *QueueStorage) QueueSize() (int, error) {
+	s.mute.Lock()
+	defer s.mute.Unlock()
+	return s.pending.Len(), nil
+}
+
+// Done marks the request processed, it is removed from the frontier on next checkpoint
+func (s *QueueStorage) Done(req *colly.Request) {
+
+	if req == nil || req.Ctx == nil {
+		return
+	}
+
+	id, err := uuid.Parse(req.Ctx.Get(QueueItemCtx))
+	if err != nil {
+		return
+	}
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	if item, ok := s.items[id]; ok {
+		item.done = true
+	}
+}
+
+// Requeue puts the taken request back to the frontier as pending, e.g. not processed when the queue stopped
+func (s *QueueStorage) Requeue(req *colly.Request) {
+
+	if req == nil || req.Ctx == nil {
+		return
+	}
+
+	id, err := uuid.Parse(req.Ctx.Get(QueueItemCtx))
+	if err != nil {
+		return
+	}
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	item, ok := s.items[id]
+	if !ok || item.done || item.state != QueueStateActive {
+		return
+	}
+
+	item.state = QueueStatePending
+	item.attempts--
+
+	// the activation is not written yet, otherwise the row is reverted on next checkpoint
+	if item.activated {
+		item.activated = false
+	} else if item.persisted {
+		item.reverted = true
+	}
+
+	s.push(item)
+}
+
+// Start periodic checkpoint of the frontier
+func (s *QueueStorage) Start(interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if err := s.Checkpoint(); err != nil {
+					slog.Error("queue: checkpoint", slog.String("err", err.Error()))
+				}
+			}
+		}
+	}()
+}
+
+// Checkpoint writes changes of the frontier since the previous checkpoint:
+// inserts new requests, marks taken requests active, reverts requeued requests to pending
+// and deletes done requests.
+func (s *QueueStorage) Checkpoint() error {
+
+	inserts, activated, reverted, done := s.changes()
+
+	ctx := context.Background()
+
+	tx, err := s.db.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(inserts); start += queueBulkSize {
+
+		chunk := inserts[start:min(start+queueBulkSize, len(inserts))]
+		builders := make([]*ent.CrawlQueueCreate, 0, len(chunk))
+
+		for _, item := range chunk {
+			builders = append(builders, tx.CrawlQueue.Create().
+				SetID(item.id).
+				SetSpiderID(s.spiderID).
+				SetQueue(s.queue).
+				SetURL(item.url).
+				SetDepth(item.depth).
+				SetPriority(int(item.class)).
+				SetState(item.state).
+				SetAttempts(item.attempts).
+				SetRequest(item.request),
+			)
+		}
+
+		if err = tx.CrawlQueue.CreateBulk(builders...).Exec(ctx); err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	if len(activated) > 0 {
+		err = tx.CrawlQueue.Update().
+			Where(crawlqueue.IDIn(activated...)).
+			SetState(QueueStateActive).
+			AddAttempts(1).
+			Exec(ctx)
+		if err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	if len(reverted) > 0 {
+		err = tx.CrawlQueue.Update().
+			Where(crawlqueue.IDIn(reverted...)).
+			SetState(QueueStatePending).
+			AddAttempts(-1).
+			Exec(ctx)
+		if err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	if len(done) > 0 {
+		if _, err = tx.CrawlQueue.Delete().Where(crawlqueue.IDIn(done...)).Exec(ctx); err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	s.persisted(inserts, done)
+
+	return nil
+}
+
+// Close stops the checkpoint, writes the final one and closes the database.
+// The frontier of the completed run is dropped.
+func (s *QueueStorage) Close() error {
+
+	s.stopped.Do(func() {
+		close(s.stop)
+	})
+
+	err := s.Checkpoint()
+
+	if size, _ := s.QueueSize(); size == 0 && err == nil {
+		err = s.dropActive()
+	}
+
+	return errors.Join(err, s.db.Close())
+}
+
+// dropActive deletes requests never marked done, e.g. aborted or filtered by collector
+func (s *QueueStorage) dropActive() error {
+
+	_, err := s.db.CrawlQueue.Delete().
+		Where(
+			crawlqueue.SpiderID(s.spiderID),
+			crawlqueue.Queue(s.queue),
+			crawlqueue.State(QueueStateActive),
+		).
+		Exec(context.Background())
+
+	return err
+}
+
+// push the item to the frontier, must be called under the lock
+func (s *QueueStorage) push(item *queueItem) {
+
+	s.items[item.id] = item
+	s.pending.Push(&frontier.Entry{
+		Request: item.request,
+		Class:   item.class,
+		Depth:   item.depth,
+		Key:     item.id.String(),
+	})
+}
+
+func (s *QueueStorage) changes() (inserts []*queueItem, activated, reverted, done []uuid.UUID) {
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	for id, item := range s.items {
+
+		switch {
+		case !item.persisted && item.done:
+			// added and processed between checkpoints
+			delete(s.items, id)
+		case !item.persisted:
+			// copy, the item is changed concurrently while writing
+			row := *item
+			inserts = append(inserts, &row)
+			item.activated = false
+		case item.done:
+			done = append(done, id)
+		case item.activated:
+			activated = append(activated, id)
+			item.activated = false
+		case item.reverted:
+			reverted = append(reverted, id)
+			item.reverted = false
+		}
+	}
+
+	return inserts, activated, reverted, done
+}
+
+func (s *QueueStorage) persisted(inserts []*queueItem, done []uuid.UUID) {
+
+	s.mute.Lock()
+	defer s.mute.Unlock()
+
+	for _, row := range inserts {
+		if item, ok := s.items[row.id]; ok {
+			item.persisted = true
+		}
+	}
+
+	for _, id := range done {
+		delete(s.items, id)
+	}
+}
+
+// newQueueItem from colly serialized request,
+// the item ID is injected to the request context to mark it done later.
+func newQueueItem(r []byte) (*queueItem, error) {
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err = json.Unmarshal(r, &fields); err != nil {
+		return nil, fmt.Errorf("queue request: %w", err)
+	}
+
+	req := &serializedRequest{}
+	if err = json.Unmarshal(r, req); err != nil {
+		return nil, fmt.Errorf("queue request: %w", err)
+	}
+
+	if req.Ctx == nil {
+		req.Ctx = map[string]any{}
+	}
+	req.Ctx[QueueItemCtx] = id.String()
+
+	if fields["Ctx"], err = json.Marshal(req.Ctx); err != nil {
+		return nil, err
+	}
+
+	request, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &queueItem{
+		id:      id,
+		url:     req.URL,
+		depth:   req.Depth,
+		class:   frontier.ParseClass(req.Ctx[frontier.ClassCtx]),
+		state:   QueueStatePending,
+		request: request,
+	}, nil
+}
+
+func rollback(tx *ent.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	return err
+}
@@ -0,0 +1,229 @@
+package store_test
+
+import (
+	"github.com/editorpost/spider/collect"
+	"github.com/editorpost/spider/collect/frontier"
+	"github.com/editorpost/spider/store"
+	"github.com/gocolly/colly/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const queueDSN = "sqlite3://file:ent?mode=memory&cache=shared&_fk=1"
+
+func TestQueueStorage_Resume(t *testing.T) {
+
+	spiderID := uuid.New().String()
+	c := colly.NewCollector()
+
+	crashed, err := store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+
+	for _, uri := range []string{"https://example.com/", "https://example.com/a", "https://example.com/b"} {
+		require.NoError(t, crashed.AddRequest(QueueRequest(t, uri)))
+	}
+
+	// first is processed, second is in progress
+	first := TakeRequest(t, c, crashed)
+	crashed.Done(first)
+	TakeRequest(t, c, crashed)
+
+	// the run is killed after the checkpoint
+	require.NoError(t, crashed.Checkpoint())
+
+	// other queue of the same spider is not affected
+	other, err := store.NewQueueStorage(spiderID, "chk/spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	resumed, err := other.Resume()
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+	assert.False(t, other.Resumed())
+
+	s, err := store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+
+	resumed, err = s.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, 2, resumed)
+	assert.True(t, s.Resumed())
+
+	// drain the resumed frontier
+	urls := make([]string, 0)
+	for size, _ := s.QueueSize(); size > 0; size, _ = s.QueueSize() {
+		req := TakeRequest(t, c, s)
+		urls = append(urls, req.URL.String())
+		assert.Equal(t, 1, req.Depth)
+		s.Done(req)
+	}
+	assert.ElementsMatch(t, []string{"https://example.com/a", "https://example.com/b"}, urls)
+
+	// completed run drops the frontier
+	require.NoError(t, s.Close())
+
+	s, err = store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	defer func() {
+		_ = s.Close()
+	}()
+
+	resumed, err = s.Resume()
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+}
+
+func TestQueueStorage_MaxAttempts(t *testing.T) {
+
+	spiderID := uuid.New().String()
+	c := colly.NewCollector()
+
+	s, err := store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	require.NoError(t, s.AddRequest(QueueRequest(t, "https://example.com/hang")))
+	require.NoError(t, s.Checkpoint())
+
+	// the request hangs the spider on every run
+	for i := 0; i < store.QueueMaxAttempts; i++ {
+		s, err = store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+		require.NoError(t, err)
+		resumed, err := s.Resume()
+		require.NoError(t, err)
+		require.Equal(t, 1, resumed)
+		TakeRequest(t, c, s)
+		require.NoError(t, s.Checkpoint())
+	}
+
+	s, err = store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	resumed, err := s.Resume()
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+
+	require.NoError(t, s.Reset())
+}
+
+func TestQueueStorage_Aborted(t *testing.T) {
+
+	spiderID := uuid.New().String()
+
+	c := colly.NewCollector(colly.AllowedDomains("example.com"))
+	// the request is aborted before it is sent
+	c.OnRequest(func(r *colly.Request) {
+		r.Abort()
+	})
+
+	s, err := store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+
+	// aborted by the handler and filtered by the domain
+	require.NoError(t, s.AddRequest(QueueRequest(t, "https://example.com/aborted")))
+	require.NoError(t, s.AddRequest(QueueRequest(t, "https://other.com/filtered")))
+
+	q, err := collect.NewQueue(1, s)
+	require.NoError(t, err)
+	require.NoError(t, q.Run(c))
+
+	// the run is killed after the checkpoint, nothing is resumed
+	require.NoError(t, s.Checkpoint())
+
+	s, err = store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	resumed, err := s.Resume()
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+
+	require.NoError(t, s.Reset())
+}
+
+func TestQueueStorage_Requeue(t *testing.T) {
+
+	spiderID := uuid.New().String()
+	c := colly.NewCollector()
+
+	s, err := store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	require.NoError(t, s.AddRequest(QueueRequest(t, "https://example.com/")))
+	require.NoError(t, s.Checkpoint())
+
+	// taken and put back when the queue stopped
+	req := TakeRequest(t, c, s)
+	require.NoError(t, s.Checkpoint())
+	s.Requeue(req)
+
+	size, err := s.QueueSize()
+	require.NoError(t, err)
+	assert.Equal(t, 1, size)
+
+	// the run is killed after the checkpoint, the single row is resumed
+	require.NoError(t, s.Checkpoint())
+
+	s, err = store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	resumed, err := s.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+
+	require.NoError(t, s.Reset())
+}
+
+func TestQueueStorage_Delayed(t *testing.T) {
+
+	spiderID := uuid.New().String()
+	c := colly.NewCollector()
+
+	s, err := store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	require.NoError(t, s.AddRequest(QueueRequest(t, "https://example.com/failed")))
+
+	q, err := collect.NewQueue(1, s)
+	require.NoError(t, err)
+
+	// the failed request is processed and waits for the retry backoff when the queue stops
+	req := TakeRequest(t, c, s)
+	require.NoError(t, q.Delay(req, time.Hour))
+	s.Done(req)
+	q.Stop()
+
+	// the run is stopped by the budget, the delayed request is resumed
+	require.NoError(t, s.Checkpoint())
+
+	s, err = store.NewQueueStorage(spiderID, "spider", queueDSN, frontier.BFS)
+	require.NoError(t, err)
+	resumed, err := s.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+
+	req = TakeRequest(t, c, s)
+	assert.Equal(t, "https://example.com/failed", req.URL.String())
+
+	require.NoError(t, s.Reset())
+}
+
+func QueueRequest(t *testing.T, uri string) []byte {
+	t.Helper()
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+
+	req := &colly.Request{URL: u, Method: "GET", Depth: 1, Ctx: colly.NewContext()}
+	b, err := req.Marshal()
+	require.NoError(t, err)
+
+	return b
+}
+
+func TakeRequest(t *testing.T, c *colly.Collector, s *store.QueueStorage) *colly.Request {
+	t.Helper()
+
+	b, err := s.GetRequest()
+	require.NoError(t, err)
+	require.NotNil(t, b)
+
+	req, err := c.UnmarshalRequest(b)
+	require.NoError(t, err)
+
+	return req
+}
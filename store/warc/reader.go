@@ -0,0 +1,240 @@
+package warc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/collect/events"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/gocolly/colly/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// Reader of the WARC file records
+type Reader struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+// NewReader of the uncompressed WARC file
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Next record of the file, io.EOF after the last one
+func (r *Reader) Next() (*Record, error) {
+
+	record := &Record{
+		Offset: r.offset,
+		Header: textproto.MIMEHeader{},
+	}
+
+	version, err := r.line()
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, fmt.Errorf("warc record at %d: version line expected, got %q", record.Offset, version)
+	}
+
+	for {
+		line, err := r.line()
+		if err != nil {
+			return nil, unexpected(err)
+		}
+
+		if len(line) == 0 {
+			break
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("warc record at %d: field is invalid: %q", record.Offset, line)
+		}
+
+		record.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	length := record.contentLength()
+	if length < 0 {
+		return nil, fmt.Errorf("warc record at %d: content length is invalid", record.Offset)
+	}
+
+	record.Block = make([]byte, length)
+	if _, err = io.ReadFull(r.r, record.Block); err != nil {
+		return nil, unexpected(err)
+	}
+	r.offset += length
+
+	// two line breaks after the block
+	for range 2 {
+		if _, err = r.line(); err != nil {
+			return nil, unexpected(err)
+		}
+	}
+
+	return record, nil
+}
+
+// line without the line break, the offset is moved by the line read
+func (r *Reader) line() (string, error) {
+
+	line, err := r.r.ReadString('\n')
+	r.offset += int64(len(line))
+
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// unexpected end of the record
+func unexpected(err error) error {
+
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// Feed the html pages of the response and the resource records to the extractor, e.g. pipe.Pipeline.
+// The entities are matched by the selector, the payload references the file and the record offset.
+// Returns the number of the extracted entities.
+func Feed(r io.Reader, file string, extractor config.Extractor, selector string) (int, error) {
+
+	reader := NewReader(r)
+	extracted := 0
+
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return extracted, nil
+		}
+		if err != nil {
+			return extracted, err
+		}
+
+		if kind := record.Type(); kind != Response && kind != Resource {
+			continue
+		}
+
+		doc, err := Document(record, file)
+		if err != nil {
+			slog.Warn("warc: record skipped",
+				slog.String("url", record.TargetURI()),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		// not a page, e.g. error status or image
+		if doc == nil {
+			continue
+		}
+
+		for _, selected := range events.Selections(doc, selector) {
+
+			ok, err := extractor.Extract(doc, selected)
+			if err != nil {
+				return extracted, fmt.Errorf("warc extract %s: %w", record.TargetURI(), err)
+			}
+
+			if ok {
+				extracted++
+			}
+		}
+	}
+}
+
+// Document of the response or the resource record, nil if the record is not the html page of success status
+func Document(record *Record, file string) (*colly.HTMLElement, error) {
+
+	header, body, err := content(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if header == nil || !strings.Contains(header.Get("Content-Type"), "html") {
+		return nil, nil
+	}
+
+	uri, err := url.Parse(record.TargetURI())
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	query.Url = uri
+
+	ctx := colly.NewContext()
+	ctx.Put(pipe.WARCCtx, &pipe.WARCRef{File: file, Offset: record.Offset})
+
+	response := &colly.Response{
+		StatusCode: http.StatusOK,
+		Body:       body,
+		Ctx:        ctx,
+		Headers:    &header,
+		Request: &colly.Request{
+			URL:     uri,
+			Method:  http.MethodGet,
+			Headers: &http.Header{},
+			Ctx:     ctx,
+		},
+	}
+
+	html := query.Find("html")
+	if html.Length() == 0 {
+		return nil, nil
+	}
+
+	return colly.NewHTMLElementFromSelectionNode(response, html, html.Nodes[0], 0), nil
+}
+
+// content of the record: the headers and the decoded body, nil headers if the response status is not success
+func content(record *Record) (http.Header, []byte, error) {
+
+	// the rendered page of the browser
+	if record.Type() == Resource {
+		return http.Header{"Content-Type": {record.Header.Get("Content-Type")}}, record.Block, nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(record.Block)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, nil
+	}
+
+	// the body is archived as received
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		if body, err = gzip.NewReader(resp.Body); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Header, data, nil
+}
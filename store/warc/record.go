@@ -0,0 +1,153 @@
+package warc
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base32"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"maps"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Version of the WARC format written
+	Version = "WARC/1.1"
+
+	// WARCInfo is the record type of the file description
+	WARCInfo = "warcinfo"
+	// Request is the record type of the http request
+	Request = "request"
+	// Response is the record type of the http response
+	Response = "response"
+	// Resource is the record type of the page rendered by the browser
+	Resource = "resource"
+
+	// RequestContentType of the request record block
+	RequestContentType = "application/http;msgtype=request"
+	// ResponseContentType of the response record block
+	ResponseContentType = "application/http;msgtype=response"
+)
+
+// Record of the WARC file: the named fields and the content block
+type Record struct {
+	// Offset of the record in the file, set by the reader
+	Offset int64
+	// Header is the named fields of the record, e.g. WARC-Type
+	Header textproto.MIMEHeader
+	// Block is the content of the record, e.g. the http response with headers
+	Block []byte
+}
+
+// NewRecord of the type with the record id, the date and the block digest
+func NewRecord(kind, targetURI string, block []byte) *Record {
+
+	header := textproto.MIMEHeader{}
+	header.Set("WARC-Type", kind)
+	header.Set("WARC-Record-ID", RecordID())
+	header.Set("WARC-Date", time.Now().UTC().Format(time.RFC3339))
+
+	if len(targetURI) > 0 {
+		header.Set("WARC-Target-URI", targetURI)
+	}
+
+	header.Set("WARC-Block-Digest", Digest(block))
+
+	return &Record{
+		Header: header,
+		Block:  block,
+	}
+}
+
+// Type of the record, e.g. response
+func (r *Record) Type() string {
+	return r.Header.Get("WARC-Type")
+}
+
+// ID of the record
+func (r *Record) ID() string {
+	return r.Header.Get("WARC-Record-ID")
+}
+
+// TargetURI of the request and the response records
+func (r *Record) TargetURI() string {
+	return r.Header.Get("WARC-Target-URI")
+}
+
+// WriteTo the writer: the version line, the named fields, the block and two line breaks
+func (r *Record) WriteTo(w io.Writer) (int64, error) {
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(Version + "\r\n")
+
+	// the record type goes first for the readability
+	_, _ = fmt.Fprintf(buf, "WARC-Type: %s\r\n", r.Type())
+	for _, name := range sortedKeys(r.Header) {
+		if name == "Warc-Type" || name == "Content-Length" {
+			continue
+		}
+		for _, value := range r.Header[name] {
+			_, _ = fmt.Fprintf(buf, "%s: %s\r\n", fieldName(name), value)
+		}
+	}
+	_, _ = fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n", len(r.Block))
+
+	buf.Write(r.Block)
+	buf.WriteString("\r\n\r\n")
+
+	return buf.WriteTo(w)
+}
+
+// RecordID is the new unique record id
+func RecordID() string {
+	return "<urn:uuid:" + uuid.NewString() + ">"
+}
+
+// Digest of the block in the sha1 base32 form
+func Digest(block []byte) string {
+	sum := sha1.Sum(block)
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
+
+// contentLength of the record, -1 if not valid
+func (r *Record) contentLength() int64 {
+
+	length, err := strconv.ParseInt(r.Header.Get("Content-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return -1
+	}
+
+	return length
+}
+
+// fieldNames of the WARC specification by the canonical header key
+var fieldNames = map[string]string{
+	"Warc-Record-Id":   "WARC-Record-ID",
+	"Warc-Target-Uri":  "WARC-Target-URI",
+	"Warc-Warcinfo-Id": "WARC-Warcinfo-ID",
+	"Warc-Ip-Address":  "WARC-IP-Address",
+}
+
+// fieldName of the specification, e.g. WARC-Record-ID of the canonical Warc-Record-Id
+func fieldName(key string) string {
+
+	if name, ok := fieldNames[key]; ok {
+		return name
+	}
+
+	if rest, ok := strings.CutPrefix(key, "Warc-"); ok {
+		return "WARC-" + rest
+	}
+
+	return key
+}
+
+// sortedKeys of the header, so the record fields are written in the same order
+func sortedKeys(header textproto.MIMEHeader) []string {
+	return slices.Sorted(maps.Keys(header))
+}
@@ -0,0 +1,222 @@
+package warc_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"github.com/editorpost/donq/res"
+	"github.com/editorpost/spider/collect"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/editorpost/spider/store"
+	"github.com/editorpost/spider/store/warc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("X-Page", r.URL.Path)
+
+		if r.URL.Path == "/" {
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "session"})
+			_, _ = w.Write([]byte(`<html><a href="/news/1.html">1</a><a href="/news/2.html">2</a><a href="/missing">3</a></html>`))
+			return
+		}
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// compressed as requested
+		if r.URL.Path == "/news/2.html" && strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			_, _ = fmt.Fprintf(gz, `<html><article>%s</article></html>`, r.URL.Path)
+			_ = gz.Close()
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `<html><article>%s</article></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	storage, err := store.NewStorage(res.S3{Bucket: store.LocalBucket, EndPoint: t.TempDir()}, "warc")
+	require.NoError(t, err)
+
+	// the file per response
+	writer := warc.NewWriter(storage, "test", 1, 0)
+
+	args := &config.Config{
+		StartURL:        srv.URL,
+		ExtractURLs:     []string{srv.URL + "/news/{num}.html"},
+		ExtractSelector: "article",
+	}
+	require.NoError(t, args.Normalize())
+
+	payloads := make([]*pipe.Payload, 0)
+	mute := &sync.Mutex{}
+
+	crawled := pipe.NewPipeline().Finisher(func(p *pipe.Payload) error {
+		mute.Lock()
+		payloads = append(payloads, p)
+		mute.Unlock()
+		return nil
+	})
+
+	crawler, err := collect.NewCrawler(args, &config.Deps{Extractor: crawled, WARC: writer})
+	require.NoError(t, err)
+	require.NoError(t, crawler.Run(context.Background()))
+	require.NoError(t, writer.Close())
+	require.Len(t, payloads, 2)
+
+	for _, p := range payloads {
+
+		require.NotNil(t, p.WARC)
+		assert.Equal(t, p.WARC.File, p.Data[pipe.WARCFileField])
+		assert.Equal(t, p.WARC.Offset, p.Data[pipe.WARCOffsetField])
+
+		data, err := storage.Load(p.WARC.File)
+		require.NoError(t, err)
+
+		// the payload references the response record
+		record, err := warc.NewReader(bytes.NewReader(data[p.WARC.Offset:])).Next()
+		require.NoError(t, err)
+		assert.Equal(t, warc.Response, record.Type())
+		assert.Equal(t, p.OriginalURL.String(), record.TargetURI())
+		assert.Contains(t, string(record.Block), "X-Page: "+p.OriginalURL.Path)
+		assert.Equal(t, p.OriginalURL.Path, p.Selection.Text())
+
+		// the body is archived as received
+		if p.OriginalURL.Path == "/news/2.html" {
+			head, body, _ := strings.Cut(string(record.Block), "\r\n\r\n")
+			assert.Contains(t, head, "Content-Encoding: gzip")
+			assert.True(t, strings.HasPrefix(body, "\x1f\x8b"))
+		}
+
+		// the request record follows the response
+		reader := warc.NewReader(bytes.NewReader(data))
+		types := make([]string, 0)
+		for {
+			rec, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			types = append(types, rec.Type())
+			if rec.Type() == warc.Request {
+				assert.Equal(t, record.ID(), rec.Header.Get("WARC-Concurrent-To"))
+				assert.Contains(t, string(rec.Block), "GET "+p.OriginalURL.Path+" HTTP/1.1")
+				// the headers sent by the transport and the cookies of the jar
+				assert.Contains(t, string(rec.Block), "Accept-Encoding: gzip")
+				assert.Contains(t, string(rec.Block), "Cookie: sid=session")
+			}
+		}
+		assert.Equal(t, []string{warc.WARCInfo, warc.Response, warc.Request}, types)
+
+		// the archived page is fed back to the pipeline
+		fed := make([]*pipe.Payload, 0)
+		extracted, err := warc.Feed(bytes.NewReader(data), p.WARC.File, pipe.NewPipeline().Finisher(func(p *pipe.Payload) error {
+			fed = append(fed, p)
+			return nil
+		}), args.ExtractSelector)
+		require.NoError(t, err)
+		assert.Equal(t, 1, extracted)
+		require.Len(t, fed, 1)
+		assert.Equal(t, p.OriginalURL.String(), fed[0].OriginalURL.String())
+		assert.Equal(t, *p.WARC, *fed[0].WARC)
+		assert.Equal(t, p.Selection.Text(), fed[0].Selection.Text())
+	}
+}
+
+func TestWriter_Autosave(t *testing.T) {
+
+	storage, err := store.NewStorage(res.S3{Bucket: store.LocalBucket, EndPoint: t.TempDir()}, "warc")
+	require.NoError(t, err)
+
+	// the file is not full, but rotated by the interval
+	writer := warc.NewWriter(storage, "test", 1<<20, 10*time.Millisecond)
+
+	refs := make([]*pipe.WARCRef, 0)
+
+	for _, uri := range []string{"https://example.com/news/1.html", "https://example.com/news/2.html"} {
+
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+
+		body := []byte("<html></html>")
+		ref, err := writer.Write(
+			&http.Request{URL: u, Method: http.MethodGet, Header: http.Header{}},
+			&http.Response{StatusCode: http.StatusOK, Header: http.Header{}},
+			body,
+		)
+		require.NoError(t, err)
+
+		// the missing file is loaded empty
+		var data []byte
+		require.Eventually(t, func() bool {
+			data, err = storage.Load(ref.File)
+			return err == nil && int64(len(data)) > ref.Offset
+		}, time.Second, 10*time.Millisecond)
+
+		record, err := warc.NewReader(bytes.NewReader(data[ref.Offset:])).Next()
+		require.NoError(t, err)
+		assert.Equal(t, uri, record.TargetURI())
+
+		refs = append(refs, ref)
+	}
+
+	// the saved file is not uploaded again, the next records are in the next file
+	assert.NotEqual(t, refs[0].File, refs[1].File)
+
+	require.NoError(t, writer.Close())
+	require.NoError(t, writer.Close())
+}
+
+func TestWriter_Resource(t *testing.T) {
+
+	storage, err := store.NewStorage(res.S3{Bucket: store.LocalBucket, EndPoint: t.TempDir()}, "warc")
+	require.NoError(t, err)
+
+	writer := warc.NewWriter(storage, "test", 1<<20, 0)
+
+	// the page rendered by the browser
+	uri := "https://example.com/news/1.html"
+	ref, err := writer.Resource(uri, "text/html; charset=utf-8", []byte(`<html><article>rendered</article></html>`))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	data, err := storage.Load(ref.File)
+	require.NoError(t, err)
+
+	record, err := warc.NewReader(bytes.NewReader(data[ref.Offset:])).Next()
+	require.NoError(t, err)
+	assert.Equal(t, warc.Resource, record.Type())
+	assert.Equal(t, uri, record.TargetURI())
+	assert.Equal(t, "text/html; charset=utf-8", record.Header.Get("Content-Type"))
+
+	// the rendered page is fed back to the pipeline
+	fed := make([]*pipe.Payload, 0)
+	extracted, err := warc.Feed(bytes.NewReader(data), ref.File, pipe.NewPipeline().Finisher(func(p *pipe.Payload) error {
+		fed = append(fed, p)
+		return nil
+	}), "article")
+	require.NoError(t, err)
+	assert.Equal(t, 1, extracted)
+	require.Len(t, fed, 1)
+	assert.Equal(t, "rendered", fed[0].Selection.Text())
+	assert.Equal(t, *ref, *fed[0].WARC)
+}
@@ -0,0 +1,226 @@
+package warc
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/editorpost/spider/extract/pipe"
+	"github.com/editorpost/spider/store"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Software is the name of the warcinfo record writer
+const Software = "editorpost/spider"
+
+// Writer of the request and the response records to the WARC files of the storage.
+// The file is kept in memory and saved when the max size is reached, every interval or on Close,
+// the next file is started after it. The saved file is never uploaded again,
+// so the killed run loses the records of the last interval only.
+type Writer struct {
+	storage store.Storage
+	prefix  string
+	maxSize int64
+	lock    *sync.Mutex
+	buf     *bytes.Buffer
+	// file is the name of the current file
+	file string
+	// files written, the sequence of the file name
+	files  int
+	closed bool
+	done   chan struct{}
+}
+
+// NewWriter of the files named by the prefix, e.g. the spider id, rotated by the max size in bytes
+// and every interval, zero interval rotates by the max size and saves on Close only
+func NewWriter(storage store.Storage, prefix string, maxSize int64, interval time.Duration) *Writer {
+
+	w := &Writer{
+		storage: storage,
+		prefix:  prefix,
+		maxSize: maxSize,
+		lock:    &sync.Mutex{},
+		buf:     &bytes.Buffer{},
+		done:    make(chan struct{}),
+	}
+
+	if interval > 0 {
+		go w.autosave(interval)
+	}
+
+	return w
+}
+
+// Write the request and the response records of the raw http exchange: the request with the headers sent
+// and the response with the body as received, returns the file and the offset of the response record
+func (w *Writer) Write(req *http.Request, resp *http.Response, body []byte) (*pipe.WARCRef, error) {
+
+	uri := req.URL.String()
+
+	response := NewRecord(Response, uri, ResponseBlock(resp, body))
+	response.Header.Set("Content-Type", ResponseContentType)
+	response.Header.Set("WARC-Payload-Digest", Digest(body))
+
+	request := NewRecord(Request, uri, RequestBlock(req))
+	request.Header.Set("Content-Type", RequestContentType)
+	request.Header.Set("WARC-Concurrent-To", response.ID())
+
+	return w.write(response, request)
+}
+
+// Resource writes the resource record of the page rendered by the browser,
+// the rendered body is not the http response, so the block is the body only
+func (w *Writer) Resource(uri, contentType string, body []byte) (*pipe.WARCRef, error) {
+
+	resource := NewRecord(Resource, uri, body)
+	resource.Header.Set("Content-Type", contentType)
+
+	return w.write(resource)
+}
+
+// write the records to the current file, returns the file and the offset of the first record
+func (w *Writer) write(records ...*Record) (*pipe.WARCRef, error) {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.buf.Len() == 0 {
+		w.start()
+	}
+
+	ref := &pipe.WARCRef{
+		File:   w.file,
+		Offset: int64(w.buf.Len()),
+	}
+
+	// buffer writes don't fail
+	for _, record := range records {
+		_, _ = record.WriteTo(w.buf)
+	}
+
+	if int64(w.buf.Len()) >= w.maxSize {
+		if err := w.flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ref, nil
+}
+
+// Close saves the current file and stops the autosave
+func (w *Writer) Close() error {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.closed {
+		w.closed = true
+		close(w.done)
+	}
+
+	return w.flush()
+}
+
+// autosave rotates the current file every interval until Close
+func (w *Writer) autosave(interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.lock.Lock()
+			if err := w.flush(); err != nil {
+				slog.Warn("warc: autosave failed", slog.String("error", err.Error()))
+			}
+			w.lock.Unlock()
+		}
+	}
+}
+
+// start the next file with the warcinfo record
+func (w *Writer) start() {
+
+	w.files++
+	w.file = fmt.Sprintf("%s-%s-%05d.warc", w.prefix, time.Now().UTC().Format("20060102150405"), w.files)
+
+	info := NewRecord(WARCInfo, "", []byte("software: "+Software+"\r\nformat: WARC File Format 1.1\r\n"))
+	info.Header.Set("Content-Type", "application/warc-fields")
+	info.Header.Set("WARC-Filename", w.file)
+
+	_, _ = info.WriteTo(w.buf)
+}
+
+// flush the current file to the storage, the next write starts the next file
+func (w *Writer) flush() error {
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	// the buffer is reused by the next file
+	defer w.buf.Reset()
+
+	if err := w.storage.Save(w.buf.Bytes(), w.file); err != nil {
+		return fmt.Errorf("warc file %s: %w", w.file, err)
+	}
+
+	return nil
+}
+
+// RequestBlock of the request record: the request line, the headers and the body sent
+func RequestBlock(req *http.Request) []byte {
+
+	host := req.Host
+	if len(host) == 0 {
+		host = req.URL.Host
+	}
+
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "%s %s HTTP/1.1\r\n", req.Method, req.URL.RequestURI())
+	_, _ = fmt.Fprintf(buf, "Host: %s\r\n", host)
+	_ = req.Header.WriteSubset(buf, map[string]bool{"Host": true})
+
+	var body []byte
+	if req.GetBody != nil {
+		if r, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(r)
+			_ = r.Close()
+		}
+	}
+
+	if len(body) > 0 && req.Header.Get("Content-Length") == "" {
+		_, _ = fmt.Fprintf(buf, "Content-Length: %d\r\n", len(body))
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body)
+
+	return buf.Bytes()
+}
+
+// ResponseBlock of the response record: the status line, the headers and the body as received
+func ResponseBlock(resp *http.Response, body []byte) []byte {
+
+	status := resp.Status
+	if len(status) == 0 {
+		status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	proto := resp.Proto
+	if len(proto) == 0 {
+		proto = "HTTP/1.1"
+	}
+
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "%s %s\r\n", proto, status)
+	_ = resp.Header.Write(buf)
+	buf.WriteString("\r\n")
+	buf.Write(body)
+
+	return buf.Bytes()
+}
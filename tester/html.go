@@ -39,7 +39,7 @@ func GetDocumentSelections(t *testing.T, path string, selector string) *colly.HT
 	query, err := goquery.NewDocumentFromReader(strings.NewReader(GetHTML(t, path)))
 	require.NoError(t, err)
 
-	ctx := &colly.Context{}
+	ctx := colly.NewContext()
 	resp := &colly.Response{
 		Request: &colly.Request{
 			Ctx: ctx,
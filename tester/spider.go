@@ -27,6 +27,17 @@ func NewSpiderWith(t *testing.T, server *TestServer) *setup.Spider {
 	return s
 }
 
+// NewSpiderCache of the server recorded to the archive dir or replayed from it,
+// the replayed spider runs the same config without the server
+func NewSpiderCache(t *testing.T, server *TestServer, mode, dir string) *setup.Spider {
+
+	s := NewSpiderWith(t, server)
+	s.Collect.Cache = config.Cache{Mode: mode, Dir: dir}
+	require.NoError(t, s.Collect.Cache.Normalize())
+
+	return s
+}
+
 func NewArgs() *config.Config {
 	return &config.Config{
 		StartURL:        "",
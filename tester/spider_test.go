@@ -1,12 +1,16 @@
 package tester_test
 
 import (
+	"context"
 	"encoding/json"
+	"github.com/editorpost/spider/collect/config"
+	"github.com/editorpost/spider/extract/pipe"
 	"github.com/editorpost/spider/manage/provider/windmill"
 	"github.com/editorpost/spider/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -19,7 +23,7 @@ func TestNewSpider(t *testing.T) {
 	require.NotNil(t, s)
 
 	// check spider
-	require.NoError(t, windmill.Check(s))
+	require.NoError(t, windmill.Check(context.Background(), s))
 
 	// read results.json
 	f, err := os.ReadFile(windmill.JobResultFile)
@@ -34,3 +38,41 @@ func TestNewSpider(t *testing.T) {
 	// remove results.json
 	require.NoError(t, os.Remove(windmill.JobResultFile))
 }
+
+func TestNewSpiderCache(t *testing.T) {
+
+	srv := tester.NewServer("./fixtures")
+	archive := t.TempDir()
+
+	crawl := func(mode string) map[string]bool {
+
+		// whole site is crawled, the limited one depends on the threads order
+		s := tester.NewSpiderCache(t, srv, mode, archive)
+		s.Collect.ExtractLimit = 0
+		s.Extract.Media.Enabled = false
+
+		extracted := make(map[string]bool)
+		mute := &sync.Mutex{}
+		s.Pipeline().Finisher(func(payload *pipe.Payload) error {
+			mute.Lock()
+			extracted[payload.OriginalURL.String()] = true
+			mute.Unlock()
+			return nil
+		})
+
+		crawler, err := s.NewCrawler()
+		require.NoError(t, err)
+		require.NoError(t, crawler.Run(context.Background()))
+
+		return extracted
+	}
+
+	recorded := crawl(config.CacheRecord)
+	require.NotEmpty(t, recorded)
+
+	// same config runs against the archive without the server
+	srv.Close()
+	assert.Equal(t, recorded, crawl(config.CacheReplay))
+
+	tester.CleanTestBucket(t)
+}